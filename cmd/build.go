@@ -31,6 +31,9 @@ type BuildCmd struct {
 	BuildSequential     bool
 	MaxConcurrentBuilds int
 	ForceDependencies   bool
+
+	BuildReport string
+	Lint        bool
 }
 
 // NewBuildCmd creates a new devspace build command
@@ -66,6 +69,9 @@ Builds all defined images and pushes them
 	buildCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
 	buildCmd.Flags().BoolVar(&cmd.SkipPushLocalKubernetes, "skip-push-local-kube", false, "Skips image pushing, if a local kubernetes environment is detected")
 
+	buildCmd.Flags().StringVar(&cmd.BuildReport, "build-report", "", "Writes a json report of all built images (including dependencies) to the given file")
+	buildCmd.Flags().BoolVar(&cmd.Lint, "lint", false, "Checks the Dockerfiles of all images for common problems before building")
+
 	return buildCmd
 }
 
@@ -128,6 +134,12 @@ func (cmd *BuildCmd) Run(f factory.Factory) error {
 		}
 	}
 
+	// Create the build report if requested
+	var buildReport *build.Report
+	if cmd.BuildReport != "" {
+		buildReport = build.NewReport()
+	}
+
 	// Dependencies
 	dependencies, err := f.NewDependencyManager(configInterface, client, configOptions, log).BuildAll(dependency.BuildOptions{
 		Dependencies:            cmd.Dependency,
@@ -141,6 +153,8 @@ func (cmd *BuildCmd) Run(f factory.Factory) error {
 			ForceRebuild:              cmd.ForceBuild,
 			Sequential:                cmd.BuildSequential,
 			MaxConcurrentBuilds:       cmd.MaxConcurrentBuilds,
+			Lint:                      cmd.Lint,
+			Report:                    buildReport,
 		},
 	})
 	if err != nil {
@@ -155,6 +169,8 @@ func (cmd *BuildCmd) Run(f factory.Factory) error {
 			ForceRebuild:              cmd.ForceBuild,
 			Sequential:                cmd.BuildSequential,
 			MaxConcurrentBuilds:       cmd.MaxConcurrentBuilds,
+			Lint:                      cmd.Lint,
+			Report:                    buildReport,
 		}, log)
 		if err != nil {
 			if strings.Index(err.Error(), "no space left on device") != -1 {
@@ -179,5 +195,13 @@ func (cmd *BuildCmd) Run(f factory.Factory) error {
 		log.Donef("Successfully built images for dependencies: %s", strings.Join(cmd.Dependency, " "))
 	}
 
+	// Write the build report
+	if buildReport != nil {
+		err = buildReport.Save(cmd.BuildReport)
+		if err != nil {
+			return errors.Wrap(err, "save build report")
+		}
+	}
+
 	return nil
 }
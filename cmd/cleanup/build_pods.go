@@ -0,0 +1,106 @@
+package cleanup
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type buildPodsCmd struct {
+	*flags.GlobalFlags
+}
+
+func newBuildPodsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &buildPodsCmd{GlobalFlags: globalFlags}
+
+	buildPodsCmd := &cobra.Command{
+		Use:   "build-pods",
+		Short: "Deletes all leftover kaniko build pods",
+		Long: `
+#######################################################
+########### devspace cleanup build-pods ###############
+#######################################################
+Deletes all kaniko build pods, including reusable build
+pods, in the current namespace and in all namespaces
+configured in images.*.build.kaniko.namespace
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunCleanupBuildPods(f, cobraCmd, args)
+		}}
+
+	return buildPodsCmd
+}
+
+// RunCleanupBuildPods executes the cleanup build-pods command logic
+func (cmd *buildPodsCmd) RunCleanupBuildPods(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	// Set config root
+	log := f.GetLog()
+	configOptions := cmd.ToConfigOptions(log)
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// Create kube client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+	configOptions.KubeClient = client
+
+	// Load config
+	configInterface, err := configLoader.Load(configOptions, log)
+	if err != nil {
+		return err
+	}
+
+	// Collect all namespaces build pods could have been started in
+	namespaces := []string{client.Namespace()}
+	for _, imageConfig := range configInterface.Config().Images {
+		if imageConfig.Build == nil || imageConfig.Build.Kaniko == nil || imageConfig.Build.Kaniko.Namespace == "" {
+			continue
+		}
+
+		found := false
+		for _, namespace := range namespaces {
+			if namespace == imageConfig.Build.Kaniko.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			namespaces = append(namespaces, imageConfig.Build.Kaniko.Namespace)
+		}
+	}
+
+	deleted := 0
+	for _, namespace := range namespaces {
+		pods, err := kaniko.DeleteBuildPods(client, namespace)
+		for _, pod := range pods {
+			log.Donef("Deleted build pod %s/%s", namespace, pod)
+		}
+		if err != nil {
+			return err
+		}
+
+		deleted += len(pods)
+	}
+
+	if deleted == 0 {
+		log.Info("No build pods found")
+		return nil
+	}
+
+	log.Donef("Successfully cleaned up %d build pods", deleted)
+	return nil
+}
@@ -15,6 +15,10 @@ import (
 
 type imagesCmd struct {
 	*flags.GlobalFlags
+
+	Registry bool
+	Keep     int
+	DryRun   bool
 }
 
 func newImagesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
@@ -28,6 +32,11 @@ func newImagesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comm
 ############# devspace cleanup images #################
 #######################################################
 Deletes all locally created docker images from docker
+
+With --registry old tags of all configured images are 
+deleted from the remote registries instead. Tags that
+are used by pods in the cluster and the most recent
+tags are kept.
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -35,6 +44,9 @@ Deletes all locally created docker images from docker
 			return cmd.RunCleanupImages(f, cobraCmd, args)
 		}}
 
+	imagesCmd.Flags().BoolVar(&cmd.Registry, "registry", false, "Delete old image tags from the remote registries instead of local images")
+	imagesCmd.Flags().IntVar(&cmd.Keep, "keep", 5, "The number of most recent tags per image to keep in the registry")
+	imagesCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Only print the registry tags that would be deleted")
 	return imagesCmd
 }
 
@@ -67,6 +79,10 @@ func (cmd *imagesCmd) RunCleanupImages(f factory.Factory, cobraCmd *cobra.Comman
 		return err
 	}
 
+	if cmd.Registry {
+		return cmd.cleanupRegistry(f, configLoader, client, log)
+	}
+
 	// Load config
 	configInterface, err := configLoader.Load(cmd.ToConfigOptions(log), log)
 	if err != nil {
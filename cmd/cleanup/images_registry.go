@@ -0,0 +1,206 @@
+package cleanup
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/docker"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	"github.com/loft-sh/devspace/pkg/devspace/registry"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type registryRepository struct {
+	name   string
+	domain string
+	path   string
+
+	options *registry.PruneOptions
+}
+
+// cleanupRegistry deletes old tags of all configured images from their remote registries
+func (cmd *imagesCmd) cleanupRegistry(f factory.Factory, configLoader loader.ConfigLoader, dockerClient docker.Client, log log.Logger) error {
+	if cmd.Keep < 0 {
+		return errors.New("--keep must be greater or equal to 0")
+	}
+
+	// Create kube client, we need it to find out which tags are still in use
+	kubeClient, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+
+	configOptions := cmd.ToConfigOptions(log)
+	configOptions.KubeClient = kubeClient
+	configInterface, err := configLoader.Load(configOptions, log)
+	if err != nil {
+		return err
+	}
+
+	config := configInterface.Config()
+	if len(config.Images) == 0 {
+		log.Done("No images found in config to clean up")
+		return nil
+	}
+
+	// Group image configs by repository, multiple image configs could use the same image
+	repositories := map[string]*registryRepository{}
+	cache := configInterface.Generated().GetActive()
+	for imageConfigName, imageConfig := range config.Images {
+		ref, err := reference.ParseNormalizedNamed(imageConfig.Image)
+		if err != nil {
+			return errors.Wrapf(err, "parse image %s", imageConfig.Image)
+		}
+
+		repository, ok := repositories[ref.Name()]
+		if !ok {
+			repository = &registryRepository{
+				name:   ref.Name(),
+				domain: reference.Domain(ref),
+				path:   reference.Path(ref),
+				options: &registry.PruneOptions{
+					Keep:             cmd.Keep,
+					ProtectedTags:    map[string]bool{},
+					ProtectedDigests: map[string]bool{},
+					DryRun:           cmd.DryRun,
+				},
+			}
+			repositories[ref.Name()] = repository
+		}
+
+		// keep the static tags from the config and the last built tag
+		for _, tag := range imageConfig.Tags {
+			repository.options.ProtectedTags[tag] = true
+		}
+		if imageCache, ok := cache.Images[imageConfigName]; ok && imageCache.Tag != "" {
+			repository.options.ProtectedTags[imageCache.Tag] = true
+		}
+	}
+
+	// Keep all tags and digests that are used by pods in the cluster
+	err = protectUsedImages(kubeClient, namespacesOf(config, kubeClient), repositories)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	defer log.StopWait()
+	deleted := 0
+	for _, name := range names {
+		repository := repositories[name]
+		registryName, err := pullsecrets.GetRegistryFromImageName(name)
+		if err != nil {
+			return err
+		}
+
+		authConfig, err := dockerClient.GetAuthConfig(registryName, true)
+		if err != nil {
+			log.Warnf("Couldn't retrieve credentials for registry %s: %v", repository.domain, err)
+			authConfig = nil
+		}
+
+		log.StartWait("Collecting tags of " + name)
+		tags, err := registry.Prune(registry.NewClient(repository.domain, authConfig), repository.path, repository.options, log)
+		log.StopWait()
+		for _, tag := range tags {
+			if cmd.DryRun {
+				log.Infof("Would delete %s:%s (%s)", name, tag.Name, tag.Digest)
+			} else {
+				log.Donef("Deleted %s:%s", name, tag.Name)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		deleted += len(tags)
+	}
+
+	if cmd.DryRun {
+		log.Infof("Would delete %d tags", deleted)
+		return nil
+	}
+
+	log.Donef("Successfully deleted %d tags", deleted)
+	return nil
+}
+
+// namespacesOf returns the current namespace and all namespaces of the configured deployments
+func namespacesOf(config *latest.Config, kubeClient kubectl.Client) []string {
+	namespaces := []string{kubeClient.Namespace()}
+	for _, deployConfig := range config.Deployments {
+		if deployConfig.Namespace == "" {
+			continue
+		}
+
+		found := false
+		for _, namespace := range namespaces {
+			if namespace == deployConfig.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			namespaces = append(namespaces, deployConfig.Namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// protectUsedImages adds all tags and digests of the repositories, that are used by pods in the given namespaces, to the protected ones
+func protectUsedImages(kubeClient kubectl.Client, namespaces []string, repositories map[string]*registryRepository) error {
+	for _, namespace := range namespaces {
+		pods, err := kubeClient.KubeClient().CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "list pods in namespace %s", namespace)
+		}
+
+		for _, pod := range pods.Items {
+			images := []string{}
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				images = append(images, container.Image)
+			}
+			for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+				images = append(images, strings.TrimPrefix(status.ImageID, "docker-pullable://"))
+			}
+
+			for _, image := range images {
+				ref, err := reference.ParseNormalizedNamed(image)
+				if err != nil {
+					continue
+				}
+
+				repository, ok := repositories[ref.Name()]
+				if !ok {
+					continue
+				}
+
+				if reference.IsNameOnly(ref) {
+					repository.options.ProtectedTags["latest"] = true
+				}
+				if tagged, ok := ref.(reference.Tagged); ok {
+					repository.options.ProtectedTags[tagged.Tag()] = true
+				}
+				if digested, ok := ref.(reference.Digested); ok {
+					repository.options.ProtectedDigests[digested.Digest().String()] = true
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"os"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/exit"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -23,6 +25,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// diffChangesExitCode is the exit code of deploy --diff if there are changes
+const diffChangesExitCode = 2
+
 // DeployCmd holds the required data for the down cmd
 type DeployCmd struct {
 	*flags.GlobalFlags
@@ -31,12 +36,14 @@ type DeployCmd struct {
 	SkipBuild           bool
 	BuildSequential     bool
 	MaxConcurrentBuilds int
+	BuildReport         string
 
-	ForceDeploy         bool
-	SkipDeploy          bool
-	Deployments         string
-	ForceDependencies   bool
-	VerboseDependencies bool
+	ForceDeploy          bool
+	SkipDeploy           bool
+	Deployments          string
+	MaxConcurrentDeploys int
+	ForceDependencies    bool
+	VerboseDependencies  bool
 
 	SkipPush                bool
 	SkipPushLocalKubernetes bool
@@ -46,6 +53,8 @@ type DeployCmd struct {
 	Wait    bool
 	Timeout int
 
+	Diff bool
+
 	log logpkg.Logger
 }
 
@@ -68,6 +77,7 @@ Deploys the current project to a Space or namespace:
 devspace deploy
 devspace deploy -n some-namespace
 devspace deploy --kube-context=deploy-context
+devspace deploy --diff
 #######################################################`,
 		Args: cobra.NoArgs,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
@@ -88,10 +98,12 @@ devspace deploy --kube-context=deploy-context
 	deployCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips building of images")
 	deployCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
 	deployCmd.Flags().IntVar(&cmd.MaxConcurrentBuilds, "max-concurrent-builds", 0, "The maximum number of image builds built in parallel (0 for infinite)")
+	deployCmd.Flags().StringVar(&cmd.BuildReport, "build-report", "", "Writes a json report of all built images (including dependencies) to the given file")
 
 	deployCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
 	deployCmd.Flags().BoolVar(&cmd.SkipDeploy, "skip-deploy", false, "Skips deploying and only builds images")
 	deployCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
+	deployCmd.Flags().IntVar(&cmd.MaxConcurrentDeploys, "max-concurrent-deploys", 1, "The maximum number of deployments deployed in parallel, deployments wait for the deployments they depend on (1 deploys one after another)")
 
 	deployCmd.Flags().StringSliceVar(&cmd.SkipDependency, "skip-dependency", []string{}, "Skips deploying the following dependencies")
 	deployCmd.Flags().StringSliceVar(&cmd.Dependency, "dependency", []string{}, "Deploys only the specific named dependencies")
@@ -99,6 +111,8 @@ devspace deploy --kube-context=deploy-context
 	deployCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "If true will wait for pods to be running or fails after given timeout")
 	deployCmd.Flags().IntVar(&cmd.Timeout, "timeout", 120, "Timeout until deploy should stop waiting")
 
+	deployCmd.Flags().BoolVar(&cmd.Diff, "diff", false, "Shows the differences between the rendered deployments and the cluster without building or deploying anything. Exits with code 2 if there are changes")
+
 	return deployCmd
 }
 
@@ -124,6 +138,12 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 		return err
 	}
 
+	// a diff compares the deployments with the last built images, because building would change the cluster state
+	if cmd.Diff {
+		cmd.SkipBuild = true
+		cmd.SkipPush = true
+	}
+
 	// load generated config
 	generatedConfig, err := configLoader.LoadGenerated(configOptions)
 	if err != nil {
@@ -173,9 +193,11 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 	}
 
 	// create namespace if necessary
-	err = client.EnsureDeployNamespaces(config, cmd.log)
-	if err != nil {
-		return errors.Errorf("unable to create namespace: %v", err)
+	if !cmd.Diff {
+		err = client.EnsureDeployNamespaces(config, cmd.log)
+		if err != nil {
+			return errors.Errorf("unable to create namespace: %v", err)
+		}
 	}
 
 	// create docker client
@@ -184,13 +206,19 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 		dockerClient = nil
 	}
 
+	// create the build report if requested
+	var buildReport *build.Report
+	if cmd.BuildReport != "" {
+		buildReport = build.NewReport()
+	}
+
 	// deploy dependencies
 	dependencies, err := f.NewDependencyManager(configInterface, client, configOptions, cmd.log).DeployAll(dependency.DeployOptions{
 		Dependencies:            cmd.Dependency,
 		SkipDependencies:        cmd.SkipDependency,
 		ForceDeployDependencies: cmd.ForceDependencies,
 		SkipBuild:               cmd.SkipBuild,
-		SkipDeploy:              cmd.SkipDeploy,
+		SkipDeploy:              cmd.SkipDeploy || cmd.Diff,
 		ForceDeploy:             cmd.ForceDeploy,
 		Verbose:                 cmd.VerboseDependencies,
 
@@ -200,6 +228,7 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 			ForceRebuild:              cmd.ForceBuild,
 			Sequential:                cmd.BuildSequential,
 			MaxConcurrentBuilds:       cmd.MaxConcurrentBuilds,
+			Report:                    buildReport,
 		},
 	})
 	if err != nil {
@@ -207,9 +236,11 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 	}
 
 	// create pull secrets if necessary
-	err = f.NewPullSecretClient(configInterface, dependencies, client, dockerClient, cmd.log).CreatePullSecrets()
-	if err != nil {
-		cmd.log.Warn(err)
+	if !cmd.Diff {
+		err = f.NewPullSecretClient(configInterface, dependencies, client, dockerClient, cmd.log).CreatePullSecrets()
+		if err != nil {
+			cmd.log.Warn(err)
+		}
 	}
 
 	// only deploy if we don't want to deploy a dependency specificly
@@ -223,6 +254,7 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 				ForceRebuild:              cmd.ForceBuild,
 				Sequential:                cmd.BuildSequential,
 				MaxConcurrentBuilds:       cmd.MaxConcurrentBuilds,
+				Report:                    buildReport,
 			}, cmd.log)
 			if err != nil {
 				if strings.Index(err.Error(), "no space left on device") != -1 {
@@ -241,6 +273,14 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 			}
 		}
 
+		// write the build report
+		if buildReport != nil {
+			err = buildReport.Save(cmd.BuildReport)
+			if err != nil {
+				return errors.Wrap(err, "save build report")
+			}
+		}
+
 		// what deployments should be deployed
 		deployments := []string{}
 		if cmd.SkipDeploy == false {
@@ -251,16 +291,39 @@ func (cmd *DeployCmd) Run(f factory.Factory) error {
 				}
 			}
 
+			// only show what would change
+			if cmd.Diff {
+				hasChanges, err := f.NewDeployController(configInterface, dependencies, client).Diff(&deploy.Options{
+					BuiltImages: builtImages,
+					Deployments: deployments,
+				}, os.Stdout, cmd.log)
+				if err != nil {
+					return err
+				} else if hasChanges {
+					return &exit.ReturnCodeError{
+						ExitCode: diffChangesExitCode,
+					}
+				}
+
+				return nil
+			}
+
 			// deploy all defined deployments
 			err = f.NewDeployController(configInterface, dependencies, client).Deploy(&deploy.Options{
-				ForceDeploy: cmd.ForceDeploy,
-				BuiltImages: builtImages,
-				Deployments: deployments,
+				ForceDeploy:          cmd.ForceDeploy,
+				BuiltImages:          builtImages,
+				Deployments:          deployments,
+				MaxConcurrentDeploys: cmd.MaxConcurrentDeploys,
 			}, cmd.log)
 			if err != nil {
 				return err
 			}
 		}
+	} else if buildReport != nil {
+		err = buildReport.Save(cmd.BuildReport)
+		if err != nil {
+			return errors.Wrap(err, "save build report")
+		}
 	}
 
 	// update last used kube context & save generated yaml
@@ -290,6 +353,15 @@ func (cmd *DeployCmd) validateFlags() error {
 	if cmd.SkipBuild && cmd.ForceBuild {
 		return errors.New("flags --skip-build & --force-build cannot be used together")
 	}
+	if cmd.Diff && cmd.SkipDeploy {
+		return errors.New("flags --diff & --skip-deploy cannot be used together")
+	}
+	if cmd.Diff && cmd.ForceBuild {
+		return errors.New("flags --diff & --force-build cannot be used together")
+	}
+	if cmd.Diff && cmd.BuildReport != "" {
+		return errors.New("flags --diff & --build-report cannot be used together")
+	}
 
 	return nil
 }
@@ -59,9 +59,10 @@ type DevCmd struct {
 	BuildSequential     bool
 	MaxConcurrentBuilds int
 
-	ForceDeploy       bool
-	Deployments       string
-	ForceDependencies bool
+	ForceDeploy          bool
+	Deployments          string
+	MaxConcurrentDeploys int
+	ForceDependencies    bool
 
 	Sync            bool
 	ExitAfterDeploy bool
@@ -135,6 +136,7 @@ Open terminal instead of logs:
 
 	devCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to deploy every deployment")
 	devCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
+	devCmd.Flags().IntVar(&cmd.MaxConcurrentDeploys, "max-concurrent-deploys", 1, "The maximum number of deployments deployed in parallel, deployments wait for the deployments they depend on (1 deploys one after another)")
 
 	devCmd.Flags().BoolVarP(&cmd.SkipPipeline, "skip-pipeline", "x", false, "Skips build & deployment and only starts sync, portforwarding & terminal")
 	devCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
@@ -369,10 +371,11 @@ func (cmd *DevCmd) buildAndDeploy(f factory.Factory, configInterface config.Conf
 
 				// Deploy all
 				err = f.NewDeployController(configInterface, dependencies, client).Deploy(&deploy.Options{
-					IsDev:       true,
-					ForceDeploy: cmd.ForceDeploy,
-					BuiltImages: builtImages,
-					Deployments: deployments,
+					IsDev:                true,
+					ForceDeploy:          cmd.ForceDeploy,
+					BuiltImages:          builtImages,
+					Deployments:          deployments,
+					MaxConcurrentDeploys: cmd.MaxConcurrentDeploys,
 				}, cmd.log)
 				if err != nil {
 					return 0, errors.Errorf("error deploying: %v", err)
@@ -0,0 +1,28 @@
+package edit
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewEditCmd creates a new cobra command for the edit sub command
+func NewEditCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	editCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit configuration in an editor",
+		Long: `
+#######################################################
+#################### devspace edit ####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	editCmd.AddCommand(newSecretsCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(editCmd, plugins, "edit")
+	return editCmd
+}
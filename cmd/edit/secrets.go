@@ -0,0 +1,217 @@
+package edit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const secretsFileHeader = `# Edit the decrypted values below. Changed values are encrypted again when you save and close the editor.
+# Use 'devspace set secret' to add new secrets.
+`
+
+type secretsCmd struct {
+	*flags.GlobalFlags
+
+	Reencrypt bool
+}
+
+func newSecretsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &secretsCmd{GlobalFlags: globalFlags}
+
+	secretsCmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Edits the encrypted values of the devspace.yaml",
+		Long: `
+#######################################################
+############### devspace edit secrets #################
+#######################################################
+Decrypts all values of the devspace.yaml that are marked
+with !encrypted and opens them in the editor defined by
+$VISUAL or $EDITOR. Changed values are encrypted again
+for your key and the keys in secrets.recipients.
+
+Examples:
+devspace edit secrets
+devspace edit secrets --reencrypt
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunEditSecrets(f, cobraCmd, args)
+		}}
+
+	secretsCmd.Flags().BoolVar(&cmd.Reencrypt, "reencrypt", false, "If true will encrypt all values again, e.g. after secrets.recipients was changed")
+	return secretsCmd
+}
+
+// RunEditSecrets executes the edit secrets command logic
+func (cmd *secretsCmd) RunEditSecrets(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	// Set config root
+	log := f.GetLog()
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	configPath := loader.ConfigPath(cmd.ConfigPath)
+	document, err := secrets.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	values := document.Values()
+	if len(values) == 0 {
+		return errors.Errorf("%s has no encrypted values, please use 'devspace set secret' to add one", configPath)
+	}
+
+	keyring, err := secrets.LoadKey()
+	if err != nil {
+		return err
+	}
+
+	decrypted := yaml.MapSlice{}
+	for _, value := range values {
+		plain, err := value.Decrypt(keyring)
+		if err != nil {
+			return err
+		}
+
+		decrypted = append(decrypted, yaml.MapItem{Key: value.Path, Value: plain})
+	}
+
+	edited, err := editInEditor(decrypted)
+	if err != nil {
+		return err
+	}
+
+	keys, err := document.EncryptionKeys(configPath, log)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for i, value := range values {
+		newValue, ok := edited[value.Path]
+		if !ok {
+			log.Warnf("Skip %s, because it was removed. Please remove it from %s instead", value.Path, configPath)
+			continue
+		}
+
+		delete(edited, value.Path)
+		if newValue == decrypted[i].Value && !cmd.Reencrypt {
+			continue
+		}
+
+		err = value.Encrypt(newValue, keys)
+		if err != nil {
+			return err
+		}
+
+		changed++
+	}
+	if len(edited) > 0 {
+		unknown := []string{}
+		for path := range edited {
+			unknown = append(unknown, path)
+		}
+
+		sort.Strings(unknown)
+		return errors.Errorf("%s are no encrypted values of %s, please use 'devspace set secret' to add new secrets", strings.Join(unknown, ", "), configPath)
+	}
+
+	if changed == 0 {
+		log.Info("No secrets were changed")
+		return nil
+	}
+
+	err = document.WriteFile(configPath)
+	if err != nil {
+		return errors.Errorf("Error saving config: %v", err)
+	}
+
+	log.Donef("Successfully encrypted %d secrets", changed)
+	return nil
+}
+
+// editInEditor opens the values in the editor of the user and returns the edited values. The temporary
+// file is only readable by the user and removed afterwards
+func editInEditor(values yaml.MapSlice) (map[string]string, error) {
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := ioutil.TempFile("", "devspace-secrets-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(append([]byte(secretsFileHeader), out...))
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	editor := strings.Fields(editorCommand())
+	editorCmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	err = editorCmd.Run()
+	if err != nil {
+		return nil, errors.Wrap(err, "run editor")
+	}
+
+	content, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	edited := map[string]interface{}{}
+	err = yaml.Unmarshal(content, &edited)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse edited secrets")
+	}
+
+	retValues := map[string]string{}
+	for path, value := range edited {
+		if value == nil {
+			value = ""
+		}
+
+		retValues[path] = fmt.Sprint(value)
+	}
+
+	return retValues, nil
+}
+
+func editorCommand() string {
+	if os.Getenv("VISUAL") != "" {
+		return os.Getenv("VISUAL")
+	} else if os.Getenv("EDITOR") != "" {
+		return os.Getenv("EDITOR")
+	} else if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+
+	return "vi"
+}
@@ -36,6 +36,7 @@ var gitFolderIgnoreRegex = regexp.MustCompile("/?\\.git/?")
 const gitIgnoreFile = ".gitignore"
 const dockerIgnoreFile = ".dockerignore"
 const devspaceFolderGitignore = "\n\n# Ignore DevSpace cache and log folder\n.devspace/\n"
+const localOverlayGitignore = "\n\n# Ignore personal DevSpace overrides\ndevspace.local.yaml\n"
 const configDockerignore = "\n\n# Ignore devspace.yaml file to prevent image rebuilding after config changes\ndevspace.yaml\n.devspace/\n"
 
 const (
@@ -302,6 +303,12 @@ func (cmd *InitCmd) Run(f factory.Factory) error {
 		cmd.log.Warn(err)
 	}
 
+	// Add devspace.local.yaml to .gitignore
+	err = appendToIgnoreFile(gitIgnoreFile, localOverlayGitignore)
+	if err != nil {
+		cmd.log.Warn(err)
+	}
+
 	// Save config
 	err = configLoader.Save(config)
 	if err != nil {
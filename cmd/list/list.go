@@ -22,7 +22,7 @@ func NewListCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plu
 
 	listCmd.AddCommand(newSyncCmd(f, globalFlags))
 	listCmd.AddCommand(newPortsCmd(f, globalFlags))
-	listCmd.AddCommand(newProfilesCmd(f))
+	listCmd.AddCommand(newProfilesCmd(f, globalFlags))
 	listCmd.AddCommand(newVarsCmd(f, globalFlags))
 	listCmd.AddCommand(newDeploymentsCmd(f, globalFlags))
 	listCmd.AddCommand(newContextsCmd(f))
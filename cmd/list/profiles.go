@@ -1,9 +1,11 @@
 package list
 
 import (
-	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"strconv"
 
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -12,10 +14,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type profilesCmd struct{}
+type profilesCmd struct {
+	*flags.GlobalFlags
+}
 
-func newProfilesCmd(f factory.Factory) *cobra.Command {
-	cmd := &profilesCmd{}
+func newProfilesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &profilesCmd{GlobalFlags: globalFlags}
 
 	profilesCmd := &cobra.Command{
 		Use:   "profiles",
@@ -24,7 +28,9 @@ func newProfilesCmd(f factory.Factory) *cobra.Command {
 #######################################################
 ############## devspace list profiles #################
 #######################################################
-Lists all DevSpace configuartions for this project
+Lists all DevSpace configuartions for this project and
+shows which profiles are activated automatically by their
+activation rules and why
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -56,10 +62,22 @@ func (cmd *profilesCmd) RunListProfiles(f factory.Factory, cobraCmd *cobra.Comma
 	profiles := config.Config().Profiles
 	generatedConfig := config.Generated()
 
+	// check which profiles would be activated automatically
+	activatedProfiles, err := loader.ProfileActivations(config, cmd.ToConfigOptions(logger), logger)
+	if err != nil {
+		return errors.Wrap(err, "evaluate profile activations")
+	}
+
+	activationReasons := map[string]string{}
+	for _, activated := range activatedProfiles {
+		activationReasons[activated.Name] = activated.Reason
+	}
+
 	// Specify the table column names
 	headerColumnNames := []string{
 		"Name",
 		"Active",
+		"Auto Activated",
 		"Description",
 	}
 
@@ -68,6 +86,7 @@ func (cmd *profilesCmd) RunListProfiles(f factory.Factory, cobraCmd *cobra.Comma
 		configRows = append(configRows, []string{
 			profile.Name,
 			strconv.FormatBool(profile.Name == generatedConfig.ActiveProfile),
+			autoActivated(profile.Name, activationReasons),
 			profile.Description,
 		})
 	}
@@ -75,3 +94,15 @@ func (cmd *profilesCmd) RunListProfiles(f factory.Factory, cobraCmd *cobra.Comma
 	log.PrintTable(logger, headerColumnNames, configRows)
 	return nil
 }
+
+// autoActivated returns why the profile is activated automatically or false if it is not
+func autoActivated(name string, activationReasons map[string]string) string {
+	reason, ok := activationReasons[name]
+	if !ok {
+		return "false"
+	} else if reason == "" {
+		return "true"
+	}
+
+	return "true (" + reason + ")"
+}
@@ -31,7 +31,7 @@ func newVarsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comman
 ############### devspace list vars ####################
 #######################################################
 Lists the defined vars in the devspace config with their
-values
+values and where the values were loaded from
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -68,6 +68,7 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 		headerColumnNames := []string{
 			"Variable",
 			"Value",
+			"Source",
 		}
 
 		varRow := make([][]string, 0, len(config.Variables()))
@@ -75,6 +76,7 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 			varRow = append(varRow, []string{
 				name,
 				fmt.Sprintf("%v", value),
+				config.VariableSources()[name],
 			})
 		}
 
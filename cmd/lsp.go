@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/lsp"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// LSPCmd is a struct that defines a command call for "lsp"
+type LSPCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewLSPCmd creates a new lsp command
+func NewLSPCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &LSPCmd{GlobalFlags: globalFlags}
+
+	lspCmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Starts a language server for devspace.yaml files",
+		Long: `
+#######################################################
+##################### devspace lsp ####################
+#######################################################
+Starts a language server for devspace.yaml files that
+communicates over stdin and stdout with the editor using
+the language server protocol. It provides completion,
+hover documentation, diagnostics, go to definition for
+images and variables and a preview of every profile.
+
+Errors of the language server are logged to
+.devspace/logs/lsp.log
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		},
+	}
+
+	return lspCmd
+}
+
+// Run executes the command logic
+func (cmd *LSPCmd) Run(f factory.Factory) error {
+	// stdout is used for the protocol, so nothing else may be written to it
+	f.GetLog().SetLevel(logrus.FatalLevel)
+	return lsp.NewServer(os.Stdin, os.Stdout, log.GetFileLogger("lsp")).Run()
+}
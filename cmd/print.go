@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
@@ -8,9 +9,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/devspace/config/schema"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	logger "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -26,6 +30,11 @@ type PrintCmd struct {
 
 	Out      io.Writer
 	SkipInfo bool
+	Schema   bool
+	Explain  bool
+
+	// ExplainPath restricts the explained values to the ones below the path
+	ExplainPath string
 }
 
 // NewPrintCmd creates a new devspace print command
@@ -36,28 +45,59 @@ func NewPrintCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comma
 	}
 
 	printCmd := &cobra.Command{
-		Use:   "print",
+		Use:   "print [path]",
 		Short: "Print displays the configuration",
 		Long: `
 #######################################################
 ################## devspace print #####################
 #######################################################
 Prints the configuration for the current or given 
-profile after all patching and variable substitution
+profile after all patching and variable substitution.
+The overlay files that were applied, e.g.
+devspace.local.yaml, are listed before the configuration.
+
+With --schema the json schema of the devspace.yaml is
+printed instead, which can be used by editors for
+validation and auto completion:
+
+devspace print --schema > devspace.schema.json
+
+With --explain every value of the configuration is
+printed together with where it came from, e.g. the
+line in devspace.yaml, the profile that changed it or
+the variable and its source. Optionally only values
+below a path are explained:
+
+devspace print --explain deployments[0].helm.values
 #######################################################`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			plugin.SetPluginCommand(cobraCmd, args)
+			if len(args) > 0 {
+				if !cmd.Explain {
+					return errors.New("a path can only be specified together with --explain")
+				}
+
+				cmd.ExplainPath = args[0]
+			}
+
 			return cmd.Run(f)
 		},
 	}
 
 	printCmd.Flags().BoolVar(&cmd.SkipInfo, "skip-info", false, "When enabled, only prints the configuration without additional information")
+	printCmd.Flags().BoolVar(&cmd.Schema, "schema", false, "Prints the json schema of the devspace.yaml instead of the configuration")
+	printCmd.Flags().BoolVar(&cmd.Explain, "explain", false, "Prints where every value of the configuration came from instead of the configuration")
 
 	return printCmd
 }
 
 // Run executes the command logic
 func (cmd *PrintCmd) Run(f factory.Factory) error {
+	if cmd.Schema {
+		return cmd.printSchema()
+	}
+
 	// Set config root
 	log := f.GetLog()
 	configOptions := cmd.ToConfigOptions(log)
@@ -75,6 +115,9 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 		log.Warnf("Unable to create new kubectl client: %v", err)
 	}
 	configOptions.KubeClient = client
+	if cmd.Explain {
+		configOptions.Provenance = provenance.NewTracker()
+	}
 
 	// load config
 	loadedConfig, err := configLoader.Load(configOptions, log)
@@ -96,6 +139,10 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 		return err
 	}
 
+	if cmd.Explain {
+		return cmd.printExplain(configOptions.Provenance)
+	}
+
 	bsConfig, err := yaml.Marshal(loadedConfig.Config())
 	if err != nil {
 		return err
@@ -120,6 +167,42 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 	return nil
 }
 
+func (cmd *PrintCmd) printSchema() error {
+	out, err := json.MarshalIndent(schema.NewConfigSchema(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Out.Write(append(out, '\n'))
+	return err
+}
+
+func (cmd *PrintCmd) printExplain(tracker *provenance.Tracker) error {
+	values := tracker.Explain(cmd.ExplainPath)
+	if len(values) == 0 {
+		return errors.Errorf("path %s not found in config", cmd.ExplainPath)
+	}
+
+	for _, value := range values {
+		out, err := yaml.Marshal(value.Value)
+		if err != nil {
+			return err
+		}
+
+		explanation := value.Path + ": " + strings.TrimSpace(string(out)) + "\n"
+		for _, origin := range value.Origin {
+			explanation += "  <- " + origin + "\n"
+		}
+
+		_, err = cmd.Out.Write([]byte(explanation))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func printExtraInfo(configPath string, config config.Config, log logger.Logger) error {
 	absPath, err := filepath.Abs(loader.ConfigPath(configPath))
 	if err != nil {
@@ -144,7 +227,14 @@ func printExtraInfo(configPath string, config config.Config, log logger.Logger)
 		log.Info("No vars found")
 	}
 
-	log.WriteString("\n-------------------\n\nLoaded path: " + absPath + "\n\n-------------------\n\n")
+	log.WriteString("\n-------------------\n\nLoaded path: " + absPath + "\n")
+	if len(config.Overlays()) > 0 {
+		log.WriteString("\nApplied overlays:\n")
+		for _, overlay := range config.Overlays() {
+			log.WriteString("  " + overlay + "\n")
+		}
+	}
+	log.WriteString("\n-------------------\n\n")
 
 	return nil
 }
@@ -38,6 +38,10 @@ type RenderCmd struct {
 
 	Deployments string
 
+	OutputDir     string
+	Split         bool
+	Kustomization bool
+
 	SkipDependencies bool
 	SkipDependency   []string
 	Dependency       []string
@@ -62,6 +66,13 @@ func NewRenderCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comm
 Builds all defined images and shows the yamls that would
 be deployed via helm and kubectl, but skips actual 
 deployment.
+
+With --output-dir the yamls are written to
+<dir>/<deployment>.yaml or with --split every object to
+<dir>/<deployment>/<kind>-<name>.yaml instead:
+
+devspace render --output-dir out --split
+devspace render --output-dir out --split --kustomization
 #######################################################`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			plugin.SetPluginCommand(cobraCmd, args)
@@ -78,6 +89,9 @@ deployment.
 	renderCmd.Flags().BoolVar(&cmd.SkipPushLocalKubernetes, "skip-push-local-kube", true, "Skips image pushing, if a local kubernetes environment is detected")
 	renderCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips image building")
 	renderCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
+	renderCmd.Flags().StringVar(&cmd.OutputDir, "output-dir", "", "Writes the yamls of every deployment into this directory instead of stdout")
+	renderCmd.Flags().BoolVar(&cmd.Split, "split", false, "Writes every object into its own file (requires --output-dir)")
+	renderCmd.Flags().BoolVar(&cmd.Kustomization, "kustomization", false, "Generates a kustomization.yaml for the written files (requires --output-dir)")
 
 	renderCmd.Flags().BoolVar(&cmd.SkipDependencies, "skip-dependencies", false, "Skips rendering the dependencies")
 	renderCmd.Flags().StringSliceVar(&cmd.SkipDependency, "skip-dependency", []string{}, "Skips rendering the following dependencies")
@@ -94,6 +108,17 @@ func (cmd *RenderCmd) Run(f factory.Factory) error {
 		log = logpkg.Discard
 	}
 
+	var manifestWriter *deploy.ManifestWriter
+	if cmd.OutputDir != "" {
+		var err error
+		manifestWriter, err = deploy.NewManifestWriter(cmd.OutputDir, cmd.Split, cmd.Kustomization)
+		if err != nil {
+			return err
+		}
+	} else if cmd.Split || cmd.Kustomization {
+		return errors.New("--split and --kustomization require --output-dir")
+	}
+
 	configOptions := cmd.ToConfigOptions(log)
 	configLoader := loader.NewConfigLoader(cmd.ConfigPath)
 	configExists, err := configLoader.SetDevSpaceRoot(log)
@@ -154,6 +179,7 @@ func (cmd *RenderCmd) Run(f factory.Factory) error {
 			SkipBuild:        cmd.SkipBuild,
 			Verbose:          cmd.VerboseDependencies,
 			Writer:           cmd.Writer,
+			ManifestWriter:   manifestWriter,
 
 			BuildOptions: build.Options{
 				SkipPush:                  cmd.SkipPush,
@@ -169,7 +195,7 @@ func (cmd *RenderCmd) Run(f factory.Factory) error {
 	}
 
 	if len(cmd.Dependency) > 0 {
-		return nil
+		return cmd.finishManifests(manifestWriter, log)
 	}
 
 	// Build images if necessary
@@ -214,12 +240,30 @@ func (cmd *RenderCmd) Run(f factory.Factory) error {
 
 	// Deploy all defined deployments
 	err = f.NewDeployController(configInterface, dependencies, client).Render(&deploy.Options{
-		BuiltImages: builtImages,
-		Deployments: deployments,
+		BuiltImages:    builtImages,
+		Deployments:    deployments,
+		ManifestWriter: manifestWriter,
 	}, cmd.Writer, log)
 	if err != nil {
 		return err
 	}
 
+	return cmd.finishManifests(manifestWriter, log)
+}
+
+// finishManifests removes stale files from the output directory. Files of deployments that were not rendered
+// are only removed if all deployments and dependencies were rendered
+func (cmd *RenderCmd) finishManifests(manifestWriter *deploy.ManifestWriter, log logpkg.Logger) error {
+	if manifestWriter == nil {
+		return nil
+	}
+
+	renderedAll := cmd.Deployments == "" && !cmd.SkipDependencies && len(cmd.SkipDependency) == 0 && len(cmd.Dependency) == 0
+	err := manifestWriter.Finish(renderedAll)
+	if err != nil {
+		return errors.Wrap(err, "write manifests")
+	}
+
+	log.Donef("Wrote manifests to %s", manifestWriter.Dir)
 	return nil
 }
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RollbackCmd holds the required data for the rollback cmd
+type RollbackCmd struct {
+	*flags.GlobalFlags
+
+	To   int
+	List bool
+
+	log log.Logger
+}
+
+// NewRollbackCmd creates a new rollback command
+func NewRollbackCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RollbackCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback [deployment]",
+		Short: "Rolls deployments back to a previous revision",
+		Long: `
+#######################################################
+################# devspace rollback ###################
+#######################################################
+Rolls helm deployments back to a previous release
+revision and re-applies the previously applied
+manifests of kubectl deployments. Without a deployment
+all deployments are rolled back to their previous
+revision:
+
+devspace rollback
+devspace rollback my-deployment
+devspace rollback my-deployment --to 3
+devspace rollback my-deployment --list
+#######################################################`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args)
+		},
+	}
+
+	rollbackCmd.Flags().IntVar(&cmd.To, "to", 0, "The revision to roll back to (0 rolls back to the previous revision)")
+	rollbackCmd.Flags().BoolVar(&cmd.List, "list", false, "Lists the available revisions instead of rolling back")
+
+	return rollbackCmd
+}
+
+// Run executes the rollback command logic
+func (cmd *RollbackCmd) Run(f factory.Factory, args []string) error {
+	// Set config root
+	cmd.log = f.GetLog()
+	configOptions := cmd.ToConfigOptions(cmd.log)
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(cmd.log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+	if cmd.To < 0 {
+		return errors.New("--to has to be a positive revision")
+	} else if cmd.To > 0 && len(args) == 0 {
+		return errors.New("please specify the deployment to roll back with --to")
+	}
+
+	log.StartFileLogging()
+
+	// Get config with adjusted cluster config
+	generatedConfig, err := configLoader.LoadGenerated(configOptions)
+	if err != nil {
+		return err
+	}
+	configOptions.GeneratedConfig = generatedConfig
+
+	// Use last context if specified
+	err = cmd.UseLastContext(generatedConfig, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+	configOptions.KubeClient = client
+
+	if !cmd.List {
+		err = client.PrintWarning(generatedConfig, cmd.NoWarn, true, cmd.log)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Execute plugin hook
+	err = plugin.ExecutePluginHook("rollback")
+	if err != nil {
+		return err
+	}
+
+	configInterface, err := configLoader.Load(configOptions, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	deployments := args
+	if len(deployments) == 0 {
+		deployConfigs, err := deploy.SortDeployments(configInterface.Config().Deployments)
+		if err != nil {
+			return err
+		}
+
+		for _, deployConfig := range deployConfigs {
+			deployments = append(deployments, deployConfig.Name)
+		}
+	}
+
+	controller := f.NewDeployController(configInterface, nil, client)
+	if cmd.List {
+		return cmd.listRevisions(controller, deployments)
+	}
+
+	for _, deployment := range deployments {
+		err = controller.Rollback(deployment, cmd.To, cmd.log)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = configLoader.SaveGenerated(generatedConfig)
+	if err != nil {
+		return errors.Errorf("error saving generated.yaml: %v", err)
+	}
+
+	return nil
+}
+
+func (cmd *RollbackCmd) listRevisions(controller deploy.Controller, deployments []string) error {
+	headerValues := []string{
+		"DEPLOYMENT",
+		"REVISION",
+		"UPDATED",
+		"STATUS",
+		"DESCRIPTION",
+	}
+
+	values := [][]string{}
+	for _, deployment := range deployments {
+		revisions, err := controller.History(deployment, cmd.log)
+		if err != nil {
+			return errors.Errorf("error listing revisions of %s: %v", deployment, err)
+		}
+
+		for _, revision := range revisions {
+			values = append(values, []string{
+				deployment,
+				strconv.Itoa(revision.Revision),
+				revision.Updated,
+				revision.Status,
+				revision.Description,
+			})
+		}
+	}
+
+	if len(values) == 0 {
+		cmd.log.Info("No revisions found")
+		return nil
+	}
+
+	log.PrintTable(cmd.log, headerValues, values)
+	return nil
+}
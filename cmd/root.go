@@ -5,6 +5,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/loft-sh/devspace/cmd/add"
 	"github.com/loft-sh/devspace/cmd/cleanup"
+	"github.com/loft-sh/devspace/cmd/edit"
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/cmd/list"
 	"github.com/loft-sh/devspace/cmd/remove"
@@ -169,6 +170,7 @@ func BuildRoot(f factory.Factory, excludePlugins bool) *cobra.Command {
 	// Add sub commands
 	rootCmd.AddCommand(add.NewAddCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(cleanup.NewCleanupCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(edit.NewEditCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(list.NewListCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(remove.NewRemoveCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(reset.NewResetCmd(f, globalFlags, plugins))
@@ -186,7 +188,10 @@ func BuildRoot(f factory.Factory, excludePlugins bool) *cobra.Command {
 	rootCmd.AddCommand(NewSyncCmd(f, globalFlags))
 	rootCmd.AddCommand(NewRenderCmd(f, globalFlags))
 	rootCmd.AddCommand(NewPurgeCmd(f, globalFlags))
-	rootCmd.AddCommand(NewUpgradeCmd())
+	rootCmd.AddCommand(NewRollbackCmd(f, globalFlags))
+	rootCmd.AddCommand(NewStatusCmd(f, globalFlags))
+	rootCmd.AddCommand(NewUpgradeCmd(f, globalFlags))
+	rootCmd.AddCommand(NewLSPCmd(f, globalFlags))
 	rootCmd.AddCommand(NewDeployCmd(f, globalFlags))
 	rootCmd.AddCommand(NewEnterCmd(f, globalFlags))
 	rootCmd.AddCommand(NewAnalyzeCmd(f, globalFlags))
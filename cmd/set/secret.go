@@ -0,0 +1,103 @@
+package set
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/loft-sh/devspace/pkg/util/survey"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type secretCmd struct {
+	*flags.GlobalFlags
+}
+
+func newSecretCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &secretCmd{GlobalFlags: globalFlags}
+
+	secretCmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Sets an encrypted variable in the devspace.yaml",
+		Long: `
+#######################################################
+################ devspace set secret ##################
+#######################################################
+Encrypts the value and saves it as variable in the
+vars section of the devspace.yaml. The value is encrypted
+for your pgp key and the keys in secrets.recipients.
+If you have no key yet, a new key is generated.
+If the value is omitted, you will be asked for it.
+
+Examples:
+devspace set secret DB_PASSWORD
+devspace set secret DB_PASSWORD mypassword
+#######################################################
+	`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunSetSecret(f, cobraCmd, args)
+		}}
+
+	return secretCmd
+}
+
+// RunSetSecret executes the set secret command logic
+func (cmd *secretCmd) RunSetSecret(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	// Set config root
+	log := f.GetLog()
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	name := args[0]
+	if variable.IsPredefinedVariable(name) {
+		return errors.Errorf("cannot set predefined variable %s", name)
+	}
+
+	value := ""
+	if len(args) == 2 {
+		value = args[1]
+	} else {
+		value, err = log.Question(&survey.QuestionOptions{
+			Question:   "Please enter the value of " + name,
+			IsPassword: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	configPath := loader.ConfigPath(cmd.ConfigPath)
+	document, err := secrets.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	keys, err := document.EncryptionKeys(configPath, log)
+	if err != nil {
+		return err
+	}
+
+	err = document.SetVariable(name, value, keys)
+	if err != nil {
+		return err
+	}
+
+	err = document.WriteFile(configPath)
+	if err != nil {
+		return errors.Errorf("Error saving config: %v", err)
+	}
+
+	log.Donef("Successfully saved encrypted variable %s, use it with ${%s}", name, name)
+	return nil
+}
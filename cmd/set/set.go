@@ -21,6 +21,7 @@ func NewSetCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plug
 	}
 
 	setCmd.AddCommand(newVarCmd(f, globalFlags))
+	setCmd.AddCommand(newSecretCmd(f, globalFlags))
 
 	// Add plugin commands
 	plugin.AddPluginCommands(setCmd, plugins, "set")
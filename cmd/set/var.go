@@ -95,7 +95,7 @@ func (cmd *varCmd) RunSetVar(f factory.Factory, cobraCmd *cobra.Command, args []
 		// try to find it in definitions
 		for _, def := range variableParser.Definitions {
 			if def.Name == splitted[0] {
-				if def.Command != "" || len(def.Commands) > 0 || def.Source == latest.VariableSourceCommand || def.Source == latest.VariableSourceEnv || def.Source == latest.VariableSourceNone {
+				if def.Command != "" || len(def.Commands) > 0 || def.Source == latest.VariableSourceCommand || def.Source == latest.VariableSourceEnv || def.Source == latest.VariableSourceNone || def.Source == latest.VariableSourceFile || def.Source == latest.VariableSourceSecret || def.Source == latest.VariableSourceConfigMap {
 					return errors.Errorf("cannot set variable %s, because variable is not loaded from cache. Please change variable type to cache it", def.Name)
 				}
 			}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// StatusCmd holds the required data for the status cmd
+type StatusCmd struct {
+	*flags.GlobalFlags
+
+	Deployments string
+	Output      string
+
+	log log.Logger
+}
+
+// NewStatusCmd creates a new status command
+func NewStatusCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &StatusCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows drift and health of the deployments",
+		Long: `
+#######################################################
+################## devspace status ####################
+#######################################################
+Compares the objects in the cluster with the objects
+DevSpace would deploy with the last built images and
+shows the health of the deployed workloads:
+
+devspace status
+devspace status --deployments my-deployment
+devspace status -o json
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		},
+	}
+
+	statusCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only show the status of specific deployments (You can specify multiple deployments comma-separated")
+	statusCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the command. Can be either empty, table or json")
+
+	return statusCmd
+}
+
+// Run executes the status command logic
+func (cmd *StatusCmd) Run(f factory.Factory) error {
+	// Set config root
+	cmd.log = f.GetLog()
+	if cmd.Output != "" && cmd.Output != "table" && cmd.Output != "json" {
+		return errors.Errorf("unsupported value for flag --output: %s", cmd.Output)
+	}
+
+	configOptions := cmd.ToConfigOptions(cmd.log)
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(cmd.log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// Get config with adjusted cluster config
+	generatedConfig, err := configLoader.LoadGenerated(configOptions)
+	if err != nil {
+		return err
+	}
+	configOptions.GeneratedConfig = generatedConfig
+
+	// Use last context if specified
+	err = cmd.UseLastContext(generatedConfig, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+	configOptions.KubeClient = client
+
+	// Execute plugin hook
+	err = plugin.ExecutePluginHook("status")
+	if err != nil {
+		return err
+	}
+
+	configInterface, err := configLoader.Load(configOptions, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	deployments := []string{}
+	if cmd.Deployments != "" {
+		deployments = strings.Split(cmd.Deployments, ",")
+		for index := range deployments {
+			deployments[index] = strings.TrimSpace(deployments[index])
+		}
+	}
+
+	cmd.log.StartWait("Checking deployments")
+	statuses, err := f.NewDeployController(configInterface, nil, client).Status(&deploy.Options{Deployments: deployments}, cmd.log)
+	cmd.log.StopWait()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+		return nil
+	}
+
+	cmd.printTable(statuses)
+	return nil
+}
+
+func (cmd *StatusCmd) printTable(statuses []*deploy.DeploymentStatus) {
+	if len(statuses) == 0 {
+		cmd.log.Info("No deployments found")
+		return
+	}
+
+	values := [][]string{}
+	drift := [][]string{}
+	for _, status := range statuses {
+		ready := 0
+		for _, workload := range status.Workloads {
+			if workload.Ready {
+				ready++
+			}
+		}
+
+		values = append(values, []string{
+			status.Name,
+			status.Type,
+			status.Status,
+			strconv.Itoa(len(status.Drift)),
+			fmt.Sprintf("%d/%d", ready, len(status.Workloads)),
+		})
+
+		if status.Error != "" {
+			drift = append(drift, []string{status.Name, "", "error", status.Error})
+		}
+		for _, objectDrift := range status.Drift {
+			drift = append(drift, []string{status.Name, objectDrift.Object, objectDrift.Reason, objectDrift.Message})
+		}
+		for _, workload := range status.Workloads {
+			if !workload.Ready {
+				drift = append(drift, []string{status.Name, workload.Name, "unhealthy", workload.Message})
+			}
+		}
+	}
+
+	log.PrintTable(cmd.log, []string{"NAME", "TYPE", "STATUS", "DRIFT", "READY"}, values)
+	if len(drift) > 0 {
+		log.PrintTable(cmd.log, []string{"DEPLOYMENT", "OBJECT", "PROBLEM", "MESSAGE"}, drift)
+	}
+}
@@ -1,8 +1,19 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -14,7 +25,7 @@ type UpgradeCmd struct {
 }
 
 // NewUpgradeCmd creates a new upgrade command
-func NewUpgradeCmd() *cobra.Command {
+func NewUpgradeCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
 	cmd := &UpgradeCmd{}
 
 	upgradeCmd := &cobra.Command{
@@ -25,6 +36,9 @@ func NewUpgradeCmd() *cobra.Command {
 ################## devspace upgrade ###################
 #######################################################
 Upgrades the DevSpace CLI to the newest version
+
+Use 'devspace upgrade config' to upgrade the
+devspace.yaml to the newest config version
 #######################################################`,
 		Args: cobra.NoArgs,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
@@ -34,6 +48,7 @@ Upgrades the DevSpace CLI to the newest version
 	}
 
 	upgradeCmd.Flags().StringVar(&cmd.Version, "version", "", "The version to update devspace to. Defaults to the latest stable version available")
+	upgradeCmd.AddCommand(newUpgradeConfigCmd(f, globalFlags))
 	return upgradeCmd
 }
 
@@ -53,3 +68,82 @@ func (cmd *UpgradeCmd) Run() error {
 
 	return nil
 }
+
+// UpgradeConfigCmd is a struct that defines a command call for "upgrade config"
+type UpgradeConfigCmd struct {
+	*flags.GlobalFlags
+
+	Out    io.Writer
+	DryRun bool
+}
+
+func newUpgradeConfigCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &UpgradeConfigCmd{
+		GlobalFlags: globalFlags,
+		Out:         os.Stdout,
+	}
+
+	upgradeConfigCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Upgrades the devspace.yaml to the newest config version",
+		Long: `
+#######################################################
+############### devspace upgrade config ###############
+#######################################################
+Upgrades the devspace.yaml to the newest config version.
+Comments and the order of keys are kept where possible
+and paths of profile patches that moved are rewritten.
+
+Examples:
+devspace upgrade config
+devspace upgrade config --dry-run
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		},
+	}
+
+	upgradeConfigCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "If true will only print the changes instead of writing the devspace.yaml")
+	return upgradeConfigCmd
+}
+
+// Run executes the command logic
+func (cmd *UpgradeConfigCmd) Run(f factory.Factory) error {
+	log := f.GetLog()
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	configPath := loader.ConfigPath(cmd.ConfigPath)
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	upgraded, err := loader.UpgradeConfig(content, log)
+	if err != nil {
+		return errors.Wrap(err, "upgrade config")
+	} else if string(upgraded) == string(content) {
+		log.Infof("%s already uses the newest config version %s", configPath, latest.Version)
+		return nil
+	}
+
+	if cmd.DryRun {
+		fmt.Fprint(cmd.Out, diff.Unified(configPath, configPath, string(content), string(upgraded), diff.DefaultContext))
+		return nil
+	}
+
+	err = ioutil.WriteFile(configPath, upgraded, 0666)
+	if err != nil {
+		return errors.Errorf("Error saving config: %v", err)
+	}
+
+	log.Donef("Successfully upgraded %s to version %s", configPath, latest.Version)
+	return nil
+}
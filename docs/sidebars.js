@@ -61,6 +61,7 @@ module.exports = {
                 'configuration/images/kaniko',
                 'configuration/images/custom',
                 'configuration/images/disabled',
+                'configuration/images/lint',
               ],
             },
           ],
@@ -112,7 +113,10 @@ module.exports = {
             'configuration/variables/source-env',
             'configuration/variables/source-input',
             'configuration/variables/source-command',
+            'configuration/variables/source-file',
+            'configuration/variables/source-kubernetes',
             'configuration/variables/source-none',
+            'configuration/variables/secrets',
           ],
         },
         {
@@ -126,13 +130,16 @@ module.exports = {
             'configuration/profiles/patches',
             'configuration/profiles/parents',
             'configuration/profiles/activation',
+            'configuration/profiles/overlays',
           ],
         },
+        'configuration/imports/basics',
         'configuration/pullSecrets/basics',
         'configuration/commands/basics',
         'configuration/hooks/basics',
         'configuration/require/basics',
         'configuration/expressions',
+        'configuration/conditionals',
         'configuration/env-file',
       ],
     },
@@ -211,6 +218,13 @@ module.exports = {
         "commands/devspace_cleanup_images",
         "commands/devspace_deploy",
         "commands/devspace_dev",
+        {
+          type: "category",
+          label: "devspace edit",
+          items: [
+            "commands/devspace_edit_secrets"
+          ]
+        },
         "commands/devspace_enter",
         "commands/devspace_init",
         {
@@ -229,6 +243,7 @@ module.exports = {
           ]
         },
         "commands/devspace_logs",
+        "commands/devspace_lsp",
         "commands/devspace_open",
         "commands/devspace_print",
         "commands/devspace_purge",
@@ -249,14 +264,17 @@ module.exports = {
             "commands/devspace_reset_vars"
           ]
         },
+        "commands/devspace_rollback",
         "commands/devspace_run",
         {
           type: "category",
           label: "devspace set",
           items: [
+            "commands/devspace_set_secret",
             "commands/devspace_set_var"
           ]
         },
+        "commands/devspace_status",
         "commands/devspace_sync",
         "commands/devspace_ui",
         {
@@ -268,6 +286,7 @@ module.exports = {
           ]
         },
         "commands/devspace_upgrade",
+        "commands/devspace_upgrade_config",
         {
           type: "category",
           label: "devspace use",
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const latestDir = "pkg/devspace/config/versions/latest"
+const outputFile = "pkg/devspace/config/schema/descriptions.go"
+
+// main parses the comments and typed string constants of the latest config types and writes them to
+// pkg/devspace/config/schema/descriptions.go, because they are not available through reflection
+func main() {
+	root, err := findModuleRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := generate(root)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(root, outputFile), out, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted content of the descriptions file for the latest config types in the given module root
+func generate(root string) ([]byte, error) {
+	fileSet := token.NewFileSet()
+	packages, err := parser.ParseDir(fileSet, filepath.Join(root, latestDir), func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := map[string]string{}
+	enums := map[string][]string{}
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+
+				switch genDecl.Tok {
+				case token.TYPE:
+					parseTypes(genDecl, descriptions)
+				case token.CONST:
+					parseEnums(genDecl, enums)
+				}
+			}
+		}
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString("// Code generated by hack/schema. DO NOT EDIT.\n\npackage schema\n\n")
+	out.WriteString("// descriptions holds the doc comments of the latest config types and fields\nvar descriptions = map[string]string{\n")
+	for _, key := range sortedKeys(descriptions) {
+		fmt.Fprintf(out, "%s: %s,\n", strconv.Quote(key), strconv.Quote(descriptions[key]))
+	}
+	out.WriteString("}\n\n// enums holds the allowed values of the latest config string types\nvar enums = map[string][]string{\n")
+	for _, key := range sortedEnumKeys(enums) {
+		values := []string{}
+		for _, value := range enums[key] {
+			values = append(values, strconv.Quote(value))
+		}
+
+		fmt.Fprintf(out, "%s: {%s},\n", strconv.Quote(key), strings.Join(values, ", "))
+	}
+	out.WriteString("}\n")
+
+	return format.Source(out.Bytes())
+}
+
+func parseTypes(genDecl *ast.GenDecl, descriptions map[string]string) {
+	for _, spec := range genDecl.Specs {
+		typeSpec := spec.(*ast.TypeSpec)
+		doc := typeSpec.Doc
+		if doc == nil && len(genDecl.Specs) == 1 {
+			doc = genDecl.Doc
+		}
+		if text := commentText(doc); text != "" {
+			descriptions[typeSpec.Name.Name] = text
+		}
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		for _, field := range structType.Fields.List {
+			text := commentText(field.Doc)
+			if text == "" {
+				text = commentText(field.Comment)
+			}
+			if text == "" {
+				continue
+			}
+
+			for _, name := range field.Names {
+				descriptions[typeSpec.Name.Name+"."+name.Name] = text
+			}
+		}
+	}
+}
+
+func parseEnums(genDecl *ast.GenDecl, enums map[string][]string) {
+	for _, spec := range genDecl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		typeIdent, ok := valueSpec.Type.(*ast.Ident)
+		if !ok || typeIdent.Name == "string" {
+			continue
+		}
+
+		for _, value := range valueSpec.Values {
+			literal, ok := value.(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				continue
+			}
+
+			unquoted, err := strconv.Unquote(literal.Value)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			enums[typeIdent.Name] = append(enums[typeIdent.Name], unquoted)
+		}
+	}
+}
+
+// commentText joins the lines of a comment, but keeps lines that start a list on their own line
+func commentText(comment *ast.CommentGroup) string {
+	if comment == nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSpace(comment.Text()), "\n")
+	text := ""
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if i > 0 {
+			if strings.HasPrefix(line, "- ") {
+				text += "\n"
+			} else {
+				text += " "
+			}
+		}
+
+		text += line
+	}
+
+	return text
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedEnumKeys(m map[string][]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func findModuleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		_, err := os.Stat(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("couldn't find go.mod")
+		}
+
+		dir = parent
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// TestDescriptionsUpToDate fails if the config types changed without running go run ./hack/schema
+func TestDescriptionsUpToDate(t *testing.T) {
+	root, err := findModuleRoot()
+	assert.NilError(t, err)
+
+	generated, err := generate(root)
+	assert.NilError(t, err)
+
+	current, err := ioutil.ReadFile(filepath.Join(root, outputFile))
+	assert.NilError(t, err)
+	assert.Equal(t, string(current), string(generated), "%s is outdated, please run go run ./hack/schema", outputFile)
+}
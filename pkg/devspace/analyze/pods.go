@@ -82,17 +82,25 @@ func (a *analyzer) pods(namespace string, options Options) ([]string, error) {
 
 	// Analyzing pods
 	if pods.Items != nil {
-		for _, pod := range pods.Items {
-			problem := checkPod(a.client, &pod, options.IgnorePodRestarts)
-			if problem != nil {
-				problems = append(problems, printPodProblem(problem))
-			}
-		}
+		problems = PodProblems(a.client, pods.Items, options.IgnorePodRestarts)
 	}
 
 	return problems, nil
 }
 
+// PodProblems analyzes the given pods and returns a printed problem report for every pod that has problems
+func PodProblems(client kubectl.Client, pods []v1.Pod, ignoreContainerRestarts bool) []string {
+	problems := []string{}
+	for _, pod := range pods {
+		problem := checkPod(client, &pod, ignoreContainerRestarts)
+		if problem != nil {
+			problems = append(problems, printPodProblem(problem))
+		}
+	}
+
+	return problems
+}
+
 type podProblem struct {
 	Name   string
 	Status string
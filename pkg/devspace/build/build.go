@@ -7,6 +7,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -20,6 +21,11 @@ type imageNameAndTag struct {
 	imageConfigName string
 	imageName       string
 	imageTag        string
+
+	imageTags []string
+	builder   string
+	digest    string
+	duration  time.Duration
 }
 
 // Options describe how images should be build
@@ -29,6 +35,13 @@ type Options struct {
 	ForceRebuild              bool
 	Sequential                bool
 	MaxConcurrentBuilds       int
+
+	// Lint checks the dockerfiles of all images before building, even if
+	// images.*.build.lint is not enabled
+	Lint bool
+
+	// Report is filled with the results of all image builds if non nil
+	Report *Report
 }
 
 // Controller is the main building interface
@@ -83,8 +96,14 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 		}
 	}
 
+	// Check the dockerfiles before we start any build
+	err := c.lintImages(options.Lint, log)
+	if err != nil {
+		return nil, err
+	}
+
 	// Execute before images build hook
-	err := c.hookExecuter.Execute(hook.Before, hook.StageImages, hook.All, hook.Context{Client: c.client}, log)
+	err = c.hookExecuter.Execute(hook.Before, hook.StageImages, hook.All, hook.Context{Client: c.client}, log)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +150,13 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 
 		if options.ForceRebuild == false && needRebuild == false {
 			log.Infof("Skip building image '%s'", imageConfigName)
+
+			imageCache := c.config.Generated().GetActive().GetImageCache(imageConfigName)
+			cachedTags := []string{}
+			if imageCache.Tag != "" {
+				cachedTags = append(cachedTags, imageCache.Tag)
+			}
+			options.Report.AddSkipped(imageConfigName, imageName, cachedTags, builderName(builder))
 			continue
 		}
 
@@ -154,6 +180,7 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 		// Sequential or parallel build?
 		if options.Sequential {
 			// Build the image
+			start := time.Now()
 			err = builder.Build(log)
 			if err != nil {
 				pluginErr := plugin.ExecutePluginHookWithContext("build.errorBuild", map[string]interface{}{
@@ -181,6 +208,7 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 
 			// Track built images
 			builtImages[imageName] = imageTags[0]
+			options.Report.AddBuilt(imageConfigName, imageName, imageTags, builderName(builder), builderDigest(builder), time.Since(start))
 
 			// Execute before images build hook
 			pluginErr := plugin.ExecutePluginHookWithContext("build.afterBuild", map[string]interface{}{
@@ -199,7 +227,7 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 		} else {
 			// wait until we are below the MaxConcurrency
 			if options.MaxConcurrentBuilds > 0 && imagesToBuild >= options.MaxConcurrentBuilds {
-				err = c.waitForBuild(errChan, cacheChan, builtImages, log)
+				err = c.waitForBuild(errChan, cacheChan, builtImages, options.Report, log)
 				if err != nil {
 					return nil, err
 				}
@@ -223,6 +251,7 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 				}()
 
 				// Build the image
+				start := time.Now()
 				err := builder.Build(streamLog)
 				_ = writer.Close()
 				if err != nil {
@@ -261,6 +290,11 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 					imageConfigName: imageConfigName,
 					imageName:       imageName,
 					imageTag:        imageTags[0],
+
+					imageTags: imageTags,
+					builder:   builderName(builder),
+					digest:    builderDigest(builder),
+					duration:  time.Since(start),
 				}
 			}()
 		}
@@ -269,7 +303,7 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 	// wait for the builds to finish
 	if options.Sequential == false {
 		for imagesToBuild > 0 {
-			err = c.waitForBuild(errChan, cacheChan, builtImages, log)
+			err = c.waitForBuild(errChan, cacheChan, builtImages, options.Report, log)
 			if err != nil {
 				return nil, err
 			}
@@ -287,7 +321,7 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 	return builtImages, nil
 }
 
-func (c *controller) waitForBuild(errChan <-chan error, cacheChan <-chan imageNameAndTag, builtImages map[string]string, log logpkg.Logger) error {
+func (c *controller) waitForBuild(errChan <-chan error, cacheChan <-chan imageNameAndTag, builtImages map[string]string, report *Report, log logpkg.Logger) error {
 	select {
 	case err := <-errChan:
 		c.hookExecuter.OnError(hook.StageImages, []string{hook.All}, hook.Context{Client: c.client, Error: err}, log)
@@ -306,6 +340,7 @@ func (c *controller) waitForBuild(errChan <-chan error, cacheChan <-chan imageNa
 
 		// Track built images
 		builtImages[done.imageName] = done.imageTag
+		report.AddBuilt(done.imageConfigName, done.imageName, done.imageTags, done.builder, done.digest, done.duration)
 	}
 
 	return nil
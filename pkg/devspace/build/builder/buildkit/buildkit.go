@@ -34,7 +34,8 @@ const EngineName = "buildkit"
 type Builder struct {
 	helper *helper.BuildHelper
 
-	authConfig *types.AuthConfig
+	authConfig   *types.AuthConfig
+	digestWriter *helper.DigestWriter
 
 	skipPush                  bool
 	skipPushOnLocalKubernetes bool
@@ -59,6 +60,15 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool)
 	return b.helper.ShouldRebuild(cache, forceRebuild)
 }
 
+// Digest returns the digest of the pushed image
+func (b *Builder) Digest() string {
+	if b.digestWriter == nil {
+		return ""
+	}
+
+	return b.digestWriter.Digest()
+}
+
 // BuildImage builds a dockerimage with the docker cli
 // contextPath is the absolute path to the context path
 // dockerfilePath is the absolute path to the dockerfile WITHIN the contextPath
@@ -85,17 +95,26 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 		return err
 	}
 
+	// We skip pushing when it is the minikube client
+	if b.skipPushOnLocalKubernetes && b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+		b.skipPush = true
+	}
+
+	// keep the build context in the builder pod and only upload changed files if enabled,
+	// otherwise or if that is not possible the complete build context is streamed to BuildKit
+	if buildKitConfig.InCluster != nil && buildKitConfig.InCluster.IncrementalContext {
+		built, err := b.buildIncremental(builder, contextPath, dockerfilePath, entrypoint, cmd, options, log)
+		if built || err != nil {
+			return err
+		}
+	}
+
 	// create the context stream
 	body, writer, _, buildOptions, err := docker.CreateContextStream(b.helper, contextPath, dockerfilePath, entrypoint, cmd, options, log)
 	if err != nil {
 		return err
 	}
 
-	// We skip pushing when it is the minikube client
-	if b.skipPushOnLocalKubernetes && b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
-		b.skipPush = true
-	}
-
 	// Should we use the minikube docker daemon?
 	useMinikubeDocker := false
 	if b.helper.KubeClient != nil && b.helper.KubeClient.CurrentContext() == "minikube" && (buildKitConfig.PreferMinikube == nil || *buildKitConfig.PreferMinikube == true) {
@@ -107,7 +126,8 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 		buildKitConfig.SkipPush = b.skipPush
 	}
 
-	return buildWithCLI(body, writer, b.helper.KubeClient, builder, buildKitConfig, *buildOptions, useMinikubeDocker, log)
+	b.digestWriter = &helper.DigestWriter{Out: writer}
+	return buildWithCLI(body, b.digestWriter, b.helper.KubeClient, builder, buildKitConfig, *buildOptions, useMinikubeDocker, log)
 }
 
 func buildWithCLI(context io.Reader, writer io.Writer, kubeClient kubectl.Client, builder string, imageConf *latest.BuildKitConfig, options types.ImageBuildOptions, useMinikubeDocker bool, log logpkg.Logger) error {
@@ -219,15 +239,7 @@ func ensureBuilder(kubeClient kubectl.Client, imageConf *latest.BuildKitConfig,
 		return "", fmt.Errorf("cannot build in cluster wth build kit without a correct kubernetes context")
 	}
 
-	namespace := kubeClient.Namespace()
-	if imageConf.InCluster.Namespace != "" {
-		namespace = imageConf.InCluster.Namespace
-	}
-
-	name := "devspace-" + namespace
-	if imageConf.InCluster.Name != "" {
-		name = imageConf.InCluster.Name
-	}
+	name, namespace := builderNameAndNamespace(kubeClient, imageConf.InCluster)
 
 	// check if we should skip
 	if imageConf.InCluster.NoCreate {
@@ -342,6 +354,21 @@ func ensureBuilder(kubeClient kubectl.Client, imageConf *latest.BuildKitConfig,
 	return name, nil
 }
 
+// builderNameAndNamespace returns the name of the in cluster builder and the namespace of its deployment
+func builderNameAndNamespace(kubeClient kubectl.Client, inCluster *latest.BuildKitInClusterConfig) (string, string) {
+	namespace := kubeClient.Namespace()
+	if inCluster.Namespace != "" {
+		namespace = inCluster.Namespace
+	}
+
+	name := "devspace-" + namespace
+	if inCluster.Name != "" {
+		name = inCluster.Name
+	}
+
+	return name, namespace
+}
+
 // getConfigStorePath will look for correct configuration store path;
 // if `$BUILDX_CONFIG` is set - use it, otherwise use parent directory
 // of Docker config file (i.e. `${DOCKER_CONFIG}/buildx`)
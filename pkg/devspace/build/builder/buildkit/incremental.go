@@ -0,0 +1,269 @@
+package buildkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	cliconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/registry"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/randutil"
+	dockerterm "github.com/moby/term"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/kubectl/pkg/util/interrupt"
+)
+
+// The directory within the BuildKit builder pod where the persistent build contexts are stored
+const builderContexts = "/tmp/devspace/contexts"
+
+// The directory within the BuildKit builder pod where the registry credentials of a build are stored
+const builderDockerConfigs = "/tmp/devspace/docker"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// buildIncremental keeps the build context in the BuildKit builder pod, uploads only the changed files with the
+// sync helper and builds the image with buildctl within the pod. If the build context cannot be kept in the
+// builder pod, false is returned and the image has to be built with the complete build context instead
+func (b *Builder) buildIncremental(builder, contextPath, dockerfilePath string, entrypoint, cmd []string, options *types.ImageBuildOptions, log logpkg.Logger) (bool, error) {
+	defer log.StopWait()
+
+	buildKitConfig := b.helper.ImageConf.Build.BuildKit
+	if b.skipPush {
+		log.Infof("Streaming complete build context for image %s, because an incremental build context requires pushing the image", b.helper.ImageConfigName)
+		return false, nil
+	} else if len(buildKitConfig.Args) > 0 {
+		log.Infof("Streaming complete build context for image %s, because buildKit.args cannot be used with an incremental build context", b.helper.ImageConfigName)
+		return false, nil
+	}
+
+	_, namespace := builderNameAndNamespace(b.helper.KubeClient, buildKitConfig.InCluster)
+	log.StartWait("Waiting for BuildKit builder " + builder)
+	buildPod, err := b.getBuilderPod(builder, namespace)
+	if err != nil {
+		log.Warnf("Error finding BuildKit builder pod, falling back to streaming the complete build context: %v", err)
+		return false, nil
+	}
+
+	// Check if we should overwrite entrypoint
+	if len(entrypoint) > 0 || len(cmd) > 0 || b.helper.ImageConf.InjectRestartHelper || len(b.helper.ImageConf.AppendDockerfileInstructions) > 0 {
+		dockerfilePath, err = helper.RewriteDockerfile(dockerfilePath, entrypoint, cmd, b.helper.ImageConf.AppendDockerfileInstructions, options.Target, b.helper.ImageConf.InjectRestartHelper, log)
+		if err != nil {
+			return false, err
+		}
+
+		defer os.RemoveAll(filepath.Dir(dockerfilePath))
+	}
+
+	// Update the persistent build context with only the changed files
+	container := buildPod.Spec.Containers[0].Name
+	remoteContextPath := builderContexts + "/" + b.helper.ImageConfigName
+	err = b.helper.SyncContext(buildPod, container, remoteContextPath, contextPath, dockerfilePath, log)
+	if err != nil {
+		log.Warnf("Error uploading build context incrementally, falling back to streaming the complete build context: %v", err)
+		return false, nil
+	}
+
+	log.Done("Uploaded files to container")
+	log.StopWait()
+
+	// Upload the registry credentials, so that buildctl is able to push the image
+	dockerConfig, err := b.getDockerConfig()
+	if err != nil {
+		return true, err
+	}
+
+	dockerConfigPath := ""
+	if dockerConfig != nil {
+		dockerConfigPath = builderDockerConfigs + "/" + strings.ToLower(randutil.GenerateRandomString(12))
+		_, stderr, err := b.helper.KubeClient.ExecBuffered(buildPod, container, []string{"sh", "-c", "mkdir -p " + dockerConfigPath + " && cat > " + dockerConfigPath + "/config.json"}, bytes.NewReader(dockerConfig))
+		if err != nil {
+			return true, errors.Errorf("error uploading registry credentials: %s: %v", string(stderr), err)
+		}
+	}
+
+	// Remove the registry credentials when we are done or get interrupted during build
+	removeDockerConfig := func() {
+		if dockerConfigPath == "" {
+			return
+		}
+
+		_, _, err := b.helper.KubeClient.ExecBuffered(buildPod, container, []string{"rm", "-rf", dockerConfigPath}, nil)
+		if err != nil {
+			log.Warnf("Error removing registry credentials from BuildKit builder pod %s/%s: %v", buildPod.Namespace, buildPod.Name, err)
+		}
+	}
+
+	return true, interrupt.New(nil, removeDockerConfig).Run(func() error {
+		defer removeDockerConfig()
+
+		// Determine output writer
+		var writer io.Writer
+		if log == logpkg.GetInstance() {
+			writer = stdout
+		} else {
+			writer = log
+		}
+
+		command := b.getBuildctlCommand(remoteContextPath, dockerfilePath, dockerConfigPath, options)
+		log.Infof("Execute BuildKit command in pod %s/%s with: %s", buildPod.Namespace, buildPod.Name, strings.Join(command, " "))
+
+		b.digestWriter = &helper.DigestWriter{Out: writer}
+		err = b.helper.KubeClient.ExecStream(&kubectl.ExecStreamOptions{
+			Pod:       buildPod,
+			Container: container,
+			Command:   command,
+			Stdout:    b.digestWriter,
+			Stderr:    b.digestWriter,
+		})
+		if err != nil {
+			if exitErr, ok := err.(utilexec.CodeExitError); ok {
+				return errors.Errorf("error building image (Exit Code %d)", exitErr.Code)
+			}
+
+			return errors.Wrap(err, "execute buildctl")
+		}
+
+		return nil
+	})
+}
+
+// getBuildctlCommand returns the buildctl command that builds and pushes the image from the context directory of the builder pod
+func (b *Builder) getBuildctlCommand(remoteContextPath, dockerfilePath, dockerConfigPath string, options *types.ImageBuildOptions) []string {
+	command := []string{}
+	if dockerConfigPath != "" {
+		command = append(command, "env", "DOCKER_CONFIG="+dockerConfigPath)
+	}
+
+	command = append(command,
+		"buildctl", "build",
+		"--progress=plain",
+		"--frontend=dockerfile.v0",
+		"--local", "context="+remoteContextPath,
+		"--local", "dockerfile="+remoteContextPath,
+		"--opt", "filename="+filepath.Base(dockerfilePath),
+	)
+	if options.Target != "" {
+		command = append(command, "--opt", "target="+options.Target)
+	}
+	if options.NetworkMode != "" {
+		command = append(command, "--opt", "force-network-mode="+options.NetworkMode)
+	}
+	for k, v := range options.BuildArgs {
+		if v == nil {
+			continue
+		}
+
+		command = append(command, "--opt", "build-arg:"+k+"="+*v)
+	}
+
+	// the output is parsed as csv, so the comma separated image names have to be quoted
+	names := []string{}
+	for _, tag := range b.helper.ImageTags {
+		names = append(names, b.helper.ImageName+":"+tag)
+	}
+
+	return append(command, "--output", `type=image,"name=`+strings.Join(names, ",")+`",push=true`)
+}
+
+// getDockerConfig returns a docker config that contains the local registry credentials for the image or nil
+// if there are no credentials
+func (b *Builder) getDockerConfig() ([]byte, error) {
+	registryURL, err := pullsecrets.GetRegistryFromImageName(b.helper.ImageName)
+	if err != nil {
+		return nil, err
+	} else if registryURL == "" {
+		registryURL = registry.IndexServer
+	}
+
+	authConfig, err := cliconfig.LoadDefaultConfigFile(ioutil.Discard).GetAuthConfig(registryURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "get registry credentials")
+	} else if authConfig.Username == "" && authConfig.Password == "" && authConfig.IdentityToken == "" {
+		return nil, nil
+	}
+
+	authConfig.ServerAddress = registryURL
+	dockerConfig := configfile.New("")
+	dockerConfig.AuthConfigs[registryURL] = authConfig
+
+	out := &bytes.Buffer{}
+	err = dockerConfig.SaveToWriter(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// getBuilderPod bootstraps the builder and returns a running pod of the BuildKit deployment
+func (b *Builder) getBuilderPod(builder, namespace string) (*k8sv1.Pod, error) {
+	tempFile, err := tempKubeContextFromClient(b.helper.KubeClient)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempFile)
+
+	command := []string{"docker", "buildx"}
+	if len(b.helper.ImageConf.Build.BuildKit.Command) > 0 {
+		command = b.helper.ImageConf.Build.BuildKit.Command
+	}
+
+	args := []string{}
+	args = append(args, command[1:]...)
+	args = append(args, "inspect", "--bootstrap", "--builder", builder)
+
+	cmd := exec.Command(command[0], args...)
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+tempFile)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Errorf("error bootstrapping BuildKit builder: %s => %v", string(out), err)
+	}
+
+	// the kubernetes driver labels the pods of the deployment with the lowercase node name
+	pods, err := b.helper.KubeClient.KubeClient().CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "app=" + strings.ToLower(builderNodeName(builder)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list builder pods")
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == k8sv1.PodRunning {
+			return pod, nil
+		}
+	}
+
+	return nil, errors.Errorf("couldn't find a running pod of builder %s in namespace %s", builder, namespace)
+}
+
+// builderNodeName returns the name of the single node of the builder, which the kubernetes
+// driver uses as deployment name
+func builderNodeName(builder string) string {
+	out, err := ioutil.ReadFile(filepath.Join(getConfigStorePath(), "instances", builder))
+	if err == nil {
+		ng := &NodeGroup{}
+		if json.Unmarshal(out, ng) == nil && len(ng.Nodes) == 1 && ng.Nodes[0].Name != "" {
+			return ng.Nodes[0].Name
+		}
+	}
+
+	return builder + "0"
+}
@@ -0,0 +1,42 @@
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+)
+
+func TestGetBuildctlCommand(t *testing.T) {
+	imageConf := &latest.ImageConfig{
+		Image: "registry.test/app",
+		Build: &latest.BuildConfig{
+			BuildKit: &latest.BuildKitConfig{
+				InCluster: &latest.BuildKitInClusterConfig{IncrementalContext: true},
+			},
+		},
+	}
+	b := &Builder{helper: helper.NewBuildHelper(nil, nil, EngineName, "app", imageConf, []string{"abc", "latest"})}
+
+	command := b.getBuildctlCommand("/tmp/devspace/contexts/app", "/tmp/rewritten/Dockerfile.dev", "", &types.ImageBuildOptions{
+		Target:    "dev",
+		BuildArgs: map[string]*string{"VERSION": ptr.String("1.0"), "UNSET": nil},
+	})
+	assert.DeepEqual(t, command, []string{
+		"buildctl", "build",
+		"--progress=plain",
+		"--frontend=dockerfile.v0",
+		"--local", "context=/tmp/devspace/contexts/app",
+		"--local", "dockerfile=/tmp/devspace/contexts/app",
+		"--opt", "filename=Dockerfile.dev",
+		"--opt", "target=dev",
+		"--opt", "build-arg:VERSION=1.0",
+		"--output", `type=image,"name=registry.test/app:abc,registry.test/app:latest",push=true`,
+	})
+
+	command = b.getBuildctlCommand("/tmp/devspace/contexts/app", "Dockerfile", "/tmp/devspace/docker/abc", &types.ImageBuildOptions{})
+	assert.DeepEqual(t, command[:2], []string{"env", "DOCKER_CONFIG=/tmp/devspace/docker/abc"})
+}
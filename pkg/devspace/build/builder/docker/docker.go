@@ -49,6 +49,7 @@ type Builder struct {
 
 	authConfig                *types.AuthConfig
 	client                    dockerclient.Client
+	digest                    string
 	skipPush                  bool
 	skipPushOnLocalKubernetes bool
 }
@@ -73,6 +74,11 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool)
 	return b.helper.ShouldRebuild(cache, forceRebuild)
 }
 
+// Digest returns the digest of the last pushed image
+func (b *Builder) Digest() string {
+	return b.digest
+}
+
 // BuildImage builds a dockerimage with the docker cli
 // contextPath is the absolute path to the context path
 // dockerfilePath is the absolute path to the dockerfile WITHIN the contextPath
@@ -210,7 +216,17 @@ func (b *Builder) pushImage(writer io.Writer, imageName string) error {
 	}
 
 	outStream := streams.NewOut(writer)
-	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), nil)
+	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), func(message jsonmessage.JSONMessage) {
+		if message.Aux == nil {
+			return
+		}
+
+		// the push response contains the digest of the pushed image
+		pushResult := types.PushResult{}
+		if json.Unmarshal(*message.Aux, &pushResult) == nil && pushResult.Digest != "" {
+			b.digest = pushResult.Digest
+		}
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,125 @@
+package helper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/restart"
+	"github.com/loft-sh/devspace/pkg/devspace/services/synccontroller"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/exec"
+)
+
+// SyncContext mirrors the build context into a persistent context directory of the given container with
+// the sync helper, so that only changed files are transferred
+func (b *BuildHelper) SyncContext(pod *k8sv1.Pod, container, remoteContextPath, contextPath, dockerfilePath string, log log.Logger) error {
+	relDockerfile := archive.CanonicalTarNameForPath(dockerfilePath)
+	ignoreRules, err := ReadDockerignore(contextPath, relDockerfile)
+	if err != nil {
+		return err
+	}
+
+	_, stderr, err := b.KubeClient.ExecBuffered(pod, container, []string{"mkdir", "-p", remoteContextPath}, nil)
+	if err != nil {
+		return errors.Errorf("error creating context directory: %s: %v", string(stderr), err)
+	}
+
+	log.StartWait("Uploading changed files to build container")
+	err = synccontroller.UploadOnce(b.KubeClient, pod, container, &synccontroller.UploadOptions{
+		LocalPath:     contextPath,
+		ContainerPath: remoteContextPath,
+		ExcludePaths:  dockerignoreToExcludePaths(ignoreRules),
+	}, log)
+	if err != nil {
+		return err
+	}
+
+	return b.UploadBuildFiles(pod, container, remoteContextPath, dockerfilePath)
+}
+
+// UploadBuildFiles copies the dockerfile and the restart helper (if enabled) into an already uploaded build context
+func (b *BuildHelper) UploadBuildFiles(pod *k8sv1.Pod, container, remoteContextPath, dockerfilePath string) error {
+	// Copy dockerfile
+	err := b.KubeClient.Copy(pod, container, remoteContextPath, dockerfilePath, []string{})
+	if err != nil {
+		return errors.Errorf("error uploading dockerfile to container: %v", err)
+	}
+
+	// Copy restart helper script
+	if b.ImageConf.InjectRestartHelper {
+		tempDir, err := ioutil.TempDir("", "")
+		if err != nil {
+			return err
+		}
+
+		defer os.RemoveAll(tempDir)
+
+		scriptPath := filepath.Join(tempDir, restart.ScriptName)
+		remoteFolder := filepath.ToSlash(filepath.Join(remoteContextPath, ".devspace", ".devspace"))
+		helperScript, err := restart.LoadRestartHelper(b.ImageConf.RestartHelperPath)
+		if err != nil {
+			return errors.Wrap(err, "load restart helper")
+		}
+
+		err = ioutil.WriteFile(scriptPath, []byte(helperScript), 0777)
+		if err != nil {
+			return errors.Wrap(err, "write restart helper script")
+		}
+
+		// create the .devspace directory in the container
+		_, _, err = b.KubeClient.ExecBuffered(pod, container, []string{"mkdir", "-p", remoteFolder}, nil)
+		if err != nil {
+			return errors.Errorf("error executing command 'mkdir -p %s' in build container: %v", remoteFolder, err)
+		}
+
+		// copy the helper script into the container
+		err = b.KubeClient.Copy(pod, container, remoteFolder, scriptPath, []string{})
+		if err != nil {
+			return errors.Errorf("error uploading helper script to container: %v", err)
+		}
+
+		// change permissions for the execution script
+		_, _, err = b.KubeClient.ExecBuffered(pod, container, []string{"chmod", "-R", "0777", remoteFolder}, nil)
+		if err != nil {
+			return errors.Errorf("error executing command 'chmod +x %s' in build container: %v", filepath.Join(remoteContextPath, restart.ScriptName), err)
+		}
+
+		// remove the .dockerignore since .devspace is usually ignored and we want to sneak our helper script in
+		// this shouldn't be any issue since the context was already pruned in the copy step beforehand
+		_, _, err = b.KubeClient.ExecBuffered(pod, container, []string{"rm", filepath.ToSlash(filepath.Join(remoteContextPath, ".dockerignore"))}, nil)
+		if err != nil {
+			if _, ok := err.(exec.CodeExitError); !ok {
+				return errors.Errorf("error executing command 'rm .dockerignore' in build container: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dockerignoreToExcludePaths converts .dockerignore rules, which are always relative to the
+// context root, into sync exclude paths
+func dockerignoreToExcludePaths(ignoreRules []string) []string {
+	excludePaths := make([]string, 0, len(ignoreRules))
+	for _, rule := range ignoreRules {
+		negate := strings.HasPrefix(rule, "!")
+		rule = strings.TrimPrefix(strings.TrimPrefix(rule, "!"), "/")
+		if rule == "" || rule == "." {
+			continue
+		}
+
+		rule = "/" + rule
+		if negate {
+			rule = "!" + rule
+		}
+
+		excludePaths = append(excludePaths, rule)
+	}
+
+	return excludePaths
+}
@@ -0,0 +1,12 @@
+package helper
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDockerignoreToExcludePaths(t *testing.T) {
+	excludePaths := dockerignoreToExcludePaths([]string{"node_modules", "/dist", "!dist/keep", "**/*.log", ".", ""})
+	assert.DeepEqual(t, excludePaths, []string{"/node_modules", "/dist", "!/dist/keep", "/**/*.log"})
+}
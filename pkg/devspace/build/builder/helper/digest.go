@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sync"
+)
+
+var digestRegEx = regexp.MustCompile(`@(sha256:[a-f0-9]{64})`)
+
+// DigestWriter passes everything through to the underlying writer and remembers
+// the last image digest (e.g. 'Pushed registry/image@sha256:...') that was printed
+type DigestWriter struct {
+	Out io.Writer
+
+	m      sync.Mutex
+	line   []byte
+	digest string
+}
+
+// Write implements the io.Writer interface
+func (d *DigestWriter) Write(p []byte) (int, error) {
+	d.m.Lock()
+	d.line = append(d.line, p...)
+	for {
+		idx := bytes.IndexByte(d.line, '\n')
+		if idx == -1 {
+			break
+		}
+
+		d.parseLine(d.line[:idx])
+		d.line = d.line[idx+1:]
+	}
+	d.m.Unlock()
+
+	return d.Out.Write(p)
+}
+
+// Digest returns the last digest that was written
+func (d *DigestWriter) Digest() string {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if len(d.line) > 0 {
+		d.parseLine(d.line)
+	}
+
+	return d.digest
+}
+
+func (d *DigestWriter) parseLine(line []byte) {
+	matches := digestRegEx.FindSubmatch(line)
+	if len(matches) == 2 {
+		d.digest = string(matches[1])
+	}
+}
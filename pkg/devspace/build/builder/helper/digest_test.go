@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDigestWriter(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	out := &bytes.Buffer{}
+	writer := &DigestWriter{Out: out}
+
+	_, err := writer.Write([]byte("INFO Retrieving image manifest golang@sha256:" + strings.Repeat("b", 64) + "\nINFO Pushed index.docker.io/user/image@"))
+	assert.NilError(t, err)
+	_, err = writer.Write([]byte(digest[:20]))
+	assert.NilError(t, err)
+	_, err = writer.Write([]byte(digest[20:] + "\n"))
+	assert.NilError(t, err)
+
+	assert.Equal(t, writer.Digest(), digest)
+	assert.Equal(t, strings.Contains(out.String(), "index.docker.io/user/image@"+digest), true)
+}
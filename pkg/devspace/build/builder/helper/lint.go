@@ -0,0 +1,292 @@
+package helper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/dockerfile"
+	"github.com/pkg/errors"
+)
+
+// Names of the Dockerfile lint checks
+const (
+	LintMissingTarget      = "missing-target"
+	LintAddRemoteURL       = "add-remote-url"
+	LintLatestBaseTag      = "latest-base-tag"
+	LintCopyExcludedSource = "copy-excluded-source"
+	LintAppendInstructions = "append-instructions-multistage"
+)
+
+// LintFinding is a single problem that was found in a Dockerfile
+type LintFinding struct {
+	Check    string
+	Severity latest.LintSeverity
+	Message  string
+
+	// Line is the line in the Dockerfile or 0 if the finding does not belong to a specific line
+	Line int
+}
+
+func (l *LintFinding) String() string {
+	return fmt.Sprintf("[%s] %s: %s", l.Severity, l.Check, l.Message)
+}
+
+// GetBuildTarget returns the configured build target of the image
+func GetBuildTarget(imageConf *latest.ImageConfig) string {
+	if imageConf.Build == nil {
+		return ""
+	}
+
+	var options *latest.BuildOptions
+	if imageConf.Build.Kaniko != nil {
+		options = imageConf.Build.Kaniko.Options
+	} else if imageConf.Build.BuildKit != nil {
+		options = imageConf.Build.BuildKit.Options
+	} else if imageConf.Build.Docker != nil {
+		options = imageConf.Build.Docker.Options
+	}
+	if options == nil {
+		return ""
+	}
+
+	return options.Target
+}
+
+// LintDockerfile checks the dockerfile of the image for common problems
+func LintDockerfile(dockerfilePath, contextPath string, imageConf *latest.ImageConfig) ([]*LintFinding, error) {
+	data, err := ioutil.ReadFile(dockerfilePath)
+	if err != nil {
+		return nil, errors.Errorf("Dockerfile %s missing: %v", dockerfilePath, err)
+	}
+
+	absoluteContextPath, err := filepath.Abs(contextPath)
+	if err != nil {
+		return nil, err
+	}
+	absoluteDockerfilePath, err := filepath.Abs(dockerfilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	relDockerfile, err := filepath.Rel(absoluteContextPath, absoluteDockerfilePath)
+	if err != nil {
+		relDockerfile = filepath.Base(dockerfilePath)
+	}
+	excludes, err := ReadDockerignore(absoluteContextPath, archive.CanonicalTarNameForPath(relDockerfile))
+	if err != nil {
+		return nil, err
+	}
+
+	stages := dockerfile.Stages(dockerfile.Parse(string(data)))
+	target := GetBuildTarget(imageConf)
+	findings := []*LintFinding{}
+	findings = append(findings, lintTarget(stages, target)...)
+	findings = append(findings, lintBaseImages(stages)...)
+	findings = append(findings, lintSources(stages, excludes)...)
+	findings = append(findings, lintAppendInstructions(stages, target, imageConf.AppendDockerfileInstructions)...)
+
+	// filter disabled checks
+	if imageConf.Build != nil && imageConf.Build.Lint != nil && len(imageConf.Build.Lint.Disable) > 0 {
+		filtered := []*LintFinding{}
+	OUTER:
+		for _, finding := range findings {
+			for _, disabled := range imageConf.Build.Lint.Disable {
+				if disabled == finding.Check {
+					continue OUTER
+				}
+			}
+
+			filtered = append(filtered, finding)
+		}
+
+		findings = filtered
+	}
+
+	return findings, nil
+}
+
+func findStage(stages []*dockerfile.Stage, name string) int {
+	for i, stage := range stages {
+		if stage.Name != "" && strings.ToLower(stage.Name) == strings.ToLower(name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func lintTarget(stages []*dockerfile.Stage, target string) []*LintFinding {
+	if target == "" || findStage(stages, target) != -1 {
+		return nil
+	}
+
+	return []*LintFinding{
+		{
+			Check:    LintMissingTarget,
+			Severity: latest.LintSeverityError,
+			Message:  fmt.Sprintf("build target '%s' is not defined as stage in the Dockerfile", target),
+		},
+	}
+}
+
+func lintBaseImages(stages []*dockerfile.Stage) []*LintFinding {
+	findings := []*LintFinding{}
+	for i, stage := range stages {
+		if stage.Image == "" || strings.ToLower(stage.Image) == "scratch" || strings.Contains(stage.Image, "$") {
+			continue
+		}
+
+		// a previous stage is used as base
+		stageIndex := findStage(stages, stage.Image)
+		if stageIndex != -1 && stageIndex < i {
+			continue
+		}
+
+		ref, err := reference.ParseNormalizedNamed(stage.Image)
+		if err != nil {
+			continue
+		}
+
+		tag := ""
+		if tagged, ok := ref.(reference.Tagged); ok {
+			tag = tagged.Tag()
+		}
+		if _, ok := ref.(reference.Digested); ok && tag == "" {
+			continue
+		}
+
+		if tag == "" || tag == "latest" {
+			findings = append(findings, &LintFinding{
+				Check:    LintLatestBaseTag,
+				Severity: latest.LintSeverityWarning,
+				Message:  fmt.Sprintf("base image %s uses the latest tag, builds are not reproducible. Please pin the image to a specific tag", stage.Image),
+				Line:     stage.From.Line,
+			})
+		}
+	}
+
+	return findings
+}
+
+func isRemoteURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+func lintSources(stages []*dockerfile.Stage, excludes []string) []*LintFinding {
+	findings := []*LintFinding{}
+	for _, stage := range stages {
+		for _, instruction := range stage.Instructions {
+			if instruction.Command != "COPY" && instruction.Command != "ADD" {
+				continue
+			}
+
+			// the last argument is the destination
+			if len(instruction.Args) < 2 {
+				continue
+			}
+			for _, source := range instruction.Args[:len(instruction.Args)-1] {
+				if isRemoteURL(source) {
+					if instruction.Command == "ADD" {
+						findings = append(findings, &LintFinding{
+							Check:    LintAddRemoteURL,
+							Severity: latest.LintSeverityWarning,
+							Message:  fmt.Sprintf("ADD downloads %s without checksum verification and caching, consider using curl or wget in a RUN instruction instead", source),
+							Line:     instruction.Line,
+						})
+					}
+
+					continue
+				}
+
+				// sources of other stages or images are not part of the build context
+				if _, ok := instruction.Flags["from"]; ok {
+					continue
+				}
+				if strings.ContainsAny(source, "$*?[") {
+					continue
+				}
+
+				cleaned := path.Clean(strings.TrimPrefix(filepath.ToSlash(source), "/"))
+				if cleaned == "." || cleaned == "/" {
+					continue
+				}
+
+				excluded, err := fileutils.Matches(cleaned, excludes)
+				if err == nil && excluded {
+					findings = append(findings, &LintFinding{
+						Check:    LintCopyExcludedSource,
+						Severity: latest.LintSeverityError,
+						Message:  fmt.Sprintf("%s source %s is excluded by the .dockerignore and will not be part of the build context", instruction.Command, source),
+						Line:     instruction.Line,
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+func lintAppendInstructions(stages []*dockerfile.Stage, target string, appendInstructions []string) []*LintFinding {
+	if len(appendInstructions) == 0 || len(stages) == 0 {
+		return nil
+	}
+
+	// the instructions are appended to the target stage or the last stage
+	stageIndex := len(stages) - 1
+	if target != "" {
+		stageIndex = findStage(stages, target)
+		if stageIndex == -1 {
+			return nil
+		}
+	}
+
+	line := stages[stageIndex].From.Line
+	findings := []*LintFinding{}
+	for _, instruction := range dockerfile.Parse(strings.Join(appendInstructions, "\n")) {
+		if instruction.Command == "FROM" {
+			findings = append(findings, &LintFinding{
+				Check:    LintAppendInstructions,
+				Severity: latest.LintSeverityError,
+				Message:  "appendDockerfileInstructions contain a FROM instruction, which starts a new stage and changes the resulting image",
+				Line:     line,
+			})
+			continue
+		}
+
+		from, ok := instruction.Flags["from"]
+		if !ok || from == "" {
+			continue
+		}
+
+		// --from either references a stage by name or index or an image
+		fromStage := findStage(stages, from)
+		if fromStage == -1 {
+			index, err := strconv.Atoi(from)
+			if err != nil {
+				continue
+			}
+
+			fromStage = index
+		}
+
+		if fromStage >= stageIndex {
+			findings = append(findings, &LintFinding{
+				Check:    LintAppendInstructions,
+				Severity: latest.LintSeverityError,
+				Message:  fmt.Sprintf("appendDockerfileInstructions reference stage '%s' with --from, which is not defined before the stage the instructions are appended to", from),
+				Line:     line,
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,111 @@
+package helper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type lintTestCase struct {
+	name string
+
+	dockerfile   string
+	dockerignore string
+	imageConf    *latest.ImageConfig
+
+	expectedFindings []string
+	expectedLines    []int
+}
+
+func TestLintDockerfile(t *testing.T) {
+	testCases := []lintTestCase{
+		{
+			name: "No problems",
+			dockerfile: `FROM golang:1.15 AS build
+COPY main.go /app/
+RUN go build -o /app/main /app/main.go
+
+FROM alpine:3.13
+COPY --from=build /app/main /main
+ENTRYPOINT ["/main"]`,
+			dockerignore:     "node_modules",
+			imageConf:        &latest.ImageConfig{},
+			expectedFindings: []string{},
+			expectedLines:    []int{},
+		},
+		{
+			name: "All problems",
+			dockerfile: `# build stage
+FROM golang AS build
+ADD https://example.com/tool.tar.gz /tmp/
+COPY node_modules \
+     /app/
+
+FROM alpine:latest
+COPY --from=build /app /app`,
+			dockerignore: "node_modules",
+			imageConf: &latest.ImageConfig{
+				AppendDockerfileInstructions: []string{"COPY --from=dev /app /app", "FROM alpine"},
+				Build: &latest.BuildConfig{
+					Docker: &latest.DockerConfig{
+						Options: &latest.BuildOptions{
+							Target: "dev",
+						},
+					},
+				},
+			},
+			expectedFindings: []string{LintMissingTarget, LintLatestBaseTag, LintLatestBaseTag, LintAddRemoteURL, LintCopyExcludedSource},
+			expectedLines:    []int{0, 2, 7, 3, 4},
+		},
+		{
+			name: "Append instructions reference later stage",
+			dockerfile: `FROM alpine:3.13 AS base
+FROM base AS dev
+FROM alpine:3.13 AS tools`,
+			imageConf: &latest.ImageConfig{
+				AppendDockerfileInstructions: []string{"COPY --from=tools /bin/tool /bin/tool", "COPY --from=0 /etc/a /etc/a", "COPY --from=busybox /bin/sh /bin/sh", "FROM alpine"},
+				Build: &latest.BuildConfig{
+					Kaniko: &latest.KanikoConfig{
+						Options: &latest.BuildOptions{
+							Target: "dev",
+						},
+					},
+					Lint: &latest.LintConfig{
+						Disable: []string{LintLatestBaseTag},
+					},
+				},
+			},
+			expectedFindings: []string{LintAppendInstructions, LintAppendInstructions},
+			expectedLines:    []int{2, 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		dir, err := ioutil.TempDir("", "testLint")
+		assert.NilError(t, err)
+		defer os.RemoveAll(dir)
+
+		err = ioutil.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(testCase.dockerfile), 0666)
+		assert.NilError(t, err)
+		if testCase.dockerignore != "" {
+			err = ioutil.WriteFile(filepath.Join(dir, ".dockerignore"), []byte(testCase.dockerignore), 0666)
+			assert.NilError(t, err)
+		}
+
+		findings, err := LintDockerfile(filepath.Join(dir, "Dockerfile"), dir, testCase.imageConf)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		checks := []string{}
+		lines := []int{}
+		for _, finding := range findings {
+			checks = append(checks, finding.Check)
+			lines = append(lines, finding.Line)
+		}
+		assert.DeepEqual(t, checks, testCase.expectedFindings)
+		assert.DeepEqual(t, lines, testCase.expectedLines)
+	}
+}
@@ -10,3 +10,9 @@ type Interface interface {
 	ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool) (bool, error)
 	Build(log log.Logger) error
 }
+
+// DigestReporter is implemented by builders that are able to tell the
+// registry digest of the last pushed image
+type DigestReporter interface {
+	Digest() string
+}
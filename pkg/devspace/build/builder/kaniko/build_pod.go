@@ -52,6 +52,11 @@ var defaultResources = &availableResources{
 func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions, dockerfilePath string) (*k8sv1.Pod, error) {
 	kanikoOptions := b.helper.ImageConf.Build.Kaniko
 
+	kanikoArgs, err := b.getKanikoArgs(kanikoContextPath, dockerfilePath, options)
+	if err != nil {
+		return nil, err
+	}
+
 	registryURL, err := pullsecrets.GetRegistryFromImageName(b.FullImageName)
 	if err != nil {
 		return nil, err
@@ -72,53 +77,6 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 		kanikoInitImage = kanikoOptions.InitImage
 	}
 
-	// additional options to pass to kaniko
-	kanikoArgs := []string{
-		"--dockerfile=" + kanikoContextPath + "/" + filepath.Base(dockerfilePath),
-		"--context=dir://" + kanikoContextPath,
-	}
-
-	// specify destinations
-	for _, tag := range b.helper.ImageTags {
-		kanikoArgs = append(kanikoArgs, "--destination="+b.helper.ImageName+":"+tag)
-	}
-
-	// set target
-	if options.Target != "" {
-		kanikoArgs = append(kanikoArgs, "--target="+options.Target)
-	}
-
-	// set snapshot mode
-	if kanikoOptions.SnapshotMode != "" {
-		kanikoArgs = append(kanikoArgs, "--snapshotMode="+kanikoOptions.SnapshotMode)
-	} else {
-		kanikoArgs = append(kanikoArgs, "--snapshotMode=time")
-	}
-
-	// allow insecure registry
-	if b.allowInsecureRegistry {
-		kanikoArgs = append(kanikoArgs, "--insecure", "--skip-tls-verify")
-	}
-
-	// build args
-	for key, value := range options.BuildArgs {
-		newKanikoArg := fmt.Sprintf("%v=%v", key, *value)
-		kanikoArgs = append(kanikoArgs, "--build-arg", newKanikoArg)
-	}
-
-	// cache flags
-	if kanikoOptions.Cache == nil || *kanikoOptions.Cache == true {
-		ref, err := reference.ParseNormalizedNamed(b.FullImageName)
-		if err != nil {
-			return nil, err
-		}
-
-		kanikoArgs = append(kanikoArgs, "--cache=true", "--cache-repo="+ref.Name())
-	}
-
-	// extra flags
-	kanikoArgs = append(kanikoArgs, kanikoOptions.Args...)
-
 	// build the volumes
 	volumes := []k8sv1.Volume{
 		{
@@ -358,6 +316,60 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 	return pod, nil
 }
 
+// getKanikoArgs returns the arguments for the kaniko executor to build the image
+// from the given context directory within the build pod
+func (b *Builder) getKanikoArgs(contextDir, dockerfilePath string, options *types.ImageBuildOptions) ([]string, error) {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
+
+	// additional options to pass to kaniko
+	kanikoArgs := []string{
+		"--dockerfile=" + contextDir + "/" + filepath.Base(dockerfilePath),
+		"--context=dir://" + contextDir,
+	}
+
+	// specify destinations
+	for _, tag := range b.helper.ImageTags {
+		kanikoArgs = append(kanikoArgs, "--destination="+b.helper.ImageName+":"+tag)
+	}
+
+	// set target
+	if options.Target != "" {
+		kanikoArgs = append(kanikoArgs, "--target="+options.Target)
+	}
+
+	// set snapshot mode
+	if kanikoOptions.SnapshotMode != "" {
+		kanikoArgs = append(kanikoArgs, "--snapshotMode="+kanikoOptions.SnapshotMode)
+	} else {
+		kanikoArgs = append(kanikoArgs, "--snapshotMode=time")
+	}
+
+	// allow insecure registry
+	if b.allowInsecureRegistry {
+		kanikoArgs = append(kanikoArgs, "--insecure", "--skip-tls-verify")
+	}
+
+	// build args
+	for key, value := range options.BuildArgs {
+		newKanikoArg := fmt.Sprintf("%v=%v", key, *value)
+		kanikoArgs = append(kanikoArgs, "--build-arg", newKanikoArg)
+	}
+
+	// cache flags
+	if kanikoOptions.Cache == nil || *kanikoOptions.Cache == true {
+		ref, err := reference.ParseNormalizedNamed(b.FullImageName)
+		if err != nil {
+			return nil, err
+		}
+
+		kanikoArgs = append(kanikoArgs, "--cache=true", "--cache-repo="+ref.Name())
+	}
+
+	// extra flags
+	kanikoArgs = append(kanikoArgs, kanikoOptions.Args...)
+	return kanikoArgs, nil
+}
+
 func ConvertMap(m map[string]string) (map[k8sv1.ResourceName]resource.Quantity, error) {
 	if m == nil {
 		return nil, nil
@@ -12,11 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"strings"
 
-	"k8s.io/client-go/util/exec"
-
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
-	"github.com/loft-sh/devspace/pkg/devspace/build/builder/restart"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/docker"
@@ -35,6 +32,7 @@ import (
 	"github.com/docker/docker/api/types"
 	dockerterm "github.com/moby/term"
 	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/util/interrupt"
 )
@@ -56,6 +54,7 @@ type Builder struct {
 
 	allowInsecureRegistry bool
 	dockerClient          docker.Client
+	digestWriter          *helper.DigestWriter
 }
 
 // Wait timeout is the maximum time to wait for the kaniko init and build container to get ready
@@ -110,6 +109,15 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool)
 	return b.helper.ShouldRebuild(cache, forceRebuild)
 }
 
+// Digest returns the digest of the pushed image
+func (b *Builder) Digest() string {
+	if b.digestWriter == nil {
+		return ""
+	}
+
+	return b.digestWriter.Digest()
+}
+
 // Authenticate authenticates kaniko for pushing to the RegistryURL (if username == "", it will try to get login data from local docker daemon)
 func (b *Builder) createPullSecret(log logpkg.Logger) error {
 	username, password := "", ""
@@ -178,6 +186,12 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 	// Generate the build pod spec
 	randString := randutil.GenerateRandomString(12)
 	buildID := strings.ToLower(randString)
+
+	// Build within a reusable build pod if enabled
+	if b.helper.ImageConf.Build.Kaniko.Reuse != nil && b.helper.ImageConf.Build.Kaniko.Reuse.Enabled {
+		return b.buildInWarmPod(buildID, contextPath, dockerfilePath, options, log)
+	}
+
 	buildPod, err := b.getBuildPod(buildID, options, dockerfilePath)
 	if err != nil {
 		return errors.Wrap(err, "get build pod")
@@ -246,89 +260,11 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 			return errors.Wrap(err, "waiting for kaniko init")
 		}
 
-		// Get ignore rules from docker ignore
-		relDockerfile := archive.CanonicalTarNameForPath(dockerfilePath)
-		ignoreRules, err := helper.ReadDockerignore(contextPath, relDockerfile)
+		// Upload the build context
+		err = b.uploadContext(buildPod, buildPod.Spec.InitContainers[0].Name, kanikoContextPath, contextPath, dockerfilePath, log)
 		if err != nil {
 			return err
 		}
-		if err := build.ValidateContextDirectory(contextPath, ignoreRules); err != nil {
-			return errors.Errorf("error checking context: '%s'", err)
-		}
-
-		log.StartWait("Uploading files to build container")
-		buildCtx, err := archive.TarWithOptions(contextPath, &archive.TarOptions{
-			ExcludePatterns: ignoreRules,
-			ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
-		})
-		if err != nil {
-			return err
-		}
-
-		// Copy complete context
-		_, stderr, err := b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"tar", "xp", "-C", kanikoContextPath + "/."}, buildCtx)
-		if err != nil {
-			if stderr != nil {
-				return errors.Errorf("copy context: error executing tar: %s: %v", string(stderr), err)
-			}
-
-			return errors.Wrap(err, "copy context")
-		}
-
-		// Copy dockerfile
-		err = b.helper.KubeClient.Copy(buildPod, buildPod.Spec.InitContainers[0].Name, kanikoContextPath, dockerfilePath, []string{})
-		if err != nil {
-			return errors.Errorf("error uploading dockerfile to container: %v", err)
-		}
-
-		// Copy restart helper script
-		if b.helper.ImageConf.InjectRestartHelper {
-			tempDir, err := ioutil.TempDir("", "")
-			if err != nil {
-				return err
-			}
-
-			defer os.RemoveAll(tempDir)
-
-			scriptPath := filepath.Join(tempDir, restart.ScriptName)
-			remoteFolder := filepath.ToSlash(filepath.Join(kanikoContextPath, ".devspace", ".devspace"))
-			helperScript, err := restart.LoadRestartHelper(b.helper.ImageConf.RestartHelperPath)
-			if err != nil {
-				return errors.Wrap(err, "load restart helper")
-			}
-
-			err = ioutil.WriteFile(scriptPath, []byte(helperScript), 0777)
-			if err != nil {
-				return errors.Wrap(err, "write restart helper script")
-			}
-
-			// create the .devspace directory in the container
-			_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"mkdir", "-p", remoteFolder}, nil)
-			if err != nil {
-				return errors.Errorf("error executing command 'mkdir -p %s' in init container: %v", remoteFolder, err)
-			}
-
-			// copy the helper script into the container
-			err = b.helper.KubeClient.Copy(buildPod, buildPod.Spec.InitContainers[0].Name, remoteFolder, scriptPath, []string{})
-			if err != nil {
-				return errors.Errorf("error uploading helper script to container: %v", err)
-			}
-
-			// change permissions for the execution script
-			_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"chmod", "-R", "0777", remoteFolder}, nil)
-			if err != nil {
-				return errors.Errorf("error executing command 'chmod +x %s' in init container: %v", filepath.Join(kanikoContextPath, restart.ScriptName), err)
-			}
-
-			// remove the .dockerignore since .devspace is usually ignored and we want to sneak our helper script in
-			// this shouldn't be any issue since the context was already pruned in the copy step beforehand
-			_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"rm", filepath.ToSlash(filepath.Join(kanikoContextPath, ".dockerignore"))}, nil)
-			if err != nil {
-				if _, ok := err.(exec.CodeExitError); !ok {
-					return errors.Errorf("error executing command 'rm .dockerignore' in init container: %v", err)
-				}
-			}
-		}
 
 		// Tell init container we are done
 		_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"touch", doneFile}, nil)
@@ -386,7 +322,8 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 			writer = log
 		}
 
-		stdoutLogger := kanikoLogger{out: writer}
+		b.digestWriter = &helper.DigestWriter{Out: writer}
+		stdoutLogger := kanikoLogger{out: b.digestWriter}
 
 		// Stream the logs
 		err = services.NewClient(b.helper.Config, nil, b.helper.KubeClient, log).StartLogsWithWriter(targetselector.NewOptionsFromFlags(buildPod.Spec.Containers[0].Name, "", buildPod.Namespace, buildPod.Name, false), true, 100, false, stdoutLogger)
@@ -421,7 +358,7 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 	if err != nil {
 		// Delete all build pods on error
 		pods, getErr := b.helper.KubeClient.KubeClient().CoreV1().Pods(b.BuildNamespace).List(context.TODO(), metav1.ListOptions{
-			LabelSelector: "devspace-build=true",
+			LabelSelector: "devspace-build=true," + warmPodLabel + "!=true",
 		})
 		if getErr != nil {
 			return err
@@ -435,3 +372,37 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 
 	return nil
 }
+
+// uploadContext uploads the build context, the dockerfile and the restart helper if needed into the given container
+func (b *Builder) uploadContext(pod *k8sv1.Pod, container, remoteContextPath, contextPath, dockerfilePath string, log logpkg.Logger) error {
+	// Get ignore rules from docker ignore
+	relDockerfile := archive.CanonicalTarNameForPath(dockerfilePath)
+	ignoreRules, err := helper.ReadDockerignore(contextPath, relDockerfile)
+	if err != nil {
+		return err
+	}
+	if err := build.ValidateContextDirectory(contextPath, ignoreRules); err != nil {
+		return errors.Errorf("error checking context: '%s'", err)
+	}
+
+	log.StartWait("Uploading files to build container")
+	buildCtx, err := archive.TarWithOptions(contextPath, &archive.TarOptions{
+		ExcludePatterns: ignoreRules,
+		ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Copy complete context
+	_, stderr, err := b.helper.KubeClient.ExecBuffered(pod, container, []string{"tar", "xp", "-C", remoteContextPath + "/."}, buildCtx)
+	if err != nil {
+		if stderr != nil {
+			return errors.Errorf("copy context: error executing tar: %s: %v", string(stderr), err)
+		}
+
+		return errors.Wrap(err, "copy context")
+	}
+
+	return b.helper.UploadBuildFiles(pod, container, remoteContextPath, dockerfilePath)
+}
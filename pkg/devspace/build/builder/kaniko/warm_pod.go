@@ -0,0 +1,469 @@
+package kaniko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/exec"
+	"k8s.io/kubectl/pkg/util/interrupt"
+)
+
+// The kaniko image we use by default for reusable build pods, it needs to contain a shell
+const kanikoWarmBuildImage = "gcr.io/kaniko-project/executor:v1.5.2-debug"
+
+// The label that marks reusable build pods
+const warmPodLabel = "devspace-build-warm"
+
+// The label that holds the hash of the reusable build pod spec
+const warmPodHashLabel = "devspace-build-hash"
+
+// The directory within the reusable build pod where the build contexts are stored
+const warmPodWorkspace = "/workspace"
+
+// The directory that is created while a build is running in the reusable build pod
+const warmPodLock = warmPodWorkspace + "/.lock"
+
+// The file within the lock directory that holds the build id of the lock owner and the time it was acquired
+const warmPodLockOwner = warmPodLock + "/owner"
+
+// The directory that is created by the build that removes a stale lock
+const warmPodLockTakeover = warmPodWorkspace + "/.lock-takeover"
+
+// The time in seconds after which a lock that was not refreshed is considered stale, e.g. because
+// the client that held it was killed
+const staleLockTimeout = int64(60)
+
+// The interval in which a running build refreshes its lock
+const lockRefreshInterval = 15 * time.Second
+
+// The file that holds the time of the last build activity in the reusable build pod
+const warmPodActivityFile = warmPodWorkspace + "/.last-activity"
+
+// The directory within the reusable build pod where the persistent build contexts are stored
+const warmPodContexts = warmPodWorkspace + "/contexts"
+
+// The default time in seconds after which an idle build pod terminates itself
+const defaultIdleTimeout = int64(600)
+
+// buildInWarmPod builds the image in a long-lived build pod, that is shared between all
+// builds with the same kaniko configuration in the namespace
+func (b *Builder) buildInWarmPod(buildID, contextPath, dockerfilePath string, options *types.ImageBuildOptions, log logpkg.Logger) error {
+	defer log.StopWait()
+
+	buildPod, err := b.ensureWarmPod(options, log)
+	if err != nil {
+		return errors.Wrap(err, "ensure build pod")
+	}
+
+	container := buildPod.Spec.Containers[0].Name
+	buildDir := warmPodWorkspace + "/" + buildID
+	remoteContextPath := buildDir + "/context"
+
+	// Make sure only one build at a time runs in the build pod, because
+	// kaniko modifies the root filesystem of the container during a build
+	err = b.acquireWarmPodLock(buildPod, container, buildID, log)
+	if err != nil {
+		return err
+	}
+
+	// Refresh the lock while the build is running, so that it doesn't become stale
+	stopRefresh := make(chan struct{})
+	go b.refreshWarmPodLock(buildPod, container, stopRefresh)
+
+	// Release the lock and remove the build context when we are done or get interrupted during build
+	var releaseOnce sync.Once
+	releaseLock := func() {
+		releaseOnce.Do(func() {
+			close(stopRefresh)
+			_, _, err := b.helper.KubeClient.ExecBuffered(buildPod, container, []string{"sh", "-c", releaseLockScript(buildID, buildDir)}, nil)
+			if err != nil {
+				log.Warnf("Error releasing build pod %s/%s: %v", buildPod.Namespace, buildPod.Name, err)
+			}
+		})
+	}
+
+	return interrupt.New(nil, releaseLock).Run(func() error {
+		defer releaseLock()
+
+		// Update the persistent build context with only the changed files if enabled
+		// and fall back to uploading the complete build context
+		incremental := false
+		if b.helper.ImageConf.Build.Kaniko.Reuse.IncrementalContext {
+			incrementalContextPath := warmPodContexts + "/" + b.helper.ImageConfigName
+			err := b.helper.SyncContext(buildPod, container, incrementalContextPath, contextPath, dockerfilePath, log)
+			if err != nil {
+				log.Warnf("Error uploading build context incrementally, falling back to full upload: %v", err)
+			} else {
+				remoteContextPath = incrementalContextPath
+				incremental = true
+			}
+		}
+
+		if !incremental {
+			_, stderr, err := b.helper.KubeClient.ExecBuffered(buildPod, container, []string{"mkdir", "-p", remoteContextPath}, nil)
+			if err != nil {
+				return errors.Errorf("error creating build directory: %s: %v", string(stderr), err)
+			}
+
+			err = b.uploadContext(buildPod, container, remoteContextPath, contextPath, dockerfilePath, log)
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Done("Uploaded files to container")
+		log.StopWait()
+
+		kanikoArgs, err := b.getKanikoArgs(remoteContextPath, dockerfilePath, options)
+		if err != nil {
+			return err
+		}
+
+		// Determine output writer
+		var writer io.Writer
+		if log == logpkg.GetInstance() {
+			writer = stdout
+		} else {
+			writer = log
+		}
+
+		// Run the kaniko executor and clean the filesystem afterwards, so that the next build
+		// starts from a clean state
+		b.digestWriter = &helper.DigestWriter{Out: writer}
+		stdoutLogger := kanikoLogger{out: b.digestWriter}
+		command := []string{"/kaniko/executor"}
+		if len(b.helper.ImageConf.Build.Kaniko.Command) > 0 {
+			command = b.helper.ImageConf.Build.Kaniko.Command
+		}
+		command = append(command, kanikoArgs...)
+		command = append(command, "--cleanup")
+		if incremental {
+			// keep the injected sync helper, otherwise it would be removed during cleanup
+			command = append(command, "--ignore-path="+inject.DevSpaceHelperContainerPath)
+		}
+
+		err = b.helper.KubeClient.ExecStream(&kubectl.ExecStreamOptions{
+			Pod:       buildPod,
+			Container: container,
+			Command:   command,
+			Stdout:    stdoutLogger,
+			Stderr:    stdoutLogger,
+		})
+		if err != nil {
+			if exitErr, ok := err.(exec.CodeExitError); ok {
+				return errors.Errorf("error building image (Exit Code %d)", exitErr.Code)
+			}
+
+			return errors.Wrap(err, "execute kaniko")
+		}
+
+		log.Done("Done building image")
+		return nil
+	})
+}
+
+// acquireLockScript returns the script that removes a stale lock and then locks the build pod for the given build.
+// mkdir is atomic, so only a single build can create the lock directory. A stale lock is only removed by the build
+// that created the takeover directory and only if the lock is still stale, so a build can never remove a lock that
+// another build has just created
+func acquireLockScript(buildID string) string {
+	isStale := `[ -d ` + warmPodLock + ` ] && [ $(( $(date +%s) - $(stat -c %Y ` + warmPodLock + `) )) -ge ` + strconv.FormatInt(staleLockTimeout, 10) + ` ]`
+	return `if ` + isStale + ` && mkdir ` + warmPodLockTakeover + ` 2>/dev/null; then
+  if ` + isStale + `; then
+    echo "$(head -n 1 ` + warmPodLockOwner + ` 2>/dev/null)"
+    rm -rf ` + warmPodLock + `
+  fi
+  rmdir ` + warmPodLockTakeover + `
+fi
+mkdir ` + warmPodLock + ` && printf '%s\n%s\n' ` + buildID + ` "$(date +%s)" > ` + warmPodLockOwner + ` && touch ` + warmPodActivityFile
+}
+
+// releaseLockScript returns the script that removes the build directory and the lock if it is still owned by
+// the given build. It succeeds if it is run multiple times
+func releaseLockScript(buildID, buildDir string) string {
+	return `rm -rf ` + buildDir + `; touch ` + warmPodActivityFile + `; if [ "$(head -n 1 ` + warmPodLockOwner + ` 2>/dev/null)" = "` + buildID + `" ]; then rm -rf ` + warmPodLock + `; fi`
+}
+
+// acquireWarmPodLock waits until no other build is running in the build pod and then locks it
+func (b *Builder) acquireWarmPodLock(buildPod *k8sv1.Pod, container, buildID string, log logpkg.Logger) error {
+	log.StartWait("Waiting for build pod " + buildPod.Name + " to become available")
+	defer log.StopWait()
+
+	err := wait.PollImmediate(time.Second*2, waitTimeout, func() (bool, error) {
+		stdout, _, err := b.helper.KubeClient.ExecBuffered(buildPod, container, []string{"sh", "-c", acquireLockScript(buildID)}, nil)
+		if staleOwner := strings.TrimSpace(string(stdout)); staleOwner != "" {
+			log.Warnf("Removed stale lock of build %s in build pod %s/%s", staleOwner, buildPod.Namespace, buildPod.Name)
+		}
+		if err != nil {
+			if _, ok := err.(exec.CodeExitError); ok {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		if err == wait.ErrWaitTimeout {
+			return errors.Errorf("timed out waiting for build pod %s/%s to become available. If no other build is running, remove the build pod with 'devspace cleanup build-pods'", buildPod.Namespace, buildPod.Name)
+		}
+
+		return errors.Wrap(err, "lock build pod")
+	}
+
+	return nil
+}
+
+// refreshWarmPodLock updates the modification time of the lock until stop is closed
+func (b *Builder) refreshWarmPodLock(buildPod *k8sv1.Pod, container string, stop chan struct{}) {
+	ticker := time.NewTicker(lockRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_, _, _ = b.helper.KubeClient.ExecBuffered(buildPod, container, []string{"touch", warmPodLock}, nil)
+		}
+	}
+}
+
+// ensureWarmPod returns a running reusable build pod or creates a new one
+func (b *Builder) ensureWarmPod(options *types.ImageBuildOptions, log logpkg.Logger) (*k8sv1.Pod, error) {
+	warmPod, err := b.getWarmPod(options)
+	if err != nil {
+		return nil, err
+	}
+
+	create := false
+	pods := b.helper.KubeClient.KubeClient().CoreV1().Pods(b.BuildNamespace)
+	err = deleteTerminatedWarmPods(pods, warmPod.Name)
+	if err != nil {
+		log.Warnf("Error removing expired build pods: %v", err)
+	}
+
+	existing, err := pods.Get(context.TODO(), warmPod.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return nil, err
+		}
+
+		create = true
+	} else if existing.DeletionTimestamp != nil || existing.Status.Phase == k8sv1.PodSucceeded || existing.Status.Phase == k8sv1.PodFailed {
+		// the pod has terminated itself because it was idle for too long
+		log.StartWait("Removing expired build pod " + existing.Name)
+		err = deleteAndWait(pods, existing.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		create = true
+	} else {
+		log.Infof("Reusing build pod %s/%s", existing.Namespace, existing.Name)
+	}
+
+	if create {
+		_, err = pods.Create(context.TODO(), warmPod, metav1.CreateOptions{})
+		if err != nil && kerrors.IsAlreadyExists(err) == false {
+			return nil, errors.Errorf("unable to create build pod: %v", err)
+		}
+	}
+
+	log.StartWait("Waiting for build pod to start")
+	defer log.StopWait()
+
+	var buildPod *k8sv1.Pod
+	err = wait.PollImmediate(time.Second, waitTimeout, func() (bool, error) {
+		buildPod, err = pods.Get(context.TODO(), warmPod.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		} else if len(buildPod.Status.ContainerStatuses) > 0 {
+			status := buildPod.Status.ContainerStatuses[0]
+			if status.State.Terminated != nil {
+				return false, fmt.Errorf("build pod %s/%s has unexpectedly exited with code %d: %s", buildPod.Namespace, buildPod.Name, status.State.Terminated.ExitCode, status.State.Terminated.Message)
+			} else if status.State.Waiting != nil && kubectl.CriticalStatus[status.State.Waiting.Reason] {
+				return false, fmt.Errorf("build pod %s/%s cannot start: %s (%s)", buildPod.Namespace, buildPod.Name, status.State.Waiting.Message, status.State.Waiting.Reason)
+			}
+		}
+
+		return len(buildPod.Status.ContainerStatuses) > 0 && buildPod.Status.ContainerStatuses[0].State.Running != nil, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "waiting for build pod")
+	}
+
+	return buildPod, nil
+}
+
+// getWarmPod returns the spec of the reusable build pod. The pod name is derived from
+// the hash of the spec, so that builds with the same kaniko configuration share a pod
+func (b *Builder) getWarmPod(options *types.ImageBuildOptions) (*k8sv1.Pod, error) {
+	reuseOptions := b.helper.ImageConf.Build.Kaniko.Reuse
+	pod, err := b.getBuildPod("", options, "Dockerfile")
+	if err != nil {
+		return nil, err
+	}
+
+	idleTimeout := defaultIdleTimeout
+	if reuseOptions.IdleTimeout != nil {
+		idleTimeout = *reuseOptions.IdleTimeout
+	}
+
+	image := kanikoWarmBuildImage
+	if reuseOptions.Image != "" {
+		image = reuseOptions.Image
+	}
+
+	// the build pod waits for builds and terminates itself after it was idle for too long
+	script := `touch ` + warmPodActivityFile + `
+while true; do
+  sleep 5
+  if [ -d ` + warmPodLock + ` ] && [ $(( $(date +%s) - $(stat -c %Y ` + warmPodLock + `) )) -lt ` + strconv.FormatInt(staleLockTimeout, 10) + ` ]; then continue; fi
+  if [ $(( $(date +%s) - $(stat -c %Y ` + warmPodActivityFile + `) )) -ge ` + strconv.FormatInt(idleTimeout, 10) + ` ]; then exit 0; fi
+done`
+
+	// we don't need the init container and the context volume, because the
+	// context is uploaded into the workspace for each build
+	volumes := []k8sv1.Volume{
+		{
+			Name: "workspace",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name != "context" {
+			volumes = append(volumes, volume)
+		}
+	}
+	volumeMounts := []k8sv1.VolumeMount{
+		{
+			Name:      "workspace",
+			MountPath: warmPodWorkspace,
+		},
+	}
+	for _, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+		if volumeMount.Name != "context" {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+
+	pod.Spec.InitContainers = nil
+	pod.Spec.Volumes = volumes
+	pod.Spec.Containers[0].Image = image
+	pod.Spec.Containers[0].Command = []string{"sh", "-c", script}
+	pod.Spec.Containers[0].Args = nil
+	pod.Spec.Containers[0].VolumeMounts = volumeMounts
+	pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, k8sv1.EnvVar{
+		Name:  "PATH",
+		Value: "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/kaniko:/busybox",
+	})
+
+	// hash the pod spec to find an existing build pod with the same configuration
+	out, err := json.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	podHash := hash.String(b.BuildNamespace + string(out))[:10]
+	pod.GenerateName = ""
+	pod.Name = "devspace-build-kaniko-" + podHash
+	delete(pod.Labels, "devspace-build-id")
+	pod.Labels[warmPodLabel] = "true"
+	pod.Labels[warmPodHashLabel] = podHash
+	return pod, nil
+}
+
+// deleteTerminatedWarmPods deletes all reusable build pods except the one with the given name that have
+// terminated themselves because they were idle for too long
+func deleteTerminatedWarmPods(pods typedcorev1.PodInterface, except string) error {
+	podList, err := pods.List(context.TODO(), metav1.ListOptions{LabelSelector: warmPodLabel + "=true"})
+	if err != nil {
+		return err
+	}
+
+	gracePeriod := int64(0)
+	for _, pod := range podList.Items {
+		if pod.Name == except || (pod.Status.Phase != k8sv1.PodSucceeded && pod.Status.Phase != k8sv1.PodFailed) {
+			continue
+		}
+
+		err = pods.Delete(context.TODO(), pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+		if err != nil && kerrors.IsNotFound(err) == false {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAndWait deletes the given pod and waits until it is gone
+func deleteAndWait(pods typedcorev1.PodInterface, name string) error {
+	gracePeriod := int64(0)
+	err := pods.Delete(context.TODO(), name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+	if err != nil && kerrors.IsNotFound(err) == false {
+		return errors.Wrap(err, "delete build pod")
+	}
+
+	return wait.PollImmediate(time.Second, waitTimeout, func() (bool, error) {
+		_, err := pods.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return true, nil
+			}
+
+			return false, err
+		}
+
+		return false, nil
+	})
+}
+
+// DeleteBuildPods deletes all kaniko build pods (including reusable build pods) in the given namespace
+// and returns the names of the deleted pods
+func DeleteBuildPods(client kubectl.Client, namespace string) ([]string, error) {
+	pods, err := client.KubeClient().CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "devspace-build=true",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list build pods")
+	}
+
+	deleted := []string{}
+	gracePeriod := int64(0)
+	for _, pod := range pods.Items {
+		err = client.KubeClient().CoreV1().Pods(namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+		if err != nil && kerrors.IsNotFound(err) == false {
+			return deleted, errors.Wrapf(err, "delete build pod %s", pod.Name)
+		}
+
+		deleted = append(deleted, pod.Name)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,117 @@
+package kaniko
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"gotest.tools/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newWarmPodBuilder(image string, kanikoConfig *latest.KanikoConfig) *Builder {
+	imageConf := &latest.ImageConfig{
+		Image: image,
+		Build: &latest.BuildConfig{
+			Kaniko: kanikoConfig,
+		},
+	}
+
+	kubeClient := &fakekube.Client{Client: fake.NewSimpleClientset()}
+	return &Builder{
+		PullSecretName: "my-pull-secret",
+		FullImageName:  image + ":abc",
+		BuildNamespace: "test",
+		helper:         helper.NewBuildHelper(nil, kubeClient, EngineName, "test", imageConf, []string{"abc"}),
+	}
+}
+
+func TestGetWarmPod(t *testing.T) {
+	reuse := &latest.KanikoReuseConfig{Enabled: true}
+	podA, err := newWarmPodBuilder("myuser/a", &latest.KanikoConfig{Reuse: reuse}).getWarmPod(&types.ImageBuildOptions{})
+	assert.NilError(t, err)
+	podB, err := newWarmPodBuilder("myuser/b", &latest.KanikoConfig{Reuse: reuse, Args: []string{"--verbosity=debug"}}).getWarmPod(&types.ImageBuildOptions{Target: "dev"})
+	assert.NilError(t, err)
+	podC, err := newWarmPodBuilder("myuser/a", &latest.KanikoConfig{Reuse: reuse, NodeSelector: map[string]string{"pool": "builders"}}).getWarmPod(&types.ImageBuildOptions{})
+	assert.NilError(t, err)
+
+	// image specific kaniko arguments should not influence the build pod
+	assert.Equal(t, podA.Name, podB.Name)
+	assert.Assert(t, podA.Name != podC.Name)
+
+	assert.Equal(t, podA.Labels[warmPodLabel], "true")
+	assert.Equal(t, len(podA.Spec.InitContainers), 0)
+	assert.Equal(t, len(podA.Spec.Containers[0].Args), 0)
+	assert.Equal(t, podA.Spec.Containers[0].Image, kanikoWarmBuildImage)
+	for _, volume := range podA.Spec.Volumes {
+		assert.Assert(t, volume.Name != "context")
+	}
+}
+
+func TestLockScripts(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("lock scripts use gnu stat")
+	}
+
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	run := func(script string) (string, error) {
+		out, err := exec.Command("sh", "-c", strings.ReplaceAll(script, warmPodWorkspace, dir)).Output()
+		return string(out), err
+	}
+
+	_, err = run(acquireLockScript("build-a"))
+	assert.NilError(t, err)
+	_, err = run(acquireLockScript("build-b"))
+	assert.Assert(t, err != nil, "lock was acquired twice")
+
+	// releasing a lock of another build doesn't remove it and releasing twice succeeds
+	_, err = run(releaseLockScript("build-b", filepath.Join(warmPodWorkspace, "build-b")))
+	assert.NilError(t, err)
+	_, err = os.Stat(filepath.Join(dir, ".lock"))
+	assert.NilError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = run(releaseLockScript("build-a", filepath.Join(warmPodWorkspace, "build-a")))
+		assert.NilError(t, err)
+	}
+	_, err = os.Stat(filepath.Join(dir, ".lock"))
+	assert.Assert(t, os.IsNotExist(err))
+
+	// a lock that was not refreshed is removed
+	_, err = run(acquireLockScript("build-a"))
+	assert.NilError(t, err)
+	stale := time.Now().Add(-time.Duration(staleLockTimeout+10) * time.Second)
+	assert.NilError(t, os.Chtimes(filepath.Join(dir, ".lock"), stale, stale))
+	out, err := run(acquireLockScript("build-b"))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(out), "build-a")
+
+	// only a single build takes over a stale lock, even if they try at the same time
+	assert.NilError(t, os.Chtimes(filepath.Join(dir, ".lock"), stale, stale))
+	results := make(chan error)
+	for i := 0; i < 5; i++ {
+		go func(i int) {
+			_, err := run(acquireLockScript("build-" + strconv.Itoa(i)))
+			results <- err
+		}(i)
+	}
+	acquired := 0
+	for i := 0; i < 5; i++ {
+		if <-results == nil {
+			acquired++
+		}
+	}
+	assert.Equal(t, acquired, 1)
+}
@@ -93,6 +93,31 @@ func (c *controller) createBuilder(imageConfigName string, imageConf *latest.Ima
 	return builder, nil
 }
 
+// builderName returns the name of the engine the given builder uses
+func builderName(b builder.Interface) string {
+	switch b.(type) {
+	case *docker.Builder:
+		return docker.EngineName
+	case *kaniko.Builder:
+		return kaniko.EngineName
+	case *buildkit.Builder:
+		return buildkit.EngineName
+	case *custom.Builder:
+		return "custom"
+	}
+
+	return ""
+}
+
+// builderDigest returns the digest of the pushed image if the builder is able to tell
+func builderDigest(b builder.Interface) string {
+	if digestReporter, ok := b.(builder.DigestReporter); ok {
+		return digestReporter.Digest()
+	}
+
+	return ""
+}
+
 func convertDockerConfigToKanikoConfig(dockerConfig *latest.ImageConfig) *latest.ImageConfig {
 	kanikoBuildOptions := &latest.KanikoConfig{
 		Cache: ptr.Bool(true),
@@ -0,0 +1,84 @@
+package build
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// lintImages checks the dockerfiles of all images that have linting enabled (or all images if forced)
+// and returns an error if a finding has a severity that should fail the build
+func (c *controller) lintImages(force bool, log logpkg.Logger) error {
+	config := c.config.Config()
+	imageConfigNames := []string{}
+	for imageConfigName := range config.Images {
+		imageConfigNames = append(imageConfigNames, imageConfigName)
+	}
+	sort.Strings(imageConfigNames)
+
+	failed := []string{}
+	for _, imageConfigName := range imageConfigNames {
+		imageConf := config.Images[imageConfigName]
+		if imageConf.Build != nil && (imageConf.Build.Disabled || imageConf.Build.Custom != nil) {
+			continue
+		}
+
+		lintConfig := &latest.LintConfig{}
+		if imageConf.Build != nil && imageConf.Build.Lint != nil {
+			lintConfig = imageConf.Build.Lint
+		}
+		if !force && !lintConfig.Enabled {
+			continue
+		}
+
+		dockerfilePath, contextPath := helper.GetDockerfileAndContext(imageConf)
+		findings, err := helper.LintDockerfile(dockerfilePath, contextPath, imageConf)
+		if err != nil {
+			return errors.Wrapf(err, "lint image %s", imageConfigName)
+		}
+
+		failOn := lintConfig.FailOn
+		if len(failOn) == 0 {
+			failOn = []latest.LintSeverity{latest.LintSeverityError}
+		}
+
+		fail := false
+		for _, finding := range findings {
+			location := dockerfilePath
+			if finding.Line > 0 {
+				location += ":" + strconv.Itoa(finding.Line)
+			}
+
+			switch finding.Severity {
+			case latest.LintSeverityError:
+				log.Errorf("%s: %s", location, finding.String())
+			case latest.LintSeverityWarning:
+				log.Warnf("%s: %s", location, finding.String())
+			default:
+				log.Infof("%s: %s", location, finding.String())
+			}
+
+			for _, severity := range failOn {
+				if severity == finding.Severity {
+					fail = true
+				}
+			}
+		}
+
+		if fail {
+			failed = append(failed, imageConfigName)
+		} else if len(findings) == 0 {
+			log.Donef("No problems found in %s of image %s", dockerfilePath, imageConfigName)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("linting the Dockerfile of image(s) %v failed, please fix the problems above or adjust images.*.build.lint", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package build
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ImageReport describes the outcome of a single image config during a build
+type ImageReport struct {
+	ImageConfigName string   `json:"imageConfigName"`
+	Image           string   `json:"image"`
+	Tags            []string `json:"tags,omitempty"`
+	Digest          string   `json:"digest,omitempty"`
+	Builder         string   `json:"builder,omitempty"`
+
+	// Duration is the build duration in seconds
+	Duration float64 `json:"duration"`
+
+	// Skipped is true if the image was not rebuilt because
+	// nothing has changed since the last build
+	Skipped bool `json:"skipped"`
+
+	// Dependency is the name of the dependency the image config
+	// belongs to, empty for the root config
+	Dependency string `json:"dependency,omitempty"`
+}
+
+// Report collects the results of all image builds, including the builds
+// of dependencies, and can be written to a file as json
+type Report struct {
+	dependency string
+	images     *reportImages
+}
+
+type reportImages struct {
+	m      sync.Mutex
+	images []*ImageReport
+}
+
+// NewReport creates a new empty build report
+func NewReport() *Report {
+	return &Report{
+		images: &reportImages{},
+	}
+}
+
+// WithDependency returns a report that shares all entries with this report, but
+// marks every new entry as originating from the given dependency
+func (r *Report) WithDependency(name string) *Report {
+	return &Report{
+		dependency: name,
+		images:     r.images,
+	}
+}
+
+// Add adds a new image to the report
+func (r *Report) Add(image *ImageReport) {
+	if r == nil || image == nil {
+		return
+	}
+
+	image.Dependency = r.dependency
+	r.images.m.Lock()
+	defer r.images.m.Unlock()
+
+	r.images.images = append(r.images.images, image)
+}
+
+// AddBuilt adds a built image to the report
+func (r *Report) AddBuilt(imageConfigName, image string, tags []string, builderName, digest string, duration time.Duration) {
+	r.Add(&ImageReport{
+		ImageConfigName: imageConfigName,
+		Image:           image,
+		Tags:            tags,
+		Digest:          digest,
+		Builder:         builderName,
+		Duration:        duration.Seconds(),
+	})
+}
+
+// AddSkipped adds an image to the report that was not rebuilt
+func (r *Report) AddSkipped(imageConfigName, image string, tags []string, builderName string) {
+	r.Add(&ImageReport{
+		ImageConfigName: imageConfigName,
+		Image:           image,
+		Tags:            tags,
+		Builder:         builderName,
+		Skipped:         true,
+	})
+}
+
+// Images returns all images of the report sorted by dependency and image config name
+func (r *Report) Images() []*ImageReport {
+	r.images.m.Lock()
+	defer r.images.m.Unlock()
+
+	images := make([]*ImageReport, len(r.images.images))
+	copy(images, r.images.images)
+	sort.SliceStable(images, func(i, j int) bool {
+		if images[i].Dependency != images[j].Dependency {
+			return images[i].Dependency < images[j].Dependency
+		}
+
+		return images[i].ImageConfigName < images[j].ImageConfigName
+	})
+
+	return images
+}
+
+// Save writes the report as json to the given path
+func (r *Report) Save(path string) error {
+	out, err := json.MarshalIndent(struct {
+		Images []*ImageReport `json:"images"`
+	}{
+		Images: r.Images(),
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal build report")
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, out, 0666)
+}
@@ -0,0 +1,52 @@
+package build
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	report := NewReport()
+	report.AddBuilt("root", "myuser/root", []string{"abc", "latest"}, "docker", "sha256:123", 2*time.Second)
+	report.WithDependency("dep1").AddSkipped("backend", "myuser/backend", []string{"def"}, "kaniko")
+
+	var nilReport *Report
+	nilReport.AddBuilt("ignored", "ignored", nil, "", "", 0)
+
+	reportPath := filepath.Join(dir, "out", "report.json")
+	err = report.Save(reportPath)
+	assert.NilError(t, err, "Error saving report")
+
+	out, err := ioutil.ReadFile(reportPath)
+	assert.NilError(t, err, "Error reading report")
+
+	parsed := struct {
+		Images []*ImageReport `json:"images"`
+	}{}
+	err = json.Unmarshal(out, &parsed)
+	assert.NilError(t, err, "Error parsing report")
+	assert.Equal(t, len(parsed.Images), 2)
+
+	assert.Equal(t, parsed.Images[0].ImageConfigName, "root")
+	assert.Equal(t, parsed.Images[0].Dependency, "")
+	assert.Equal(t, parsed.Images[0].Digest, "sha256:123")
+	assert.Equal(t, parsed.Images[0].Duration, float64(2))
+	assert.Equal(t, parsed.Images[0].Skipped, false)
+
+	assert.Equal(t, parsed.Images[1].ImageConfigName, "backend")
+	assert.Equal(t, parsed.Images[1].Dependency, "dep1")
+	assert.Equal(t, parsed.Images[1].Builder, "kaniko")
+	assert.Equal(t, parsed.Images[1].Skipped, true)
+}
@@ -20,6 +20,14 @@ type Config interface {
 	// loading the config
 	Variables() map[string]interface{}
 
+	// VariableSources returns where the resolved variables were
+	// loaded from, e.g. env, cache, file .env or secret my-secret
+	VariableSources() map[string]string
+
+	// Overlays returns the paths of the overlay files, e.g. devspace.local.yaml,
+	// that were applied after the profiles
+	Overlays() []string
+
 	// Path returns the absolute path from which the config was loaded
 	Path() string
 }
@@ -34,11 +42,39 @@ func NewConfig(raw map[interface{}]interface{}, parsed *latest.Config, generated
 	}
 }
 
+// WithVariableSources returns a copy of the config that returns the given sources of the resolved variables
+func WithVariableSources(c Config, variableSources map[string]string) Config {
+	return &config{
+		rawConfig:         c.Raw(),
+		parsedConfig:      c.Config(),
+		generatedConfig:   c.Generated(),
+		resolvedVariables: c.Variables(),
+		variableSources:   variableSources,
+		overlays:          c.Overlays(),
+		path:              c.Path(),
+	}
+}
+
+// WithOverlays returns a copy of the config that returns the given paths of the applied overlay files
+func WithOverlays(c Config, overlays []string) Config {
+	return &config{
+		rawConfig:         c.Raw(),
+		parsedConfig:      c.Config(),
+		generatedConfig:   c.Generated(),
+		resolvedVariables: c.Variables(),
+		variableSources:   c.VariableSources(),
+		overlays:          overlays,
+		path:              c.Path(),
+	}
+}
+
 type config struct {
 	rawConfig         map[interface{}]interface{}
 	parsedConfig      *latest.Config
 	generatedConfig   *generated.Config
 	resolvedVariables map[string]interface{}
+	variableSources   map[string]string
+	overlays          []string
 	path              string
 }
 
@@ -58,6 +94,14 @@ func (c *config) Variables() map[string]interface{} {
 	return c.resolvedVariables
 }
 
+func (c *config) VariableSources() map[string]string {
+	return c.variableSources
+}
+
+func (c *config) Overlays() []string {
+	return c.overlays
+}
+
 func (c *config) Path() string {
 	return c.path
 }
@@ -79,6 +123,12 @@ func Ensure(config Config) Config {
 	if retConfig.Variables() == nil {
 		retConfig = NewConfig(retConfig.Raw(), retConfig.Config(), retConfig.Generated(), map[string]interface{}{}, retConfig.Path())
 	}
+	if config != nil && retConfig != config && len(config.VariableSources()) > 0 {
+		retConfig = WithVariableSources(retConfig, config.VariableSources())
+	}
+	if config != nil && retConfig != config && len(config.Overlays()) > 0 {
+		retConfig = WithOverlays(retConfig, config.Overlays())
+	}
 
 	return retConfig
 }
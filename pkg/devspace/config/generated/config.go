@@ -9,6 +9,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/util/encryption"
+	"github.com/mitchellh/go-homedir"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -17,10 +18,23 @@ const (
 	DEVSPACE_DISABLE_VARS_ENCRYPTION_ENV = "DEVSPACE_DISABLE_VARS_ENCRYPTION"
 )
 
-// EncryptionKey is the key to encrypt generated variables with. This will be compiled into the binary during the pipeline.
-// If empty DevSpace will not encrypt / decrypt the variables.
+// VarsKeyUser marks variables that are encrypted with the key of the current user
+const VarsKeyUser = "user"
+
+// EncryptionKey is the legacy key to encrypt generated variables with. This will be compiled into the binary during the pipeline.
+// It is only used if the key of the current user cannot be loaded. If both are unavailable DevSpace will not encrypt / decrypt the variables.
 var EncryptionKey string
 
+// UserKeyPath returns the path of the key that encrypts the generated variables of the current user
+var UserKeyPath = func() (string, error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, constants.DefaultHomeDevSpaceFolder, "vars.key"), nil
+}
+
 // ConfigLoader is the interface for loading the generated config
 type ConfigLoader interface {
 	Load() (*Config, error)
@@ -91,6 +105,12 @@ func (l *configLoader) loadFromPath(path string) (*Config, error) {
 
 	// Decrypt vars if necessary
 	if loadedConfig.VarsEncrypted {
+		key := []byte(EncryptionKey)
+		if loadedConfig.VarsKey == VarsKeyUser {
+			// if the key of the user cannot be loaded, the variables cannot be decrypted and are asked again
+			key, _ = userKey()
+		}
+
 		for k, v := range loadedConfig.Vars {
 			if len(v) == 0 {
 				continue
@@ -102,7 +122,7 @@ func (l *configLoader) loadFromPath(path string) (*Config, error) {
 				continue
 			}
 
-			decrypted, err := encryption.DecryptAES([]byte(EncryptionKey), decoded)
+			decrypted, err := encryption.DecryptAES(key, decoded)
 			if err != nil {
 				// we cannot decrypt the variable, so we will ask the user again
 				delete(loadedConfig.Vars, k)
@@ -113,6 +133,7 @@ func (l *configLoader) loadFromPath(path string) (*Config, error) {
 		}
 
 		loadedConfig.VarsEncrypted = false
+		loadedConfig.VarsKey = ""
 	}
 
 	return loadedConfig, nil
@@ -132,13 +153,14 @@ func (l *configLoader) Save(config *Config) error {
 	}
 
 	// encrypt variables
-	if os.Getenv(DEVSPACE_DISABLE_VARS_ENCRYPTION_ENV) != "true" && EncryptionKey != "" {
+	key, keyName := encryptionKey()
+	if os.Getenv(DEVSPACE_DISABLE_VARS_ENCRYPTION_ENV) != "true" && key != nil {
 		for k, v := range copiedConfig.Vars {
 			if len(v) == 0 {
 				continue
 			}
 
-			encrypted, err := encryption.EncryptAES([]byte(EncryptionKey), []byte(v))
+			encrypted, err := encryption.EncryptAES(key, []byte(v))
 			if err != nil {
 				return err
 			}
@@ -147,6 +169,7 @@ func (l *configLoader) Save(config *Config) error {
 		}
 
 		copiedConfig.VarsEncrypted = true
+		copiedConfig.VarsKey = keyName
 	}
 
 	// marshal again with the encrypted vars
@@ -163,6 +186,27 @@ func (l *configLoader) Save(config *Config) error {
 	return ioutil.WriteFile(l.configPath, data, 0666)
 }
 
+// encryptionKey returns the key of the current user or the legacy key if the key of the user is not available
+func encryptionKey() ([]byte, string) {
+	key, err := userKey()
+	if err == nil {
+		return key, VarsKeyUser
+	} else if EncryptionKey != "" {
+		return []byte(EncryptionKey), ""
+	}
+
+	return nil, ""
+}
+
+func userKey() ([]byte, error) {
+	keyPath, err := UserKeyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.LoadOrCreateKey(keyPath)
+}
+
 // NewCache returns a new cache object
 func NewCache() *CacheConfig {
 	return &CacheConfig{
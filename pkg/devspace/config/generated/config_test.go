@@ -3,6 +3,8 @@ package generated
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
@@ -178,6 +180,9 @@ func TestSave(t *testing.T) {
 		}
 	}()
 
+	os.Setenv(DEVSPACE_DISABLE_VARS_ENCRYPTION_ENV, "true")
+	defer os.Unsetenv(DEVSPACE_DISABLE_VARS_ENCRYPTION_ENV)
+
 	for _, testCase := range testCases {
 		var loader ConfigLoader
 		if testCase.devspaceConfigPath != "" {
@@ -202,6 +207,42 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestSaveEncrypted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	userKeyPathBackup := UserKeyPath
+	UserKeyPath = func() (string, error) {
+		return filepath.Join(dir, "home", "vars.key"), nil
+	}
+	defer func() { UserKeyPath = userKeyPathBackup }()
+
+	loader := NewConfigLoaderFromDevSpacePath("", filepath.Join(dir, "devspace.yaml"))
+	err = loader.Save(&Config{
+		Vars: map[string]string{
+			"key": "value",
+		},
+	})
+	assert.NilError(t, err)
+
+	fileContent, err := ioutil.ReadFile(filepath.Join(dir, ".devspace", "generated.yaml"))
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(fileContent), "value"), "variable was saved unencrypted")
+	assert.Assert(t, strings.Contains(string(fileContent), "varsKey: user"), "variables are not encrypted with the user key")
+
+	loaded, err := loader.Load()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded.Vars, map[string]string{"key": "value"})
+
+	// variables encrypted with another key are asked again
+	err = os.Remove(filepath.Join(dir, "home", "vars.key"))
+	assert.NilError(t, err)
+	loaded, err = loader.Load()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded.Vars, map[string]string{})
+}
+
 type getActiveTestCase struct {
 	name string
 
@@ -8,6 +8,7 @@ type Config struct {
 	ActiveProfile   string                  `yaml:"activeProfile,omitempty"`
 	Vars            map[string]string       `yaml:"vars,omitempty"`
 	VarsEncrypted   bool                    `yaml:"varsEncrypted,omitempty"`
+	VarsKey         string                  `yaml:"varsKey,omitempty"`
 	Profiles        map[string]*CacheConfig `yaml:"profiles,omitempty"`
 }
 
@@ -0,0 +1,58 @@
+package loader
+
+import (
+	"path/filepath"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
+	"github.com/loft-sh/devspace/pkg/util/log"
+)
+
+// ProfileActivations returns the profiles of the loaded config that are activated automatically by their
+// activation rules together with the reason why they are activated
+func ProfileActivations(c config.Config, options *ConfigOptions, log log.Logger) ([]*versions.ActivatedProfile, error) {
+	if options == nil {
+		options = &ConfigOptions{}
+	}
+
+	data, err := copyRaw(c.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := variable.NewResolver(c.Generated().Vars, &variable.PredefinedVariableOptions{
+		BasePath:         options.BasePath,
+		ConfigPath:       c.Path(),
+		KubeContextFlag:  options.KubeContext,
+		NamespaceFlag:    options.Namespace,
+		KubeConfigLoader: kubeconfig.NewLoader(),
+		KubeClient:       options.KubeClient,
+	}, log)
+
+	return versions.ActivatedProfiles(filepath.Dir(c.Path()), data, activationLookup(data, options.Vars, resolver, log))
+}
+
+// activationLookup returns the value of the variables used in profile activations. Variables are parsed
+// from the config before any profile is applied and --var flags take precedence over their definitions
+func activationLookup(data map[interface{}]interface{}, flags []string, resolver variable.Resolver, log log.Logger) versions.LookupFn {
+	var lookup versions.LookupFn
+	return func(name string) (interface{}, error) {
+		if lookup == nil {
+			_, err := resolver.ConvertFlags(flags)
+			if err != nil {
+				return nil, err
+			}
+
+			vars, err := versions.ParseVariables(data, log)
+			if err != nil {
+				return nil, err
+			}
+
+			lookup = versions.LookupFn(conditionLookup(vars, resolver))
+		}
+
+		return lookup(name)
+	}
+}
@@ -0,0 +1,160 @@
+package loader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/expression"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"github.com/pkg/errors"
+)
+
+// conditionalListSections are the lists whose entries are removed if their if expression is false
+var conditionalListSections = [][]string{
+	{"deployments"},
+	{"hooks"},
+	{"commands"},
+	{"dev", "sync"},
+	{"dev", "ports"},
+}
+
+// conditionalMapSections are the objects whose entries are removed if their if expression is false
+var conditionalMapSections = [][]string{
+	{"images"},
+}
+
+// applyConditions evaluates the if expressions of the conditional sections and removes the entries whose
+// expression is false. Variables in expressions are resolved with the resolver
+func applyConditions(preparedConfig map[interface{}]interface{}, vars []*latest.Variable, resolver variable.Resolver) error {
+	lookup := conditionLookup(vars, resolver)
+	for _, path := range conditionalListSections {
+		parent, ok := sectionParent(preparedConfig, path)
+		if !ok {
+			continue
+		}
+
+		entries, ok := parent[path[len(path)-1]].([]interface{})
+		if !ok {
+			continue
+		}
+
+		keep := []interface{}{}
+		for i, entry := range entries {
+			enabled, err := evaluateCondition(entry, lookup)
+			if err != nil {
+				return errors.Wrapf(err, "%s[%d].if", strings.Join(path, "."), i)
+			} else if enabled {
+				keep = append(keep, entry)
+			}
+		}
+
+		parent[path[len(path)-1]] = keep
+	}
+
+	for _, path := range conditionalMapSections {
+		parent, ok := sectionParent(preparedConfig, path)
+		if !ok {
+			continue
+		}
+
+		entries, ok := parent[path[len(path)-1]].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		// evaluate in a stable order, since evaluating may ask for variables
+		keys := []string{}
+		for key := range entries {
+			keys = append(keys, fmt.Sprint(key))
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			enabled, err := evaluateCondition(entries[key], lookup)
+			if err != nil {
+				return errors.Wrapf(err, "%s.%s.if", strings.Join(path, "."), key)
+			} else if !enabled {
+				delete(entries, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// evaluateCondition evaluates the if expression of the entry and removes it from the entry
+func evaluateCondition(entry interface{}, lookup expression.LookupFn) (bool, error) {
+	entryMap, ok := entry.(map[interface{}]interface{})
+	if !ok {
+		return true, nil
+	}
+
+	condition, ok := entryMap["if"]
+	if !ok {
+		return true, nil
+	}
+
+	delete(entryMap, "if")
+	switch typed := condition.(type) {
+	case nil:
+		return true, nil
+	case bool:
+		return typed, nil
+	case string:
+		// variables within the expression are replaced first, e.g. if: ${ENABLED}
+		if varspkg.VarMatchRegex.MatchString(typed) {
+			replaced, err := varspkg.ParseString(typed, func(name string) (interface{}, error) {
+				return lookup(name)
+			})
+			if err != nil {
+				return false, err
+			} else if enabled, ok := replaced.(bool); ok {
+				return enabled, nil
+			}
+
+			typed = fmt.Sprint(replaced)
+		}
+
+		return expression.EvaluateBool(typed, lookup)
+	}
+
+	return false, errors.Errorf("expected an expression or a boolean, but got %v", condition)
+}
+
+// conditionLookup returns the value of the resolved, defined or predefined variable with the given name
+func conditionLookup(vars []*latest.Variable, resolver variable.Resolver) expression.LookupFn {
+	return func(name string) (interface{}, error) {
+		if value, ok := resolver.ResolvedVariables()[name]; ok {
+			return value, nil
+		}
+
+		for _, definition := range vars {
+			if strings.TrimSpace(definition.Name) == name {
+				return resolver.Resolve(name, definition)
+			}
+		}
+
+		if variable.IsPredefinedVariable(name) {
+			return resolver.Resolve(name, nil)
+		}
+
+		return nil, errors.Errorf("variable %s is not defined, please define it in the vars section or use env(\"%s\") for environment variables", name, name)
+	}
+}
+
+func sectionParent(config map[interface{}]interface{}, path []string) (map[interface{}]interface{}, bool) {
+	parent := config
+	for _, key := range path[:len(path)-1] {
+		child, ok := parent[key].(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		parent = child
+	}
+
+	return parent, true
+}
@@ -0,0 +1,553 @@
+package expression
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LookupFn returns the value of the variable with the given name
+type LookupFn func(name string) (interface{}, error)
+
+// EvaluateBool evaluates the expression and returns an error if the result is not a boolean
+func EvaluateBool(expression string, lookup LookupFn) (bool, error) {
+	value, err := Evaluate(expression, lookup)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("expression '%s' returned %v instead of a boolean", expression, value)
+	}
+
+	return result, nil
+}
+
+// Evaluate evaluates an expression of the built-in expression language without starting any processes.
+// Expressions support string, number, boolean and null literals, variables, comparisons (==, !=, <, <=,
+// >, >=), boolean logic (&&, ||, !), parentheses and the functions in the functions map, e.g.
+// DEVSPACE_PROFILE == "production" && !contains(DEVSPACE_CONTEXT, "minikube")
+func Evaluate(expression string, lookup LookupFn) (interface{}, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse expression '%s'", expression)
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse expression '%s'", expression)
+	} else if p.peek().kind != tokenEOF {
+		return nil, errors.Errorf("parse expression '%s': unexpected %s", expression, p.peek().value)
+	}
+
+	value, err := node.eval(lookup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "evaluate expression '%s'", expression)
+	}
+
+	return value, nil
+}
+
+// functions are the functions that can be called within expressions
+var functions = map[string]func(args []interface{}) (interface{}, error){
+	"contains": stringFunction2(func(s, substr string) (interface{}, error) {
+		return strings.Contains(s, substr), nil
+	}),
+	"startsWith": stringFunction2(func(s, prefix string) (interface{}, error) {
+		return strings.HasPrefix(s, prefix), nil
+	}),
+	"endsWith": stringFunction2(func(s, suffix string) (interface{}, error) {
+		return strings.HasSuffix(s, suffix), nil
+	}),
+	"matches": stringFunction2(func(s, pattern string) (interface{}, error) {
+		return regexp.MatchString(pattern, s)
+	}),
+	"lower": stringFunction1(func(s string) (interface{}, error) {
+		return strings.ToLower(s), nil
+	}),
+	"upper": stringFunction1(func(s string) (interface{}, error) {
+		return strings.ToUpper(s), nil
+	}),
+	"trim": stringFunction1(func(s string) (interface{}, error) {
+		return strings.TrimSpace(s), nil
+	}),
+	"len": stringFunction1(func(s string) (interface{}, error) {
+		return len(s), nil
+	}),
+	"env": stringFunction1(func(name string) (interface{}, error) {
+		return os.Getenv(name), nil
+	}),
+}
+
+func stringFunction1(fn func(string) (interface{}, error)) func(args []interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errors.Errorf("expected 1 argument, got %d", len(args))
+		}
+
+		return fn(toString(args[0]))
+	}
+}
+
+func stringFunction2(fn func(string, string) (interface{}, error)) func(args []interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, errors.Errorf("expected 2 arguments, got %d", len(args))
+		}
+
+		return fn(toString(args[0]), toString(args[1]))
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", ","}
+
+func tokenize(expression string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			str := strings.Builder{}
+			j := i + 1
+			for ; j < len(expression) && expression[j] != c; j++ {
+				if expression[j] == '\\' && j+1 < len(expression) {
+					j++
+				}
+				str.WriteByte(expression[j])
+			}
+			if j >= len(expression) {
+				return nil, errors.Errorf("unterminated string at position %d", i)
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: str.String()})
+			i = j + 1
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(expression) && (expression[j] >= '0' && expression[j] <= '9' || expression[j] == '.') {
+				j++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, value: expression[i:j]})
+			i = j
+		case isIdentStart(c):
+			j := i
+			for j < len(expression) && isIdentPart(expression[j]) {
+				j++
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, value: expression[i:j]})
+			i = j
+		default:
+			found := false
+			for _, operator := range operators {
+				if strings.HasPrefix(expression[i:], operator) {
+					tokens = append(tokens, token{kind: tokenOperator, value: operator})
+					i += len(operator)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, errors.Errorf("unexpected character '%c' at position %d", c, i)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, value: "end of expression"}), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9') || c == '-' || c == '.'
+}
+
+type node interface {
+	eval(lookup LookupFn) (interface{}, error)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) acceptOperator(operators ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+
+	for _, operator := range operators {
+		if t.value == operator {
+			p.pos++
+			return operator, true
+		}
+	}
+
+	return "", false
+}
+
+func (p *parser) expectOperator(operator string) error {
+	if _, ok := p.acceptOperator(operator); !ok {
+		return errors.Errorf("expected %s, but got %s", operator, p.peek().value)
+	}
+
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("||"); !ok {
+			return left, nil
+		}
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalNode{or: true, left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("&&"); !ok {
+			return left, nil
+		}
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalNode{left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.acceptOperator("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	operator, ok := p.acceptOperator("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &comparisonNode{operator: operator, left: left, right: right}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenNumber:
+		if i, err := strconv.Atoi(t.value); err == nil {
+			return &literalNode{value: i}, nil
+		}
+
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid number %s", t.value)
+		}
+
+		return &literalNode{value: f}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+
+		if _, ok := p.acceptOperator("("); !ok {
+			return &variableNode{name: t.value}, nil
+		}
+
+		fn, ok := functions[t.value]
+		if !ok {
+			return nil, errors.Errorf("unknown function %s", t.value)
+		}
+
+		args := []node{}
+		if _, ok := p.acceptOperator(")"); ok {
+			return &functionNode{name: t.value, fn: fn, args: args}, nil
+		}
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			args = append(args, arg)
+			if _, ok := p.acceptOperator(","); !ok {
+				break
+			}
+		}
+
+		return &functionNode{name: t.value, fn: fn, args: args}, p.expectOperator(")")
+	case tokenOperator:
+		if t.value == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			return inner, p.expectOperator(")")
+		}
+	}
+
+	return nil, errors.Errorf("unexpected %s", t.value)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(lookup LookupFn) (interface{}, error) {
+	return n.value, nil
+}
+
+type variableNode struct {
+	name string
+}
+
+func (n *variableNode) eval(lookup LookupFn) (interface{}, error) {
+	return lookup(n.name)
+}
+
+type functionNode struct {
+	name string
+	fn   func(args []interface{}) (interface{}, error)
+	args []node
+}
+
+func (n *functionNode) eval(lookup LookupFn) (interface{}, error) {
+	args := []interface{}{}
+	for _, arg := range n.args {
+		value, err := arg.eval(lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, value)
+	}
+
+	value, err := n.fn(args)
+	if err != nil {
+		return nil, errors.Wrap(err, n.name)
+	}
+
+	return value, nil
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(lookup LookupFn) (interface{}, error) {
+	value, err := n.operand.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	return !truthy(value), nil
+}
+
+type logicalNode struct {
+	or          bool
+	left, right node
+}
+
+func (n *logicalNode) eval(lookup LookupFn) (interface{}, error) {
+	left, err := n.left.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	// the right side is only evaluated if needed
+	if truthy(left) == n.or {
+		return n.or, nil
+	}
+
+	right, err := n.right.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	return truthy(right), nil
+}
+
+type comparisonNode struct {
+	operator    string
+	left, right node
+}
+
+func (n *comparisonNode) eval(lookup LookupFn) (interface{}, error) {
+	left, err := n.left.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are compared by value, everything else by its string representation. Strings that contain a
+	// number are converted if they are compared with a number or ordered, e.g. env("REPLICAS") > 2
+	var result int
+	ordered := n.operator != "==" && n.operator != "!="
+	leftNumber, leftOk := toNumber(left)
+	rightNumber, rightOk := toNumber(right)
+	if leftOk != rightOk || (ordered && !leftOk) {
+		leftNumber, leftOk = parseNumber(left)
+		rightNumber, rightOk = parseNumber(right)
+	}
+
+	if leftOk && rightOk {
+		if leftNumber < rightNumber {
+			result = -1
+		} else if leftNumber > rightNumber {
+			result = 1
+		}
+	} else if ordered && (leftOk || rightOk) {
+		return nil, errors.Errorf("cannot compare %q and %q with %s, because only one of them is a number", toString(left), toString(right), n.operator)
+	} else {
+		result = strings.Compare(toString(left), toString(right))
+	}
+
+	switch n.operator {
+	case "==":
+		return result == 0, nil
+	case "!=":
+		return result != 0, nil
+	case "<":
+		return result < 0, nil
+	case "<=":
+		return result <= 0, nil
+	case ">":
+		return result > 0, nil
+	default:
+		return result >= 0, nil
+	}
+}
+
+func truthy(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return typed
+	case string:
+		return typed != ""
+	}
+
+	if number, ok := toNumber(value); ok {
+		return number != 0
+	}
+
+	return true
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case int:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	}
+
+	return 0, false
+}
+
+// parseNumber returns the value as number if it is a number or a string that contains a number
+func parseNumber(value interface{}) (float64, bool) {
+	if str, ok := value.(string); ok {
+		number, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+		return number, err == nil
+	}
+
+	return toNumber(value)
+}
+
+func toString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,90 @@
+package expression
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+type evaluateTestCase struct {
+	expression string
+
+	expected    interface{}
+	expectedErr string
+}
+
+func TestEvaluate(t *testing.T) {
+	os.Setenv("EVALUATE_TEST_ENV", "set")
+	defer os.Unsetenv("EVALUATE_TEST_ENV")
+	os.Setenv("EVALUATE_TEST_REPLICAS", "10")
+	defer os.Unsetenv("EVALUATE_TEST_REPLICAS")
+
+	variables := map[string]interface{}{
+		"DEVSPACE_PROFILE": "production",
+		"DEVSPACE_CONTEXT": "minikube",
+		"REPLICAS":         3,
+		"DEBUG":            false,
+		"my-var.name":      "value",
+	}
+	lookup := func(name string) (interface{}, error) {
+		value, ok := variables[name]
+		if !ok {
+			return nil, errors.Errorf("variable %s is not defined", name)
+		}
+
+		return value, nil
+	}
+
+	testCases := []evaluateTestCase{
+		{expression: `DEVSPACE_PROFILE == "production"`, expected: true},
+		{expression: `DEVSPACE_PROFILE != 'production'`, expected: false},
+		{expression: `REPLICAS > 2 && REPLICAS <= 3`, expected: true},
+		{expression: `REPLICAS == "3"`, expected: true},
+		{expression: `REPLICAS < 10`, expected: true},
+		{expression: `env("EVALUATE_TEST_REPLICAS") > 9`, expected: true},
+		{expression: `env("EVALUATE_TEST_REPLICAS") > "9"`, expected: true},
+		{expression: `"b" > "a"`, expected: true},
+		{expression: `DEVSPACE_PROFILE == 1`, expected: false},
+		{expression: `DEVSPACE_PROFILE > 1`, expectedErr: "evaluate expression 'DEVSPACE_PROFILE > 1': cannot compare \"production\" and \"1\" with >, because only one of them is a number"},
+		{expression: `!DEBUG`, expected: true},
+		{expression: `DEBUG || contains(DEVSPACE_CONTEXT, "mini")`, expected: true},
+		{expression: `!(DEBUG || startsWith(DEVSPACE_CONTEXT, "kind"))`, expected: true},
+		{expression: `endsWith(upper(DEVSPACE_PROFILE), "TION") && lower("ABC") == "abc"`, expected: true},
+		{expression: `matches(DEVSPACE_CONTEXT, "^mini.*$")`, expected: true},
+		{expression: `len(trim("  abc  "))`, expected: 3},
+		{expression: `env("EVALUATE_TEST_ENV") == "set" && env("EVALUATE_TEST_MISSING") == ""`, expected: true},
+		{expression: `my-var.name`, expected: "value"},
+		{expression: `null == ""`, expected: true},
+		{expression: `"a \"quoted\" string"`, expected: `a "quoted" string`},
+		{expression: `DEBUG && UNDEFINED`, expected: false},
+		{expression: `UNDEFINED`, expectedErr: "evaluate expression 'UNDEFINED': variable UNDEFINED is not defined"},
+		{expression: `unknown(1)`, expectedErr: "parse expression 'unknown(1)': unknown function unknown"},
+		{expression: `contains("a")`, expectedErr: "evaluate expression 'contains(\"a\")': contains: expected 2 arguments, got 1"},
+		{expression: `(true`, expectedErr: "parse expression '(true': expected ), but got end of expression"},
+		{expression: `true true`, expectedErr: "parse expression 'true true': unexpected true"},
+		{expression: `"open`, expectedErr: "parse expression '\"open': unterminated string at position 0"},
+		{expression: `a = b`, expectedErr: "parse expression 'a = b': unexpected character '=' at position 2"},
+	}
+
+	for _, testCase := range testCases {
+		value, err := Evaluate(testCase.expression, lookup)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in expression %s", testCase.expression)
+			continue
+		}
+
+		assert.NilError(t, err, "Error in expression %s", testCase.expression)
+		assert.DeepEqual(t, value, testCase.expected)
+	}
+}
+
+func TestEvaluateBool(t *testing.T) {
+	_, err := EvaluateBool(`"production"`, nil)
+	assert.Error(t, err, "expression '\"production\"' returned production instead of a boolean")
+
+	result, err := EvaluateBool(`1 < 2`, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, true)
+}
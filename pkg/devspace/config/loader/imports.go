@@ -0,0 +1,419 @@
+package loader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// namedImportSections are lists whose entries are merged by the given key. Entries of the importing
+// config replace imported entries with the same key
+var namedImportSections = map[string]string{
+	"vars":         "name",
+	"pullSecrets":  "registry",
+	"deployments":  "name",
+	"commands":     "name",
+	"dependencies": "name",
+	"profiles":     "name",
+}
+
+// appendImportSections are lists where the entries of the importing config are appended to the imported ones
+var appendImportSections = map[string]bool{
+	"hooks": true,
+}
+
+// mergeImportSections are objects whose keys are merged. Keys of the importing config replace imported keys
+var mergeImportSections = map[string]bool{
+	"require": true,
+	"images":  true,
+	"dev":     true,
+}
+
+type importResolver struct {
+	rootDir string
+	update  bool
+	log     log.Logger
+
+	// tracker records the positions of imported values if set
+	tracker *provenance.Tracker
+
+	imported map[string]bool
+}
+
+// resolveImports merges the imported configs into the config. Imports of imported configs are resolved as well
+func resolveImports(configPath string, data map[interface{}]interface{}, update bool, tracker *provenance.Tracker, log log.Logger) (map[interface{}]interface{}, error) {
+	if data["imports"] == nil {
+		return data, nil
+	} else if data["version"] != latest.Version {
+		return nil, errors.Errorf("imports are only supported with config version %s", latest.Version)
+	}
+
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &importResolver{
+		rootDir:  filepath.Dir(absPath),
+		update:   update,
+		log:      log,
+		tracker:  tracker,
+		imported: map[string]bool{absPath: true},
+	}
+	return resolver.resolve(absPath, data, []string{absPath})
+}
+
+func (r *importResolver) resolve(configPath string, data map[interface{}]interface{}, chain []string) (map[interface{}]interface{}, error) {
+	imports, err := parseImports(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse imports of %s", configPath)
+	}
+
+	delete(data, "imports")
+	merged := map[interface{}]interface{}{}
+	for i, importConfig := range imports {
+		if importConfig.Source == nil {
+			return nil, errors.Errorf("imports[%d].source is required in %s", i, configPath)
+		}
+
+		importPath, err := r.download(filepath.Dir(configPath), importConfig.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "imports[%d] of %s", i, configPath)
+		}
+
+		for _, path := range chain {
+			if path == importPath {
+				cycle := []string{}
+				for _, path := range append(chain, importPath) {
+					cycle = append(cycle, r.relativePath(path))
+				}
+
+				return nil, errors.Errorf("import cycle detected: %s", strings.Join(cycle, " -> "))
+			}
+		}
+
+		// every config is only imported once, even if it is imported by multiple configs
+		if r.imported[importPath] {
+			continue
+		}
+		r.imported[importPath] = true
+
+		importedData, err := loadImport(importPath, data["version"])
+		if err != nil {
+			return nil, err
+		}
+
+		// paths are rebased before resolving the nested imports, because these are rebased already
+		r.rebasePaths(importedData, filepath.Dir(importPath))
+
+		importedData, err = r.resolve(importPath, importedData, append(chain, importPath))
+		if err != nil {
+			return nil, err
+		}
+
+		// imported configs are recorded after their own imports, so that their values take precedence
+		err = r.recordFile(importPath)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = mergeImport(merged, importedData)
+	}
+
+	return mergeImport(merged, data), nil
+}
+
+// recordFile records the positions of the values of the given file if provenance is tracked
+func (r *importResolver) recordFile(path string) error {
+	if r.tracker == nil {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return r.tracker.RecordFile(r.relativePath(path), content)
+}
+
+// relativePath returns the path relative to the directory of the root config if possible
+func (r *importResolver) relativePath(path string) string {
+	relPath, err := filepath.Rel(r.rootDir, path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return path
+	}
+
+	return filepath.ToSlash(relPath)
+}
+
+// download returns the path of the imported config file and downloads the source if necessary
+func (r *importResolver) download(basePath string, source *latest.SourceConfig) (string, error) {
+	ID := dependencyutil.GetParentProfileID(basePath, source, "", nil)
+	if ID == "" {
+		return "", errors.New("either source.git or source.path is required")
+	}
+
+	localPath, err := dependencyutil.DownloadDependency(ID, basePath, source, r.update, r.log)
+	if err != nil {
+		return "", err
+	}
+
+	// configs from urls are always downloaded to a devspace.yaml
+	isURL := strings.HasPrefix(source.Path, "http://") || strings.HasPrefix(source.Path, "https://")
+	stat, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	} else if !stat.IsDir() {
+		return localPath, nil
+	}
+
+	if source.ConfigName != "" && !isURL {
+		return filepath.Join(localPath, source.ConfigName), nil
+	}
+
+	return filepath.Join(localPath, constants.DefaultConfigPath), nil
+}
+
+// rebasePaths rewrites the relative paths of the imported config, so that they are relative to the root config
+// instead of the imported config. Profiles, commands and paths containing variables are not rebased
+func (r *importResolver) rebasePaths(data map[interface{}]interface{}, importDir string) {
+	if importDir == r.rootDir {
+		return
+	}
+
+	rebase := func(obj interface{}, keys ...string) {
+		objMap, ok := obj.(map[interface{}]interface{})
+		if !ok {
+			return
+		}
+
+		for _, key := range keys {
+			switch value := objMap[key].(type) {
+			case string:
+				objMap[key] = r.rebasePath(importDir, value)
+			case []interface{}:
+				for i, entry := range value {
+					if path, ok := entry.(string); ok {
+						value[i] = r.rebasePath(importDir, path)
+					}
+				}
+			}
+		}
+	}
+
+	if images, ok := data["images"].(map[interface{}]interface{}); ok {
+		for _, image := range images {
+			rebase(image, "dockerfile", "context")
+		}
+	}
+	for _, deployment := range listOf(data["deployments"]) {
+		deploymentMap, _ := deployment.(map[interface{}]interface{})
+		if helm, ok := deploymentMap["helm"].(map[interface{}]interface{}); ok {
+			rebase(helm, "valuesFiles")
+
+			// chart names are only paths if the chart exists locally
+			if chart, ok := helm["chart"].(map[interface{}]interface{}); ok {
+				if name, ok := chart["name"].(string); ok && name != "" && !filepath.IsAbs(name) {
+					if _, err := os.Stat(filepath.Join(importDir, name)); err == nil {
+						rebase(chart, "name")
+					}
+				}
+			}
+		}
+		rebase(deploymentMap["kubectl"], "manifests")
+	}
+	if dev, ok := data["dev"].(map[interface{}]interface{}); ok {
+		for _, sync := range listOf(dev["sync"]) {
+			rebase(sync, "localSubPath", "excludeFile", "downloadExcludeFile", "uploadExcludeFile")
+		}
+	}
+	for _, dependency := range listOf(data["dependencies"]) {
+		dependencyMap, _ := dependency.(map[interface{}]interface{})
+		rebase(dependencyMap["source"], "path")
+	}
+	for _, variable := range listOf(data["vars"]) {
+		rebase(variable, "files")
+	}
+	for _, hook := range listOf(data["hooks"]) {
+		hookMap, _ := hook.(map[interface{}]interface{})
+		rebase(hookMap["upload"], "localPath")
+		rebase(hookMap["download"], "localPath")
+	}
+}
+
+// rebasePath returns the path relative to the root config. Empty and absolute paths, urls and
+// paths with variables are returned unchanged
+func (r *importResolver) rebasePath(importDir, path string) string {
+	if path == "" || filepath.IsAbs(path) || strings.Contains(path, "$") || strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+
+	absPath := filepath.Join(importDir, filepath.FromSlash(path))
+	relPath, err := filepath.Rel(r.rootDir, absPath)
+	if err != nil {
+		return absPath
+	}
+
+	return filepath.ToSlash(relPath)
+}
+
+func listOf(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+func parseImports(data map[interface{}]interface{}) ([]*latest.ImportConfig, error) {
+	if data["imports"] == nil {
+		return nil, nil
+	}
+
+	out, err := yaml.Marshal(data["imports"])
+	if err != nil {
+		return nil, err
+	}
+
+	imports := []*latest.ImportConfig{}
+	err = yaml.UnmarshalStrict(out, &imports)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// loadImport reads the imported config and validates it against the json schema. Imported configs
+// may omit the version, but if they specify one it has to match the version of the importing config
+func loadImport(path string, version interface{}) (map[interface{}]interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read imported config")
+	}
+
+	// encrypted values are decrypted after the config was parsed
+	content, err = secrets.Placeholders(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse imported config %s", path)
+	}
+
+	data := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(content, &data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse imported config %s", path)
+	}
+
+	if data["version"] == nil {
+		data["version"] = version
+	} else if data["version"] != version {
+		return nil, fmt.Errorf("imported config %s has version %v, but the importing config has version %v", path, data["version"], version)
+	}
+
+	err = validateSchemaFile(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// mergeImport merges the config into the imported config
+func mergeImport(imported map[interface{}]interface{}, config map[interface{}]interface{}) map[interface{}]interface{} {
+	merged := map[interface{}]interface{}{}
+	for key, value := range imported {
+		merged[key] = value
+	}
+
+	for key, value := range config {
+		section, _ := key.(string)
+		if mergeKey, ok := namedImportSections[section]; ok {
+			merged[key] = mergeNamedList(merged[key], value, mergeKey)
+		} else if appendImportSections[section] {
+			merged[key] = appendList(merged[key], value)
+		} else if mergeImportSections[section] {
+			merged[key] = mergeObject(merged[key], value)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+// mergeNamedList replaces the imported entries with the entries of the config that have the same key in place
+// and appends the other entries of the config
+func mergeNamedList(imported interface{}, config interface{}, mergeKey string) interface{} {
+	importedList, ok := imported.([]interface{})
+	configList, ok2 := config.([]interface{})
+	if !ok || !ok2 {
+		return config
+	}
+
+	merged := append([]interface{}{}, importedList...)
+	for _, entry := range configList {
+		replaced := false
+		if name := namedEntryKey(entry, mergeKey); name != "" {
+			for i, importedEntry := range merged {
+				if namedEntryKey(importedEntry, mergeKey) == name {
+					merged[i] = entry
+					replaced = true
+					break
+				}
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, entry)
+		}
+	}
+
+	return merged
+}
+
+func namedEntryKey(entry interface{}, mergeKey string) string {
+	entryMap, ok := entry.(map[interface{}]interface{})
+	if !ok {
+		return ""
+	}
+
+	name, _ := entryMap[mergeKey].(string)
+	return name
+}
+
+func appendList(imported interface{}, config interface{}) interface{} {
+	importedList, ok := imported.([]interface{})
+	configList, ok2 := config.([]interface{})
+	if !ok || !ok2 {
+		return config
+	}
+
+	return append(append([]interface{}{}, importedList...), configList...)
+}
+
+func mergeObject(imported interface{}, config interface{}) interface{} {
+	importedMap, ok := imported.(map[interface{}]interface{})
+	configMap, ok2 := config.(map[interface{}]interface{})
+	if !ok || !ok2 {
+		return config
+	}
+
+	merged := map[interface{}]interface{}{}
+	for key, value := range importedMap {
+		merged[key] = value
+	}
+	for key, value := range configMap {
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -0,0 +1,327 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+type importsTestCase struct {
+	name string
+
+	files map[string]string
+
+	expectedConfig string
+	expectedErr    string
+}
+
+func TestResolveImports(t *testing.T) {
+	testCases := []importsTestCase{
+		{
+			name: "merge sections",
+			files: map[string]string{
+				"devspace.yaml": `version: v1beta10
+imports:
+- source:
+    path: shared
+images:
+  app:
+    image: myapp
+deployments:
+- name: db
+  helm:
+    chart:
+      name: postgres
+- name: app
+  kubectl:
+    manifests: [app.yaml]
+hooks:
+- command: echo local
+dev:
+  sync:
+  - imageSelector: myapp
+`,
+				"shared/devspace.yaml": `version: v1beta10
+pullSecrets:
+- registry: registry.example.com
+images:
+  sidecar:
+    image: sidecar
+deployments:
+- name: db
+  helm:
+    chart:
+      name: mysql
+- name: redis
+  helm:
+    chart:
+      name: redis
+hooks:
+- command: echo shared
+dev:
+  ports:
+  - imageSelector: sidecar
+  sync:
+  - imageSelector: sidecar
+`,
+			},
+			expectedConfig: `deployments:
+- helm:
+    chart:
+      name: postgres
+  name: db
+- helm:
+    chart:
+      name: redis
+  name: redis
+- kubectl:
+    manifests:
+    - app.yaml
+  name: app
+dev:
+  ports:
+  - imageSelector: sidecar
+  sync:
+  - imageSelector: myapp
+hooks:
+- command: echo shared
+- command: echo local
+images:
+  app:
+    image: myapp
+  sidecar:
+    image: sidecar
+pullSecrets:
+- registry: registry.example.com
+version: v1beta10
+`,
+		},
+		{
+			name: "nested imports and config names",
+			files: map[string]string{
+				"devspace.yaml": `version: v1beta10
+imports:
+- source:
+    path: shared
+    configName: commands.yaml
+- source:
+    path: shared/base.yaml
+commands:
+- name: build
+  command: make build
+`,
+				"shared/commands.yaml": `imports:
+- source:
+    path: base.yaml
+commands:
+- name: build
+  command: docker build .
+- name: test
+  command: go test ./...
+`,
+				"shared/base.yaml": `commands:
+- name: lint
+  command: golangci-lint run
+`,
+			},
+			expectedConfig: `commands:
+- command: golangci-lint run
+  name: lint
+- command: make build
+  name: build
+- command: go test ./...
+  name: test
+version: v1beta10
+`,
+		},
+		{
+			name: "rebase relative paths",
+			files: map[string]string{
+				"devspace.yaml": `version: v1beta10
+imports:
+- source:
+    path: shared/backend
+`,
+				"shared/backend/chart/Chart.yaml": `name: backend
+`,
+				"shared/backend/devspace.yaml": `images:
+  backend:
+    image: backend
+    dockerfile: ./Dockerfile
+    context: ../
+deployments:
+- name: backend
+  helm:
+    chart:
+      name: ./chart
+    valuesFiles:
+    - values.yaml
+- name: redis
+  helm:
+    chart:
+      name: redis
+- name: config
+  kubectl:
+    manifests:
+    - manifests/
+    - ${MANIFESTS}/config.yaml
+    - /etc/manifests
+    - https://example.com/manifest.yaml
+dev:
+  sync:
+  - imageSelector: backend
+    localSubPath: src
+    excludeFile: .dockerignore
+dependencies:
+- name: api
+  source:
+    path: ../api
+vars:
+- name: TOKEN
+  source: file
+  files:
+  - .env
+hooks:
+- upload:
+    localPath: scripts/
+  command: ./scripts/init.sh
+  where:
+    container:
+      imageSelector: backend
+`,
+			},
+			expectedConfig: `dependencies:
+- name: api
+  source:
+    path: shared/api
+deployments:
+- helm:
+    chart:
+      name: shared/backend/chart
+    valuesFiles:
+    - shared/backend/values.yaml
+  name: backend
+- helm:
+    chart:
+      name: redis
+  name: redis
+- kubectl:
+    manifests:
+    - shared/backend/manifests
+    - ${MANIFESTS}/config.yaml
+    - /etc/manifests
+    - https://example.com/manifest.yaml
+  name: config
+dev:
+  sync:
+  - excludeFile: shared/backend/.dockerignore
+    imageSelector: backend
+    localSubPath: shared/backend/src
+hooks:
+- command: ./scripts/init.sh
+  upload:
+    localPath: shared/backend/scripts
+  where:
+    container:
+      imageSelector: backend
+images:
+  backend:
+    context: shared
+    dockerfile: shared/backend/Dockerfile
+    image: backend
+vars:
+- files:
+  - shared/backend/.env
+  name: TOKEN
+  source: file
+version: v1beta10
+`,
+		},
+		{
+			name: "import cycle",
+			files: map[string]string{
+				"devspace.yaml": `version: v1beta10
+imports:
+- source:
+    path: a.yaml
+`,
+				"a.yaml": `imports:
+- source:
+    path: b.yaml
+`,
+				"b.yaml": `imports:
+- source:
+    path: devspace.yaml
+`,
+			},
+			expectedErr: "import cycle detected: devspace.yaml -> a.yaml -> b.yaml -> devspace.yaml",
+		},
+		{
+			name: "version mismatch",
+			files: map[string]string{
+				"devspace.yaml": `version: v1beta10
+imports:
+- source:
+    path: old.yaml
+`,
+				"old.yaml": `version: v1beta9
+`,
+			},
+			expectedErr: "has version v1beta9, but the importing config has version v1beta10",
+		},
+		{
+			name: "invalid imported config",
+			files: map[string]string{
+				"devspace.yaml": `version: v1beta10
+imports:
+- source:
+    path: shared.yaml
+`,
+				"shared.yaml": `comands: []
+`,
+			},
+			expectedErr: "shared.yaml:1:1: comands: unknown field comands, did you mean commands?",
+		},
+	}
+
+	for _, testCase := range testCases {
+		dir, err := ioutil.TempDir("", "test")
+		assert.NilError(t, err)
+		defer os.RemoveAll(dir)
+
+		for path, content := range testCase.files {
+			path = filepath.Join(dir, path)
+			assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			assert.NilError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		}
+
+		data := map[interface{}]interface{}{}
+		err = yaml.Unmarshal([]byte(testCase.files["devspace.yaml"]), &data)
+		assert.NilError(t, err)
+
+		merged, err := resolveImports(filepath.Join(dir, "devspace.yaml"), data, false, nil, log.Discard)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		out, err := yaml.Marshal(merged)
+		assert.NilError(t, err)
+		assert.Equal(t, string(out), testCase.expectedConfig, "Unexpected config in testCase %s", testCase.name)
+	}
+}
+
+func TestResolveImportsOldVersion(t *testing.T) {
+	_, err := resolveImports("devspace.yaml", map[interface{}]interface{}{
+		"version": "v1beta9",
+		"imports": []interface{}{},
+	}, false, nil, log.Discard)
+	assert.Assert(t, err != nil && strings.Contains(err.Error(), "imports are only supported"))
+}
@@ -20,6 +20,9 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/devspace/config/schema"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
@@ -129,7 +132,23 @@ func (l *configLoader) LoadWithParser(parser Parser, options *ConfigOptions, log
 		return nil, err
 	}
 
-	parsedConfig, generatedConfig, resolver, err := l.parseConfig(absPath, data, parser, options, log)
+	err = l.validateSchema(data)
+	if err == nil {
+		data, err = resolveImports(absPath, data, options.ProfileRefresh, options.Provenance, log)
+	}
+	if err == nil {
+		err = l.recordFile(options.Provenance)
+	}
+	if err != nil {
+		pluginErr = plugin.ExecutePluginHookWithContext("config.errorLoad", map[string]interface{}{"ERROR": err, "LOAD_PATH": absPath})
+		if pluginErr != nil {
+			return nil, pluginErr
+		}
+
+		return nil, err
+	}
+
+	parsedConfig, generatedConfig, resolver, overlays, err := l.parseConfig(absPath, data, parser, options, log)
 	if err != nil {
 		pluginErr = plugin.ExecutePluginHookWithContext("config.errorLoad", map[string]interface{}{"ERROR": err, "LOAD_PATH": absPath})
 		if pluginErr != nil {
@@ -149,7 +168,7 @@ func (l *configLoader) LoadWithParser(parser Parser, options *ConfigOptions, log
 		return nil, errors.Wrap(err, "require versions")
 	}
 
-	c := config.NewConfig(data, parsedConfig, generatedConfig, resolver.ResolvedVariables(), absPath)
+	c := config.WithOverlays(config.WithVariableSources(config.NewConfig(data, parsedConfig, generatedConfig, resolver.ResolvedVariables(), absPath), resolver.ResolvedSources()), overlays)
 	pluginErr = plugin.ExecutePluginHookWithContext("config.afterLoad", map[string]interface{}{
 		"LOAD_PATH":     absPath,
 		"LOADED_CONFIG": c.Config(),
@@ -254,18 +273,18 @@ func (l *configLoader) ensureRequires(config *latest.Config, log log.Logger) err
 	return nil
 }
 
-func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]interface{}, parser Parser, options *ConfigOptions, log log.Logger) (*latest.Config, *generated.Config, variable.Resolver, error) {
+func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]interface{}, parser Parser, options *ConfigOptions, log log.Logger) (*latest.Config, *generated.Config, variable.Resolver, []string, error) {
 	// load the generated config
 	generatedConfig, err := l.LoadGenerated(options)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// restore vars if wanted
 	if options.KubeClient != nil && options.RestoreVars {
 		vars, _, err := RestoreVarsFromSecret(options.KubeClient, options.VarsSecretName)
 		if err != nil {
-			return nil, nil, nil, errors.Wrap(err, "restore vars")
+			return nil, nil, nil, nil, errors.Wrap(err, "restore vars")
 		} else if vars != nil {
 			generatedConfig.Vars = vars
 		}
@@ -282,25 +301,44 @@ func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]int
 	// copy raw config
 	copiedRawConfig, err := copyRaw(rawConfig)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	// decrypt the encrypted values
+	secretVars := map[string]bool{}
+	err = decryptSecrets(copiedRawConfig, secretVars)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	// apply the profiles
-	copiedRawConfig, err = l.applyProfiles(copiedRawConfig, options, log)
+	copiedRawConfig, profiles, err := l.applyProfiles(copiedRawConfig, options, resolver, log)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// apply the overlay files of the profiles and the local overlay file
+	copiedRawConfig, overlays, err := l.applyOverlays(copiedRawConfig, profiles, options.Provenance, log)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// decrypt the encrypted values of the overlay files
+	err = decryptSecrets(copiedRawConfig, secretVars)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// Load defined variables
 	vars, err := versions.ParseVariables(copiedRawConfig, log)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// validate variables
 	err = validateVars(vars)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// Delete vars from config
@@ -309,15 +347,26 @@ func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]int
 	// parse the config
 	latestConfig, err := parser.Parse(absPath, rawConfig, copiedRawConfig, vars, resolver, options, log)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// now we validate the config
 	err = validate(latestConfig, log)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
+	// set the config the tracked values are explained for
+	if options.Provenance != nil {
+		err = setProvenanceConfig(options.Provenance, latestConfig)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	// secrets are never cached and never printed
+	redactSecrets(secretVars, resolver.ResolvedVariables(), generatedConfig)
+
 	// Save generated config
 	if options.generatedLoader == nil {
 		err = generated.NewConfigLoaderFromDevSpacePath(GetLastProfile(options.Profiles), l.configPath).Save(generatedConfig)
@@ -325,52 +374,66 @@ func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]int
 		err = options.generatedLoader.Save(generatedConfig)
 	}
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// save vars if wanted
 	if options.KubeClient != nil && options.SaveVars {
 		err = SaveVarsInSecret(options.KubeClient, generatedConfig.Vars, options.VarsSecretName, log)
 		if err != nil {
-			return nil, nil, nil, errors.Wrap(err, "save vars")
+			return nil, nil, nil, nil, errors.Wrap(err, "save vars")
 		}
 	}
 
-	return latestConfig, generatedConfig, resolver, nil
+	return latestConfig, generatedConfig, resolver, overlays, nil
 }
 
-func (l *configLoader) applyProfiles(data map[interface{}]interface{}, options *ConfigOptions, log log.Logger) (map[interface{}]interface{}, error) {
+// applyProfiles applies the active profiles and their parents and returns the names of the applied profiles
+// in the order they were applied
+func (l *configLoader) applyProfiles(data map[interface{}]interface{}, options *ConfigOptions, resolver variable.Resolver, log log.Logger) (map[interface{}]interface{}, []string, error) {
 	// Get profile
-	profiles, err := versions.ParseProfile(filepath.Dir(l.configPath), data, options.Profiles, options.ProfileRefresh, options.DisableProfileActivation, log)
+	profiles, err := versions.ParseProfile(filepath.Dir(l.configPath), data, options.Profiles, options.ProfileRefresh, options.DisableProfileActivation, activationLookup(data, options.Vars, resolver, log), log)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Now delete not needed parts from config
 	delete(data, "profiles")
 
 	// Apply profiles
+	names := []string{}
 	for i := len(profiles) - 1; i >= 0; i-- {
-		// Apply replace
-		err = ApplyReplace(data, profiles[i])
+		data, err = applyProfile(data, profiles[i], options.Provenance)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		// Apply merge
-		data, err = ApplyMerge(data, profiles[i])
-		if err != nil {
-			return nil, err
+		if name, ok := profiles[i]["name"].(string); ok {
+			names = append(names, name)
 		}
+	}
 
-		// Apply strategic merge
-		data, err = ApplyStrategicMerge(data, profiles[i])
-		if err != nil {
-			return nil, err
-		}
+	return data, names, nil
+}
+
+// PreviewProfile applies the given profile and its parents to the raw config without resolving variables
+// and without activating any other profiles. Parents from other sources, e.g. git repositories, are skipped,
+// because the preview should neither download nor clone anything
+func PreviewProfile(basePath string, data map[interface{}]interface{}, profile string, log log.Logger) (map[interface{}]interface{}, error) {
+	data, err := copyRaw(data)
+	if err != nil {
+		return nil, err
+	}
+	skipRemoteParents(data, log)
+
+	profiles, err := versions.ParseProfile(basePath, data, []string{profile}, false, true, nil, log)
+	if err != nil {
+		return nil, err
+	}
 
-		// Apply patches
-		data, err = ApplyPatches(data, profiles[i])
+	delete(data, "profiles")
+	for i := len(profiles) - 1; i >= 0; i-- {
+		data, err = applyProfile(data, profiles[i], nil)
 		if err != nil {
 			return nil, err
 		}
@@ -379,6 +442,66 @@ func (l *configLoader) applyProfiles(data map[interface{}]interface{}, options *
 	return data, nil
 }
 
+// skipRemoteParents removes the parents that are loaded from another source from all profiles
+func skipRemoteParents(data map[interface{}]interface{}, log log.Logger) {
+	profiles, ok := data["profiles"].([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, rawProfile := range profiles {
+		profile, ok := rawProfile.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		parents, ok := profile["parents"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		localParents := []interface{}{}
+		for _, rawParent := range parents {
+			parent, ok := rawParent.(map[interface{}]interface{})
+			if ok && parent["source"] != nil {
+				log.Infof("Skip parent profile %v of profile %v in the preview, because it is loaded from another source", parent["profile"], profile["name"])
+				continue
+			}
+
+			localParents = append(localParents, rawParent)
+		}
+		profile["parents"] = localParents
+	}
+}
+
+// applyProfile applies the replace, merge, strategic merge and patches of a single profile to the config
+func applyProfile(data map[interface{}]interface{}, profile map[interface{}]interface{}, tracker *provenance.Tracker) (map[interface{}]interface{}, error) {
+	if tracker != nil {
+		return applyProfileWithProvenance(data, profile, tracker)
+	}
+
+	// Apply replace
+	err := ApplyReplace(data, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply merge
+	data, err = ApplyMerge(data, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply strategic merge
+	data, err = ApplyStrategicMerge(data, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply patches
+	return ApplyPatches(data, profile)
+}
+
 func (l *configLoader) newVariableResolver(generatedConfig *generated.Config, options *ConfigOptions, log log.Logger) variable.Resolver {
 	return variable.NewResolver(generatedConfig.Vars, &variable.PredefinedVariableOptions{
 		BasePath:         options.BasePath,
@@ -386,6 +509,7 @@ func (l *configLoader) newVariableResolver(generatedConfig *generated.Config, op
 		KubeContextFlag:  options.KubeContext,
 		NamespaceFlag:    options.Namespace,
 		KubeConfigLoader: l.kubeConfigLoader,
+		KubeClient:       options.KubeClient,
 		Profile:          GetLastProfile(options.Profiles),
 	}, log)
 }
@@ -397,6 +521,20 @@ func GetLastProfile(profiles []string) string {
 	return profiles[len(profiles)-1]
 }
 
+// recordFile records the positions of the values within the config file if provenance is tracked
+func (l *configLoader) recordFile(tracker *provenance.Tracker) error {
+	if tracker == nil {
+		return nil
+	}
+
+	fileContent, err := l.readConfig()
+	if err != nil {
+		return err
+	}
+
+	return tracker.RecordFile(ConfigPath(l.configPath), fileContent)
+}
+
 // configExistsInPath checks whether a devspace configuration exists at a certain path
 func configExistsInPath(path string) bool {
 	// check devspace.yaml
@@ -410,14 +548,13 @@ func configExistsInPath(path string) bool {
 
 // LoadRaw loads the raw config
 func (l *configLoader) LoadRaw() (map[interface{}]interface{}, error) {
-	// What path should we use
-	configPath := ConfigPath(l.configPath)
-	_, err := os.Stat(configPath)
+	fileContent, err := l.readConfig()
 	if err != nil {
-		return nil, errors.Errorf("Couldn't load '%s': %v", configPath, err)
+		return nil, err
 	}
 
-	fileContent, err := ioutil.ReadFile(configPath)
+	// the values marked with !encrypted are decrypted after the config was parsed
+	fileContent, err = secrets.Placeholders(fileContent)
 	if err != nil {
 		return nil, err
 	}
@@ -431,6 +568,48 @@ func (l *configLoader) LoadRaw() (map[interface{}]interface{}, error) {
 	return rawMap, nil
 }
 
+func (l *configLoader) readConfig() ([]byte, error) {
+	// What path should we use
+	configPath := ConfigPath(l.configPath)
+	_, err := os.Stat(configPath)
+	if err != nil {
+		return nil, errors.Errorf("Couldn't load '%s': %v", configPath, err)
+	}
+
+	return ioutil.ReadFile(configPath)
+}
+
+// validateSchema validates the config file against the json schema of the latest config version and
+// returns an error with the line and column of every problem. Older config versions are not validated
+func (l *configLoader) validateSchema(data map[interface{}]interface{}) error {
+	if data["version"] != latest.Version {
+		return nil
+	}
+
+	fileContent, err := l.readConfig()
+	if err != nil {
+		return err
+	}
+
+	return validateSchemaFile(ConfigPath(l.configPath), fileContent)
+}
+
+func validateSchemaFile(path string, fileContent []byte) error {
+	schemaErrors, err := schema.NewConfigSchema().Validate(fileContent)
+	if err != nil {
+		return err
+	} else if len(schemaErrors) == 0 {
+		return nil
+	}
+
+	messages := []string{}
+	for _, schemaError := range schemaErrors {
+		messages = append(messages, fmt.Sprintf("  %s:%d:%d: %s: %s", path, schemaError.Line, schemaError.Column, schemaError.Path, schemaError.Message))
+	}
+
+	return errors.Errorf("Invalid config:\n%s", strings.Join(messages, "\n"))
+}
+
 // Exists checks whether the yaml file for the config exists or the configs.yaml exists
 func (l *configLoader) Exists() bool {
 	return configExistsInPath(ConfigPath(l.configPath))
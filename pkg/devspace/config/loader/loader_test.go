@@ -267,6 +267,28 @@ func testLoad(testCase loadTestCase, t *testing.T) {
 	assert.Equal(t, string(configAsYaml), string(expectedAsYaml), "Unexpected config in testCase %s", testCase.name)
 }
 
+func TestValidateSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	loader := &configLoader{configPath: configPath}
+
+	// older versions are not validated
+	err = ioutil.WriteFile(configPath, []byte("version: v1beta9\ndevv: {}\n"), 0644)
+	assert.NilError(t, err)
+	data, err := loader.LoadRaw()
+	assert.NilError(t, err)
+	assert.NilError(t, loader.validateSchema(data))
+
+	err = ioutil.WriteFile(configPath, []byte("version: "+latest.Version+"\ndevv: {}\n"), 0644)
+	assert.NilError(t, err)
+	data, err = loader.LoadRaw()
+	assert.NilError(t, err)
+	assert.Error(t, loader.validateSchema(data), "Invalid config:\n  "+configPath+":2:1: devv: unknown field devv, did you mean dev?")
+}
+
 type setDevSpaceRootTestCase struct {
 	name string
 
@@ -1217,7 +1239,7 @@ profiles:
 		testCase.in.options.generatedLoader = &fakeGeneratedLoader{}
 
 		configLoader := NewConfigLoader("").(*configLoader)
-		newConfig, _, _, err := configLoader.parseConfig("", testMap, NewDefaultParser(), testCase.in.options, log.Discard)
+		newConfig, _, _, _, err := configLoader.parseConfig("", testMap, NewDefaultParser(), testCase.in.options, log.Discard)
 		if testCase.expectedErr {
 			if err == nil {
 				t.Fatalf("TestCase %s: expected error, but got none", index)
@@ -1249,3 +1271,181 @@ func (f *fakeGeneratedLoader) Load() (*generated.Config, error) {
 func (f *fakeGeneratedLoader) Save(config *generated.Config) error {
 	return nil
 }
+
+func TestFileVariables(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("IMAGE=base\nTAG=1.0\nREPLICAS=1\n"), 0644)
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, ".env.local"), []byte("IMAGE=local\n"), 0644)
+	assert.NilError(t, err)
+
+	rawConfig := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(`version: v1beta10
+vars:
+- name: IMAGE
+  source: file
+  files: [.env, .env.local, .env.missing]
+- name: VERSION
+  source: file
+  files: [.env]
+  key: TAG
+  noCache: true
+- name: REPLICAS
+  source: file
+  files: [.env.local]
+  default: 2
+- name: DOMAIN
+  source: file
+  files: [.env]
+images:
+  app:
+    image: ${IMAGE}:${VERSION}
+deployments:
+- name: app
+  helm:
+    chart:
+      name: ${DOMAIN}
+    values:
+      replicas: ${REPLICAS}
+`), &rawConfig)
+	assert.NilError(t, err)
+
+	generatedConfig := &generated.Config{Vars: map[string]string{"DOMAIN": "cached", "VERSION": "cached"}}
+	configLoader := &configLoader{configPath: filepath.Join(dir, "devspace.yaml")}
+	parsedConfig, _, resolver, _, err := configLoader.parseConfig("", rawConfig, NewDefaultParser(), &ConfigOptions{
+		GeneratedConfig: generatedConfig,
+		generatedLoader: &fakeGeneratedLoader{},
+	}, log.Discard)
+	assert.NilError(t, err)
+
+	assert.Equal(t, parsedConfig.Images["app"].Image, "local:1.0")
+	assert.Equal(t, parsedConfig.Deployments[0].Helm.Chart.Name, "cached")
+	assert.Equal(t, parsedConfig.Deployments[0].Helm.Values["replicas"], 2)
+	assert.DeepEqual(t, resolver.ResolvedSources(), map[string]string{
+		"IMAGE":    "file .env.local",
+		"VERSION":  "file .env",
+		"REPLICAS": "default",
+		"DOMAIN":   "cache",
+	})
+	assert.DeepEqual(t, generatedConfig.Vars, map[string]string{"IMAGE": "local", "DOMAIN": "cached", "VERSION": "cached"})
+}
+
+func TestConditions(t *testing.T) {
+	rawConfig := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(`version: v1beta10
+vars:
+- name: ENABLE_DB
+  value: false
+- name: REPLICAS
+  value: 3
+- name: DEBUG_IMAGE
+  source: env
+images:
+  app:
+    image: app
+  debug:
+    image: ${DEBUG_IMAGE}
+    if: DEVSPACE_PROFILE == "debug"
+deployments:
+- name: app
+  if: REPLICAS > 1 && !contains(DEVSPACE_PROFILE, "debug")
+  helm:
+    chart:
+      name: app
+- name: db
+  if: ${ENABLE_DB}
+  helm:
+    chart:
+      name: db
+hooks:
+- command: echo
+  if: "false"
+  when:
+    before:
+      deployments: all
+- command: echo2
+  when:
+    before:
+      deployments: all
+dev:
+  ports:
+  - imageSelector: app
+    if: "true"
+    forward:
+    - port: 8080
+`), &rawConfig)
+	assert.NilError(t, err)
+
+	configLoader := NewConfigLoader("").(*configLoader)
+	parsedConfig, _, resolver, _, err := configLoader.parseConfig("", rawConfig, NewDefaultParser(), &ConfigOptions{
+		Profiles:        []string{},
+		GeneratedConfig: &generated.Config{Vars: map[string]string{}},
+		generatedLoader: &fakeGeneratedLoader{},
+	}, log.Discard)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(parsedConfig.Images), 1)
+	assert.Equal(t, parsedConfig.Images["app"].If, "")
+	assert.Equal(t, len(parsedConfig.Deployments), 1)
+	assert.Equal(t, parsedConfig.Deployments[0].Name, "app")
+	assert.Equal(t, len(parsedConfig.Hooks), 1)
+	assert.Equal(t, parsedConfig.Hooks[0].Command, "echo2")
+	assert.Equal(t, len(parsedConfig.Dev.Ports), 1)
+
+	// variables of removed entries are not resolved
+	_, ok := resolver.ResolvedVariables()["DEBUG_IMAGE"]
+	assert.Assert(t, !ok, "variable of removed image was resolved")
+
+	rawConfig = map[interface{}]interface{}{
+		"version": latest.Version,
+		"images": map[interface{}]interface{}{
+			"app": map[interface{}]interface{}{
+				"image": "app",
+				"if":    "UNDEFINED_VAR == 1",
+			},
+		},
+	}
+	_, _, _, _, err = configLoader.parseConfig("", rawConfig, NewDefaultParser(), &ConfigOptions{
+		GeneratedConfig: &generated.Config{Vars: map[string]string{}},
+		generatedLoader: &fakeGeneratedLoader{},
+	}, log.Discard)
+	assert.ErrorContains(t, err, "images.app.if: evaluate expression 'UNDEFINED_VAR == 1': variable UNDEFINED_VAR is not defined")
+}
+
+func TestPreviewProfile(t *testing.T) {
+	data := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(`version: v1beta10
+images:
+  backend:
+    image: john/dev
+profiles:
+- name: base
+  merge:
+    images:
+      backend:
+        image: john/base
+- name: production
+  parents:
+  - profile: base
+  - profile: remote
+    source:
+      git: https://github.com/example/does-not-exist
+  patches:
+  - op: add
+    path: images.backend.tags
+    value: [latest]
+`), &data)
+	assert.NilError(t, err)
+
+	preview, err := PreviewProfile(".", data, "production", log.Discard)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, preview["images"], map[string]interface{}{
+		"backend": map[string]interface{}{
+			"image": "john/base",
+			"tags":  []interface{}{"latest"},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package loader
 
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"gopkg.in/yaml.v2"
 )
@@ -39,6 +40,9 @@ type ConfigOptions struct {
 	SaveVars       bool
 	VarsSecretName string
 
+	// If set, the loader records where the values of the loaded config come from
+	Provenance *provenance.Tracker `yaml:"-" json:"-"`
+
 	// can be used for testing
 	generatedLoader generated.ConfigLoader `yaml:"-" json:"-"`
 }
@@ -0,0 +1,137 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// LocalOverlay is the name of the overlay that is applied after all profile overlays. It is meant for
+// personal changes and should not be committed, e.g. devspace.local.yaml
+const LocalOverlay = "local"
+
+// overlayConfig is the structure of an overlay file, which is a profile without name, parents and activation
+type overlayConfig struct {
+	Patches        []*latest.PatchConfig          `yaml:"patches,omitempty"`
+	Replace        *latest.ProfileConfigStructure `yaml:"replace,omitempty"`
+	Merge          *latest.ProfileConfigStructure `yaml:"merge,omitempty"`
+	StrategicMerge *latest.ProfileConfigStructure `yaml:"strategicMerge,omitempty"`
+}
+
+// OverlayPath returns the path of the overlay file with the given name next to the config, e.g.
+// devspace.production.yaml for the profile production and the config devspace.yaml
+func OverlayPath(configPath, name string) string {
+	ext := filepath.Ext(configPath)
+	return strings.TrimSuffix(configPath, ext) + "." + name + ext
+}
+
+// applyOverlays applies the overlay files of the given profiles in the given order and the local overlay
+// file afterwards. Overlay files that don't exist are skipped. Returns the paths of the applied files
+func (l *configLoader) applyOverlays(data map[interface{}]interface{}, profiles []string, tracker *provenance.Tracker, log log.Logger) (map[interface{}]interface{}, []string, error) {
+	configPath := ConfigPath(l.configPath)
+	names := []string{}
+	for _, profile := range profiles {
+		// the profile name is part of the overlay path, so it must not point to another directory
+		if strings.ContainsAny(profile, `/\`) || strings.Contains(profile, "..") {
+			return nil, nil, errors.Errorf("cannot load the overlay file of profile %s: profile names with path separators or '..' are not allowed", profile)
+		}
+
+		if profile != LocalOverlay && !contains(names, profile) {
+			names = append(names, profile)
+		}
+	}
+	names = append(names, LocalOverlay)
+
+	applied := []string{}
+	for _, name := range names {
+		path := OverlayPath(configPath, name)
+		overlay, err := loadOverlay(path)
+		if err != nil {
+			return nil, nil, err
+		} else if overlay == nil {
+			continue
+		}
+
+		log.Debugf("Apply overlay %s", path)
+		data, err = recordChanges(data, tracker, overlayKeys(overlay), overlayStep(path), func(data map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+			return applyProfile(data, overlay, nil)
+		})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "apply overlay %s", path)
+		}
+
+		applied = append(applied, path)
+	}
+
+	return data, applied, nil
+}
+
+// loadOverlay reads and validates the overlay file at the given path. Returns nil if the file doesn't exist
+func loadOverlay(path string) (map[interface{}]interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "read overlay %s", path)
+	}
+
+	// encrypted values are decrypted after the overlay was applied
+	content, err = secrets.Placeholders(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse overlay %s", path)
+	}
+
+	err = yaml.UnmarshalStrict(content, &overlayConfig{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse overlay %s", path)
+	}
+
+	overlay := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(content, &overlay)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse overlay %s", path)
+	}
+
+	// the name is used by the profile functions in error messages
+	overlay["name"] = filepath.Base(path)
+	return overlay, nil
+}
+
+// overlayKeys returns the keys of all values that are set by the replace, merge and strategic merge of the overlay
+func overlayKeys(overlay map[interface{}]interface{}) map[string]bool {
+	keys := map[string]bool{}
+	for _, kind := range []string{"replace", "merge", "strategicMerge"} {
+		for key := range provenance.Keys("", overlay[kind]) {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
+
+// overlayStep returns a step description function for the overlay file at the given path
+func overlayStep(path string) func(key string, before interface{}) string {
+	return func(key string, before interface{}) string {
+		return "overlay " + path
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
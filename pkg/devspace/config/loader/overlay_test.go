@@ -0,0 +1,198 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const overlayTestConfig = `version: v1beta10
+images:
+  app:
+    image: dev/app
+deployments:
+- name: app
+  helm:
+    componentChart: true
+    values:
+      replicas: 1
+dev:
+  ports:
+  - imageName: app
+    forward:
+    - port: 8080
+profiles:
+- name: production
+  merge:
+    images:
+      app:
+        image: prod/app
+`
+
+const productionOverlay = `patches:
+- op: replace
+  path: deployments.name=app.helm.values.replicas
+  value: 3
+`
+
+const localOverlay = `merge:
+  images:
+    app:
+      image: john/app
+strategicMerge:
+  dev:
+    ports:
+    - imageName: app
+      forward:
+      - port: 9229
+`
+
+type overlayTestCase struct {
+	name string
+
+	profiles []string
+	overlays map[string]string
+
+	expectedOverlays []string
+	expectedImage    string
+	expectedReplicas int
+	expectedPort     int
+	expectedErr      string
+}
+
+func TestOverlays(t *testing.T) {
+	testCases := []overlayTestCase{
+		{
+			name:             "No overlays",
+			expectedOverlays: []string{},
+			expectedImage:    "dev/app",
+			expectedReplicas: 1,
+			expectedPort:     8080,
+		},
+		{
+			name:             "Profile overlay of inactive profile",
+			overlays:         map[string]string{"devspace.production.yaml": productionOverlay},
+			expectedOverlays: []string{},
+			expectedImage:    "dev/app",
+			expectedReplicas: 1,
+			expectedPort:     8080,
+		},
+		{
+			name:             "Profile overlay",
+			profiles:         []string{"production"},
+			overlays:         map[string]string{"devspace.production.yaml": productionOverlay},
+			expectedOverlays: []string{"devspace.production.yaml"},
+			expectedImage:    "prod/app",
+			expectedReplicas: 3,
+			expectedPort:     8080,
+		},
+		{
+			name:     "Local overlay after profile overlay",
+			profiles: []string{"production"},
+			overlays: map[string]string{
+				"devspace.production.yaml": productionOverlay,
+				"devspace.local.yaml":      localOverlay,
+			},
+			expectedOverlays: []string{"devspace.production.yaml", "devspace.local.yaml"},
+			expectedImage:    "john/app",
+			expectedReplicas: 3,
+			expectedPort:     9229,
+		},
+		{
+			name:        "Invalid overlay",
+			overlays:    map[string]string{"devspace.local.yaml": "images:\n  app:\n    image: john/app\n"},
+			expectedErr: "parse overlay",
+		},
+	}
+
+	for _, testCase := range testCases {
+		dir, err := ioutil.TempDir("", "test")
+		assert.NilError(t, err)
+		defer os.RemoveAll(dir)
+
+		configPath := filepath.Join(dir, "devspace.yaml")
+		err = ioutil.WriteFile(configPath, []byte(overlayTestConfig), 0644)
+		assert.NilError(t, err)
+		for name, content := range testCase.overlays {
+			err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+			assert.NilError(t, err)
+		}
+
+		data := map[interface{}]interface{}{}
+		err = yaml.Unmarshal([]byte(overlayTestConfig), &data)
+		assert.NilError(t, err)
+
+		configLoader := &configLoader{configPath: configPath}
+		options := &ConfigOptions{
+			Profiles:        testCase.profiles,
+			GeneratedConfig: &generated.Config{Vars: map[string]string{}},
+			generatedLoader: &fakeGeneratedLoader{},
+		}
+		config, _, _, overlays, err := configLoader.parseConfig(configPath, data, NewDefaultParser(), options, log.Discard)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, "Unexpected error in test case %s", testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Error in test case %s", testCase.name)
+
+		expectedOverlays := []string{}
+		for _, overlay := range testCase.expectedOverlays {
+			expectedOverlays = append(expectedOverlays, filepath.Join(dir, overlay))
+		}
+		assert.DeepEqual(t, overlays, expectedOverlays)
+		assert.Equal(t, config.Images["app"].Image, testCase.expectedImage, "Unexpected image in test case %s", testCase.name)
+		assert.Equal(t, config.Deployments[0].Helm.Values["replicas"], testCase.expectedReplicas, "Unexpected replicas in test case %s", testCase.name)
+		assert.Equal(t, *config.Dev.Ports[0].PortMappings[0].LocalPort, testCase.expectedPort, "Unexpected port in test case %s", testCase.name)
+	}
+}
+
+func TestOverlayProfileNames(t *testing.T) {
+	configLoader := &configLoader{configPath: filepath.Join("project", "devspace.yaml")}
+	for _, profile := range []string{"../production", "team/production", `team\production`, "production.."} {
+		_, _, err := configLoader.applyOverlays(map[interface{}]interface{}{}, []string{profile}, nil, log.Discard)
+		assert.ErrorContains(t, err, "profile names with path separators or '..' are not allowed", "Unexpected error for profile %s", profile)
+	}
+}
+
+func TestExplainOverlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	err = ioutil.WriteFile(configPath, []byte(overlayTestConfig), 0644)
+	assert.NilError(t, err)
+	localPath := OverlayPath(configPath, LocalOverlay)
+	err = ioutil.WriteFile(localPath, []byte(localOverlay), 0644)
+	assert.NilError(t, err)
+
+	data := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(overlayTestConfig), &data)
+	assert.NilError(t, err)
+
+	tracker := provenance.NewTracker()
+	configLoader := &configLoader{configPath: configPath}
+	assert.NilError(t, configLoader.recordFile(tracker))
+
+	options := &ConfigOptions{
+		Provenance:      tracker,
+		GeneratedConfig: &generated.Config{Vars: map[string]string{}},
+		generatedLoader: &fakeGeneratedLoader{},
+	}
+	_, _, _, _, err = configLoader.parseConfig(configPath, data, NewDefaultParser(), options, log.Discard)
+	assert.NilError(t, err)
+
+	explained := map[string][]string{}
+	for _, value := range tracker.Explain("") {
+		explained[value.Path] = value.Origin
+	}
+
+	assert.DeepEqual(t, explained["images.app.image"], []string{configPath + ":4", "overlay " + localPath})
+}
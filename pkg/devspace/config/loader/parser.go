@@ -98,14 +98,22 @@ func (p *profilesParser) Parse(configPath string, originalRawConfig map[interfac
 }
 
 func fillVariablesAndParse(configPath string, preparedConfig map[interface{}]interface{}, vars []*latest.Variable, resolver variable.Resolver, options *ConfigOptions, log log.Logger) (*latest.Config, error) {
+	// remove the sections whose if expression is false first, so that their variables and expressions are not resolved
+	err := applyConditions(preparedConfig, vars, resolver)
+	if err != nil {
+		return nil, err
+	}
+
 	// fill in variables
-	err := fillVariables(resolver, preparedConfig, vars, options)
+	err = fillVariables(resolver, preparedConfig, vars, options)
 	if err != nil {
 		return nil, err
 	}
 
 	// execute expressions
-	err = expression.ResolveAllExpressions(preparedConfig, filepath.Dir(configPath))
+	_, err = recordChanges(preparedConfig, options.Provenance, nil, expressionStep, func(data map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+		return data, expression.ResolveAllExpressions(data, filepath.Dir(configPath))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +165,9 @@ func fillVariables(resolver variable.Resolver, preparedConfig map[interface{}]in
 	}
 
 	// Walk over data and fill in variables
-	err = resolver.FillVariables(preparedConfig)
+	_, err = recordChanges(preparedConfig, options.Provenance, nil, variableStep(resolver), func(data map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+		return data, resolver.FillVariables(data)
+	})
 	if err != nil {
 		return err
 	}
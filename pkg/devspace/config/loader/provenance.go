@@ -0,0 +1,156 @@
+package loader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"gopkg.in/yaml.v2"
+)
+
+// applyProfileWithProvenance applies the profile step by step and records which values each step changed.
+// Patches are recorded one by one, so that every changed value can be traced back to its patch
+func applyProfileWithProvenance(data map[interface{}]interface{}, profile map[interface{}]interface{}, tracker *provenance.Tracker) (map[interface{}]interface{}, error) {
+	name := fmt.Sprint(profile["name"])
+	profileKey := "profiles[name=" + name + "]"
+
+	data, err := recordChanges(data, tracker, provenance.Keys("", profile["replace"]), profileStep(tracker, name, "replace", profileKey+".replace"), func(data map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+		return data, ApplyReplace(data, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = recordChanges(data, tracker, provenance.Keys("", profile["merge"]), profileStep(tracker, name, "merge", profileKey+".merge"), func(data map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+		return ApplyMerge(data, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = recordChanges(data, tracker, provenance.Keys("", profile["strategicMerge"]), profileStep(tracker, name, "strategicMerge", profileKey+".strategicMerge"), func(data map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+		return ApplyStrategicMerge(data, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	patches, ok := profile["patches"].([]interface{})
+	if !ok {
+		return ApplyPatches(data, profile)
+	}
+
+	// patches are applied together, so every step applies all patches up to the current one on the original data
+	original := data
+	for i := range patches {
+		partialProfile := map[interface{}]interface{}{
+			"name":    profile["name"],
+			"patches": patches[:i+1],
+		}
+
+		patchKey := "patches[" + strconv.Itoa(i) + "]"
+		data, err = recordChanges(data, tracker, nil, profileStep(tracker, name, patchKey, profileKey+"."+patchKey+".path"), func(map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+			return ApplyPatches(original, partialProfile)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// profileStep returns a step description function for the given profile step. The position of the step is
+// looked up by the key of the changed value within the profile step or by the key itself if it is a patch
+func profileStep(tracker *provenance.Tracker, name, kind, stepKey string) func(key string, before interface{}) string {
+	return func(key string, before interface{}) string {
+		line := tracker.Line(stepKey + "." + key)
+		if strings.HasPrefix(kind, "patches") {
+			line = tracker.Line(stepKey)
+		}
+		if line == "" {
+			return fmt.Sprintf("profile %s (%s)", name, kind)
+		}
+
+		return fmt.Sprintf("profile %s (%s, %s)", name, kind, line)
+	}
+}
+
+// variableStep returns a step description function that names the variables used in the value before it was filled
+func variableStep(resolver variable.Resolver) func(key string, before interface{}) string {
+	return func(key string, before interface{}) string {
+		str, ok := before.(string)
+		if !ok {
+			return ""
+		}
+
+		steps := []string{}
+		for _, match := range varspkg.VarMatchRegex.FindAllString(str, -1) {
+			if strings.HasPrefix(match, "$$") {
+				continue
+			}
+
+			name := strings.TrimSuffix(strings.TrimLeft(match, "$!{"), "}")
+			source := resolver.ResolvedSources()[name]
+			if source == "" {
+				steps = append(steps, "variable "+name)
+			} else {
+				steps = append(steps, fmt.Sprintf("variable %s (%s)", name, source))
+			}
+		}
+
+		return strings.Join(steps, ", ")
+	}
+}
+
+// expressionStep describes values that were changed by an expression
+func expressionStep(key string, before interface{}) string {
+	return "expression"
+}
+
+// recordChanges calls apply and records all values it changed in the tracker if provenance is tracked
+func recordChanges(data map[interface{}]interface{}, tracker *provenance.Tracker, keys map[string]bool, step func(key string, before interface{}) string, apply func(data map[interface{}]interface{}) (map[interface{}]interface{}, error)) (map[interface{}]interface{}, error) {
+	if tracker == nil {
+		return apply(data)
+	}
+
+	// values are compared as yaml, because some steps convert numbers to floats
+	before, err := copyRaw(data)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = apply(data)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := copyRaw(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker.Record(before, after, keys, step)
+	return data, nil
+}
+
+// setProvenanceConfig sets the parsed config as the config the tracker explains the values of
+func setProvenanceConfig(tracker *provenance.Tracker, config *latest.Config) error {
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	configMap := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(out, &configMap)
+	if err != nil {
+		return err
+	}
+
+	tracker.SetConfig(configMap)
+	return nil
+}
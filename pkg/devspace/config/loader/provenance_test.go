@@ -0,0 +1,88 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/provenance"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const provenanceTestConfig = `version: v1beta10
+vars:
+- name: IMAGE
+  default: myimage
+  source: env
+images:
+  app:
+    image: dev/app
+deployments:
+- name: app
+  helm:
+    chart:
+      name: mychart
+    values:
+      image: ${IMAGE}
+      replicas: 1
+profiles:
+- name: production
+  merge:
+    images:
+      app:
+        image: prod/app
+  patches:
+  - op: add
+    path: deployments.name=app.helm.values.debug
+    value: false
+  - op: replace
+    path: deployments.name=app.helm.values.replicas
+    value: 3
+`
+
+func TestExplain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	err = ioutil.WriteFile(configPath, []byte(provenanceTestConfig), 0644)
+	assert.NilError(t, err)
+
+	data := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(provenanceTestConfig), &data)
+	assert.NilError(t, err)
+
+	tracker := provenance.NewTracker()
+	configLoader := &configLoader{configPath: configPath}
+	assert.NilError(t, configLoader.recordFile(tracker))
+
+	options := &ConfigOptions{
+		Profiles:        []string{"production"},
+		Provenance:      tracker,
+		GeneratedConfig: &generated.Config{Vars: map[string]string{}},
+		generatedLoader: &fakeGeneratedLoader{},
+	}
+	_, _, _, _, err = configLoader.parseConfig(configPath, data, NewDefaultParser(), options, log.Discard)
+	assert.NilError(t, err)
+
+	explained := map[string][]string{}
+	for _, value := range tracker.Explain("") {
+		explained[value.Path] = value.Origin
+	}
+
+	assert.DeepEqual(t, explained["images.app.image"], []string{configPath + ":8", "profile production (merge, " + configPath + ":22)"})
+	assert.DeepEqual(t, explained["deployments[0].name"], []string{configPath + ":10"})
+	assert.DeepEqual(t, explained["deployments[0].helm.values.image"], []string{configPath + ":15", "variable IMAGE (default)"})
+	assert.DeepEqual(t, explained["deployments[0].helm.values.replicas"], []string{configPath + ":16", "profile production (patches[1], " + configPath + ":28)"})
+	assert.DeepEqual(t, explained["deployments[0].helm.values.debug"], []string{"profile production (patches[0], " + configPath + ":25)"})
+
+	values := tracker.Explain("deployments[0].helm.values")
+	assert.Equal(t, len(values), 3)
+	assert.Equal(t, values[0].Path, "deployments[0].helm.values.debug")
+	assert.Equal(t, values[0].Value, false)
+}
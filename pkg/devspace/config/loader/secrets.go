@@ -0,0 +1,93 @@
+package loader
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl/walk"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+// decryptSecrets replaces the placeholders of encrypted values with the decrypted values and adds the
+// variables that are encrypted or use an encrypted variable in their definition to the secret variables.
+// The secrets key is only loaded if there are encrypted values
+func decryptSecrets(data map[interface{}]interface{}, secretVars map[string]bool) error {
+	findSecretVariables(data["vars"], secretVars)
+
+	var keyring openpgp.EntityList
+	return walk.Walk(data, func(key, value string) bool {
+		return secrets.IsPlaceholder(value)
+	}, func(value string) (interface{}, error) {
+		if keyring == nil {
+			var err error
+			keyring, err = secrets.LoadKey()
+			if err != nil {
+				return nil, errors.Wrap(err, "decrypt secrets")
+			}
+		}
+
+		decrypted, err := secrets.DecryptPlaceholder(value, keyring)
+		if err != nil {
+			return nil, errors.Wrap(err, "decrypt secrets")
+		}
+
+		// encrypted values outside of variables are redacted by their encrypted message
+		log.Redact(value, decrypted)
+		return decrypted, nil
+	})
+}
+
+// findSecretVariables adds the variables of the raw vars section that contain an encrypted value or use
+// a secret variable in their definition to the secret variables
+func findSecretVariables(rawVars interface{}, secretVars map[string]bool) {
+	definitions, ok := rawVars.([]interface{})
+	if !ok {
+		return
+	}
+
+	// variables can only use variables that are defined before them, so a single pass is enough
+	for _, definition := range definitions {
+		definitionMap, ok := definition.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		name, ok := definitionMap["name"].(string)
+		if !ok {
+			continue
+		}
+
+		// the walk only visits the strings of the definition and never replaces them
+		_ = walk.Walk(definitionMap, func(key, value string) bool {
+			if secrets.IsPlaceholder(value) {
+				secretVars[name] = true
+				return false
+			}
+
+			_, _ = varspkg.ParseString(value, func(v string) (interface{}, error) {
+				if secretVars[v] {
+					secretVars[name] = true
+				}
+
+				return "", nil
+			})
+			return false
+		}, nil)
+	}
+}
+
+// redactSecrets removes the secret variables from the generated config and redacts their values from the log output
+func redactSecrets(secretVars map[string]bool, resolvedVars map[string]interface{}, generatedConfig *generated.Config) {
+	for name := range secretVars {
+		delete(generatedConfig.Vars, name)
+
+		value, ok := resolvedVars[name]
+		if ok {
+			log.Redact("vars."+name, fmt.Sprintf("%v", value))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/secrets"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestDecryptSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	os.Setenv(secrets.KeyEnv, filepath.Join(dir, "key.asc"))
+	defer os.Unsetenv(secrets.KeyEnv)
+
+	keyring, err := secrets.EnsureKey(log.Discard)
+	assert.NilError(t, err)
+	password, err := secrets.Encrypt("my-secret-password", keyring)
+	assert.NilError(t, err)
+	token, err := secrets.Encrypt("my-secret-token", keyring)
+	assert.NilError(t, err)
+
+	data := map[interface{}]interface{}{
+		"vars": []interface{}{
+			map[interface{}]interface{}{"name": "PASSWORD", "value": secrets.Tag + " " + password},
+			map[interface{}]interface{}{"name": "URL", "value": "postgres://admin:${PASSWORD}@db"},
+			map[interface{}]interface{}{"name": "DATABASE", "source": "input", "default": "${URL}"},
+			map[interface{}]interface{}{"name": "IMAGE", "value": "myimage"},
+		},
+		"deployments": []interface{}{
+			map[interface{}]interface{}{"name": "db", "helm": map[interface{}]interface{}{"values": map[interface{}]interface{}{"token": secrets.Tag + " " + token}}},
+		},
+	}
+
+	secretVars := map[string]bool{}
+	err = decryptSecrets(data, secretVars)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, secretVars, map[string]bool{"PASSWORD": true, "URL": true, "DATABASE": true})
+	assert.Equal(t, data["vars"].([]interface{})[0].(map[interface{}]interface{})["value"], "my-secret-password")
+	assert.Equal(t, data["deployments"].([]interface{})[0].(map[interface{}]interface{})["helm"].(map[interface{}]interface{})["values"].(map[interface{}]interface{})["token"], "my-secret-token")
+
+	generatedConfig := &generated.Config{Vars: map[string]string{"DATABASE": "postgres://admin:my-secret-password@db", "IMAGE": "myimage"}}
+	redactSecrets(secretVars, map[string]interface{}{
+		"PASSWORD": "my-secret-password",
+		"URL":      "postgres://admin:my-secret-password@db",
+		"DATABASE": "postgres://admin:my-secret-password@db",
+		"IMAGE":    "myimage",
+	}, generatedConfig)
+	assert.DeepEqual(t, generatedConfig.Vars, map[string]string{"IMAGE": "myimage"})
+	assert.Equal(t, log.RedactString("token my-secret-token, image myimage"), "token "+log.RedactedValue+", image myimage")
+	assert.Equal(t, log.RedactString("connect to postgres://admin:my-secret-password@db"), "connect to "+log.RedactedValue)
+
+	// short secrets are redacted as well
+	log.Redact("vars.PIN", "1234")
+	assert.Equal(t, log.RedactString("pin is 1234"), "pin is "+log.RedactedValue)
+	log.Redact("vars.PIN", "")
+	assert.Equal(t, log.RedactString("pin is 1234"), "pin is 1234")
+}
@@ -0,0 +1,740 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/patch"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	yaml2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// upgradeMarker is placed at the path of a patch to find out where the path moved to during the upgrade
+const upgradeMarker = "__devspace_upgrade_marker__"
+
+// variableMarkerBase is the first number that replaces a variable during the upgrade. Numbers are used,
+// because they can be parsed into string, number and interface fields
+const variableMarkerBase int64 = 7000000000
+
+// markedVariables are the variables that were replaced before the upgrade
+type markedVariables struct {
+	// byMarker are the variables of string, number and interface fields by their number
+	byMarker map[string]string
+
+	// removed are the variables of the other fields, e.g. booleans, which cannot hold a unique number
+	removed []*removedVariable
+}
+
+// removedVariable is a variable that was removed from a field and is restored at the same path after the upgrade
+type removedVariable struct {
+	path     []interface{}
+	variable string
+}
+
+var nameSelectorRegEx = regexp.MustCompile(`^[^\.\=\>\<\~\[\]\/]+$`)
+
+var variableRegEx = regexp.MustCompile(`^\$!?\{[a-zA-Z0-9\-\_\.]+\}$`)
+
+// UpgradeConfig upgrades the given devspace.yaml content to the latest config version. Comments and the order
+// of keys are preserved where possible and patches of profiles that reference moved paths are rewritten.
+// Content that already uses the latest version is returned unchanged
+func UpgradeConfig(content []byte, log log.Logger) ([]byte, error) {
+	data := map[interface{}]interface{}{}
+	err := yaml2.Unmarshal(content, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	version, ok := data["version"].(string)
+	if !ok {
+		return nil, errors.Errorf("Version is missing in devspace.yaml")
+	} else if version == latest.Version {
+		return content, nil
+	}
+
+	// values like ${PORT} cannot be parsed into typed fields, so they are replaced with markers
+	var configType reflect.Type
+	if versionConfig := versions.New(version); versionConfig != nil {
+		configType = reflect.TypeOf(versionConfig)
+	}
+	variables := &markedVariables{byMarker: map[string]string{}}
+	if profiles, ok := data["profiles"].([]interface{}); ok {
+		// the partial configs of the profiles are upgraded like a complete config
+		for i, rawProfile := range profiles {
+			profile, _ := rawProfile.(map[interface{}]interface{})
+			for _, key := range []string{"replace", "merge", "strategicMerge"} {
+				if partial, ok := profile[key].(map[interface{}]interface{}); ok {
+					markVariables(partial, configType, []interface{}{"profiles", i, key}, variables)
+				}
+			}
+		}
+	}
+	markVariables(data, configType, nil, variables)
+
+	base, err := copyRaw(data)
+	if err != nil {
+		return nil, err
+	}
+	delete(base, "profiles")
+
+	upgraded, err := versions.Parse(base, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if data["profiles"] != nil {
+		upgradedBase := map[interface{}]interface{}{}
+		err = util.Convert(upgraded, &upgradedBase)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles, err := upgradeProfiles(base, upgradedBase, data["profiles"], log)
+		if err != nil {
+			return nil, err
+		}
+
+		err = util.Convert(profiles, &upgraded.Profiles)
+		if err != nil {
+			return nil, errors.Wrap(err, "convert profiles")
+		}
+	}
+
+	out, err := yaml2.Marshal(upgraded)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradedDoc := &yaml.Node{}
+	err = yaml.Unmarshal(out, upgradedDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreVariables(upgradedDoc, variables.byMarker)
+	for _, removed := range variables.removed {
+		if !setPathValue(upgradedDoc.Content[0], removed.path, removed.variable) {
+			log.Warnf("Couldn't restore variable %s at %s, because the field was moved or removed during the upgrade. Please add it manually", removed.variable, formatPath(data, removed.path))
+		}
+	}
+
+	originalDoc := &yaml.Node{}
+	err = yaml.Unmarshal(content, originalDoc)
+	if err != nil {
+		return nil, err
+	}
+	originalDoc.Content[0] = mergeNode(originalDoc.Content[0], upgradedDoc.Content[0])
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	err = encoder.Encode(originalDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// markVariables replaces the values that only consist of a variable depending on the type of the field they are
+// parsed into. Values of string, number and interface fields are replaced with unique numbers, values of other
+// fields are removed and remembered by their path. An unknown type is treated like an interface field
+func markVariables(value interface{}, t reflect.Type, path []interface{}, variables *markedVariables) interface{} {
+	switch v := value.(type) {
+	case string:
+		if !variableRegEx.MatchString(v) {
+			break
+		} else if !canHoldMarker(t) {
+			variables.removed = append(variables.removed, &removedVariable{path: path, variable: v})
+			return nil
+		}
+
+		marker := variableMarkerBase + int64(len(variables.byMarker))
+		variables.byMarker[strconv.FormatInt(marker, 10)] = v
+		return marker
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			v[key] = markVariables(item, fieldType(t, key), appendPath(path, key), variables)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = markVariables(item, fieldType(t, i), appendPath(path, i), variables)
+		}
+	}
+
+	return value
+}
+
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	return append(append([]interface{}{}, path...), element)
+}
+
+// fieldType returns the type of the field, map value or array item with the given key or nil if it is unknown
+func fieldType(t reflect.Type, key interface{}) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0] == fmt.Sprint(key) {
+				return t.Field(i).Type
+			}
+		}
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return t.Elem()
+	}
+
+	return nil
+}
+
+// canHoldMarker checks if a variable marker can be parsed into a field of the given type and is printed
+// unchanged again
+func canHoldMarker(t reflect.Type) bool {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Interface, reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+// setPathValue sets the string value at the path of the node. A missing key is added to its mapping, but
+// missing parents are not created
+func setPathValue(node *yaml.Node, path []interface{}, value string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	for _, element := range path[:len(path)-1] {
+		node = childNode(node, element)
+		if node == nil {
+			return false
+		}
+	}
+
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	last := path[len(path)-1]
+	target := childNode(node, last)
+	if target != nil {
+		*target = *valueNode
+		return true
+	} else if node.Kind != yaml.MappingNode {
+		return false
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(last)}, valueNode)
+	return true
+}
+
+// childNode returns the value of the mapping key or the sequence item with the given index or nil
+func childNode(node *yaml.Node, element interface{}) *yaml.Node {
+	switch e := element.(type) {
+	case int:
+		if node.Kind == yaml.SequenceNode && e < len(node.Content) {
+			return node.Content[e]
+		}
+	default:
+		return mappingValue(node, fmt.Sprint(e))
+	}
+
+	return nil
+}
+
+// restoreVariables replaces the markers of the variables with the variables again
+func restoreVariables(node *yaml.Node, variables map[string]string) {
+	if node.Kind == yaml.ScalarNode {
+		variable, ok := variables[node.Value]
+		if ok {
+			node.Value = variable
+			node.Tag = "!!str"
+			node.Style = 0
+		}
+	}
+
+	for _, child := range node.Content {
+		restoreVariables(child, variables)
+	}
+}
+
+// upgradeProfiles upgrades the replace, merge and strategic merge sections of the profiles and
+// rewrites the paths of patches that moved
+func upgradeProfiles(base, upgradedBase map[interface{}]interface{}, rawProfiles interface{}, log log.Logger) (interface{}, error) {
+	profiles, ok := rawProfiles.([]interface{})
+	if !ok {
+		return nil, errors.New("profiles is not an array")
+	}
+
+	for i, rawProfile := range profiles {
+		profile, ok := rawProfile.(map[interface{}]interface{})
+		if !ok {
+			return nil, errors.Errorf("profiles[%d] is not an object", i)
+		}
+
+		for _, key := range []string{"replace", "merge", "strategicMerge"} {
+			partial, ok := profile[key].(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+
+			upgradedPartial, err := upgradePartial(base["version"], partial)
+			if err != nil {
+				return nil, errors.Wrapf(err, "profiles[%d].%s", i, key)
+			}
+
+			equivalent, err := equivalentPartials(base, upgradedBase, key, partial, upgradedPartial)
+			if err != nil {
+				return nil, errors.Wrapf(err, "profiles[%d].%s", i, key)
+			} else if !equivalent {
+				log.Warnf("Couldn't upgrade profiles[%d].%s, because the upgraded profile would change the config differently. Please upgrade it manually", i, key)
+				continue
+			}
+
+			profile[key] = upgradedPartial
+		}
+
+		patches, ok := profile["patches"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for j, rawPatch := range patches {
+			patch, ok := rawPatch.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+
+			newPath, err := upgradePatchPath(base, patch)
+			if err != nil {
+				log.Warnf("Couldn't check if the path of profiles[%d].patches[%d] moved: %v. Please check it manually", i, j, err)
+				continue
+			} else if newPath != "" {
+				log.Infof("Rewrite path of profiles[%d].patches[%d] from %v to %s", i, j, patch["path"], newPath)
+				patch["path"] = newPath
+			}
+		}
+	}
+
+	return profiles, nil
+}
+
+// upgradePartial upgrades a partial config of a profile. It returns the partial unchanged if the upgrade
+// didn't change anything
+func upgradePartial(version interface{}, partial map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+	data, err := copyRaw(partial)
+	if err != nil {
+		return nil, err
+	}
+	data["version"] = version
+
+	config, err := versions.Parse(data, log.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	upgraded := map[interface{}]interface{}{}
+	err = util.Convert(config, &upgraded)
+	if err != nil {
+		return nil, err
+	}
+	delete(upgraded, "version")
+
+	originalOut, err := yaml2.Marshal(partial)
+	if err != nil {
+		return nil, err
+	}
+	upgradedOut, err := yaml2.Marshal(upgraded)
+	if err != nil {
+		return nil, err
+	} else if bytes.Equal(originalOut, upgradedOut) {
+		return partial, nil
+	}
+
+	return upgraded, nil
+}
+
+// equivalentPartials checks if applying the upgraded partial to the upgraded config results in the
+// same config as applying the original partial to the original config and upgrading it afterwards
+func equivalentPartials(base, upgradedBase map[interface{}]interface{}, key string, original, upgraded map[interface{}]interface{}) (bool, error) {
+	expected, err := applyPartial(base, key, original)
+	if err != nil {
+		return false, err
+	}
+
+	actual, err := applyPartial(upgradedBase, key, upgraded)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(expected, actual), nil
+}
+
+func applyPartial(data map[interface{}]interface{}, key string, partial map[interface{}]interface{}) ([]byte, error) {
+	data, err := copyRaw(data)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := copyRaw(map[interface{}]interface{}{key: partial})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = applyProfile(data, profile, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := versions.Parse(data, log.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml2.Marshal(config)
+}
+
+// upgradePatchPath returns the new path of the patch or an empty string if the path didn't move. The
+// path is found by placing a marker at the patched path of the base config and searching for it after
+// the upgrade
+func upgradePatchPath(base map[interface{}]interface{}, rawPatch map[interface{}]interface{}) (string, error) {
+	patchConfig := &latest.PatchConfig{}
+	err := util.Convert(rawPatch, patchConfig)
+	if err != nil {
+		return "", err
+	} else if patchConfig.Path == "" || strings.Contains(patchConfig.Path, "*") || strings.Contains(patchConfig.Path, "..") {
+		return "", nil
+	}
+
+	value := patchConfig.Value
+	if patchConfig.Operation != "add" && patchConfig.Operation != "replace" {
+		value, err = findValue(base, patchConfig.Path)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	marked, suffix, ok := markValue(value)
+	if !ok {
+		return "", errors.New("the value has no string field")
+	}
+
+	operation := "replace"
+	if patchConfig.Operation == "add" {
+		operation = "add"
+	}
+
+	patched, err := copyRaw(base)
+	if err != nil {
+		return "", err
+	}
+	patched, err = ApplyPatchesOnObject(patched, []*latest.PatchConfig{{Operation: operation, Path: patchConfig.Path, Value: marked}})
+	if err != nil {
+		return "", err
+	}
+
+	oldPath := findMarker(convertBack(patched), nil)
+	if oldPath == nil {
+		return "", errors.Errorf("path %s not found", patchConfig.Path)
+	}
+
+	config, err := versions.Parse(patched, log.Discard)
+	if err != nil {
+		return "", err
+	}
+
+	upgraded := map[interface{}]interface{}{}
+	err = util.Convert(config, &upgraded)
+	if err != nil {
+		return "", err
+	}
+
+	newPath := findMarker(upgraded, nil)
+	if newPath == nil {
+		return "", errors.Errorf("path %s was removed", patchConfig.Path)
+	} else if fmt.Sprint(oldPath) == fmt.Sprint(newPath) {
+		return "", nil
+	} else if len(newPath) < len(suffix) || fmt.Sprint(newPath[len(newPath)-len(suffix):]) != fmt.Sprint(suffix) {
+		return "", errors.Errorf("path %s was restructured", patchConfig.Path)
+	}
+
+	newPath = newPath[:len(newPath)-len(suffix)]
+	if strings.HasPrefix(patchConfig.Path, "/") {
+		return formatXPath(newPath, strings.HasSuffix(patchConfig.Path, "/-")), nil
+	}
+
+	return formatPath(upgraded, newPath), nil
+}
+
+// findValue returns the value at the patch path in the config or nil if there is none
+func findValue(data map[interface{}]interface{}, path string) (interface{}, error) {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &yaml.Node{}
+	err = yaml.Unmarshal(out, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	opPath := patch.OpPath(transformPath(path))
+	target, err := findPath(&opPath, doc)
+	if err != nil || target == nil {
+		return nil, err
+	}
+
+	out, err = yaml.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = yaml2.Unmarshal(out, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// markValue replaces the first string field of the value with the marker and returns the path of the field
+// within the value
+func markValue(value interface{}) (interface{}, []interface{}, bool) {
+	switch v := value.(type) {
+	case nil, string:
+		return upgradeMarker, nil, true
+	case map[interface{}]interface{}:
+		keys := []string{}
+		for key := range v {
+			keys = append(keys, fmt.Sprint(key))
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			marked, suffix, ok := markValue(v[key])
+			if !ok {
+				continue
+			}
+
+			copied := map[interface{}]interface{}{}
+			for k, v := range v {
+				copied[k] = v
+			}
+			copied[key] = marked
+			return copied, append([]interface{}{key}, suffix...), true
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			marked, suffix, ok := markValue(v[0])
+			if ok {
+				copied := append([]interface{}{marked}, v[1:]...)
+				return copied, append([]interface{}{0}, suffix...), true
+			}
+		}
+	}
+
+	return value, nil, false
+}
+
+// findMarker returns the path of the marker within the value or nil if the value doesn't contain the marker
+func findMarker(value interface{}, path []interface{}) []interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == upgradeMarker {
+			return path
+		}
+	case map[interface{}]interface{}:
+		keys := []string{}
+		for key := range v {
+			keys = append(keys, fmt.Sprint(key))
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			found := findMarker(v[key], append(append([]interface{}{}, path...), key))
+			if found != nil {
+				return found
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			found := findMarker(item, append(append([]interface{}{}, path...), i))
+			if found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatPath formats the path in the dot notation and selects array items by name if possible, e.g.
+// deployments.name=backend.helm.values
+func formatPath(data interface{}, path []interface{}) string {
+	tokens := []string{}
+	for _, element := range path {
+		switch e := element.(type) {
+		case int:
+			var item interface{}
+			if items, ok := data.([]interface{}); ok && e < len(items) {
+				item = items[e]
+			}
+
+			itemMap, _ := item.(map[interface{}]interface{})
+			name, ok := itemMap["name"].(string)
+			if ok && nameSelectorRegEx.MatchString(name) {
+				tokens = append(tokens, "name="+name)
+			} else if len(tokens) > 0 {
+				tokens[len(tokens)-1] += "[" + strconv.Itoa(e) + "]"
+			} else {
+				tokens = append(tokens, "["+strconv.Itoa(e)+"]")
+			}
+
+			data = item
+		default:
+			key := fmt.Sprint(e)
+			tokens = append(tokens, key)
+			if m, ok := data.(map[interface{}]interface{}); ok {
+				data = m[key]
+			}
+		}
+	}
+
+	return strings.Join(tokens, ".")
+}
+
+// formatXPath formats the path in the xpath notation, e.g. /deployments/0/helm/values
+func formatXPath(path []interface{}, appendToArray bool) string {
+	tokens := []string{}
+	for _, element := range path {
+		tokens = append(tokens, fmt.Sprint(element))
+	}
+	if appendToArray && len(tokens) > 0 {
+		tokens[len(tokens)-1] = "-"
+	}
+
+	return "/" + strings.Join(tokens, "/")
+}
+
+// mergeNode returns the node of the upgraded config, but keeps the nodes, comments and key order of the
+// original config where possible
+func mergeNode(original, upgraded *yaml.Node) *yaml.Node {
+	if original.Kind != upgraded.Kind {
+		copyComments(original, upgraded)
+		return upgraded
+	}
+
+	switch upgraded.Kind {
+	case yaml.MappingNode:
+		merged := *original
+		merged.Content = []*yaml.Node{}
+		if len(original.Content) == 0 {
+			merged.Style = upgraded.Style
+		}
+		keys := map[string]bool{}
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			value := mappingValue(upgraded, original.Content[i].Value)
+			if value == nil {
+				continue
+			}
+
+			keys[original.Content[i].Value] = true
+			merged.Content = append(merged.Content, original.Content[i], mergeNode(original.Content[i+1], value))
+		}
+		for i := 0; i+1 < len(upgraded.Content); i += 2 {
+			if !keys[upgraded.Content[i].Value] {
+				merged.Content = append(merged.Content, upgraded.Content[i], upgraded.Content[i+1])
+			}
+		}
+
+		return &merged
+	case yaml.SequenceNode:
+		merged := *original
+		merged.Content = []*yaml.Node{}
+		if len(original.Content) == 0 {
+			merged.Style = upgraded.Style
+		}
+		for i, item := range upgraded.Content {
+			match := matchItem(original, item, i)
+			if match != nil {
+				item = mergeNode(match, item)
+			}
+
+			merged.Content = append(merged.Content, item)
+		}
+
+		return &merged
+	case yaml.ScalarNode:
+		if original.Value == upgraded.Value {
+			return original
+		}
+	}
+
+	copyComments(original, upgraded)
+	return upgraded
+}
+
+// matchItem returns the item of the original sequence with the same name as the upgraded item or the item
+// at the same index
+func matchItem(original, item *yaml.Node, index int) *yaml.Node {
+	if name := mappingValue(item, "name"); name != nil {
+		for _, originalItem := range original.Content {
+			originalName := mappingValue(originalItem, "name")
+			if originalName != nil && originalName.Value == name.Value {
+				return originalItem
+			}
+		}
+	}
+
+	if index < len(original.Content) {
+		return original.Content[index]
+	}
+
+	return nil
+}
+
+func copyComments(from, to *yaml.Node) {
+	to.HeadComment = from.HeadComment
+	to.LineComment = from.LineComment
+	to.FootComment = from.FootComment
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+type upgradeTestCase struct {
+	name     string
+	in       string
+	expected string
+}
+
+func TestUpgradeConfig(t *testing.T) {
+	testCases := []upgradeTestCase{
+		{
+			name: "Latest version is unchanged",
+			in: `version: v1beta10
+# comment
+images:
+  backend:
+    image: test
+`,
+			expected: `version: v1beta10
+# comment
+images:
+  backend:
+    image: test
+`,
+		},
+		{
+			name: "Upgrade with comments, moved paths and profiles",
+			in: `version: v1beta9
+# the images
+images:
+  backend:
+    image: test # the image
+dev:
+  interactive:
+    terminal:
+      imageName: backend
+    images:
+    - name: backend
+      entrypoint:
+      - sleep
+profiles:
+- name: staging
+  patches:
+  - op: replace
+    path: dev.interactive.terminal.imageName
+    value: frontend
+  - op: replace
+    path: dev.interactive.images.name=backend.entrypoint
+    value:
+    - run
+  - op: replace
+    path: images.backend.image
+    value: other
+  merge:
+    dev:
+      interactive:
+        terminal:
+          workDir: /app
+- name: interactive
+  merge:
+    dev:
+      interactive:
+        defaultEnabled: true
+`,
+			expected: `version: v1beta10
+# the images
+images:
+  backend:
+    image: test # the image
+dev:
+  terminal:
+    imageName: backend
+    disabled: true
+  deprecatedInteractiveImages:
+  - name: backend
+    entrypoint:
+    - sleep
+profiles:
+- name: staging
+  patches:
+  - op: replace
+    path: dev.terminal.imageName
+    value: frontend
+  - op: replace
+    path: dev.deprecatedInteractiveImages.name=backend.entrypoint
+    value:
+    - run
+  - op: replace
+    path: images.backend.image
+    value: other
+  merge:
+    dev:
+      terminal:
+        disabled: true
+        workDir: /app
+- name: interactive
+  merge:
+    dev:
+      interactive:
+        defaultEnabled: true
+`,
+		},
+		{
+			name: "Upgrade with variables in typed fields",
+			in: `version: v1beta9
+vars:
+- name: PORT
+  default: "8080"
+images:
+  backend:
+    image: ${IMAGE}
+dev:
+  ports:
+  - imageName: backend
+    forward:
+    - port: ${PORT}
+  interactive:
+    terminal:
+      imageName: backend
+commands:
+- name: run
+  command: echo ${PORT}
+`,
+			expected: `version: v1beta10
+vars:
+- name: PORT
+  default: "8080"
+images:
+  backend:
+    image: ${IMAGE}
+dev:
+  ports:
+  - imageName: backend
+    forward:
+    - port: ${PORT}
+  terminal:
+    imageName: backend
+    disabled: true
+commands:
+- name: run
+  command: echo ${PORT}
+`,
+		},
+		{
+			name: "Upgrade with variables in boolean fields",
+			in: `version: v1beta9
+images:
+  backend:
+    image: test
+    createPullSecret: ${CREATE}
+    build:
+      docker:
+        skipPush: ${SKIP}
+deployments:
+- name: backend
+  helm:
+    replaceImageTags: $!{REPLACE}
+    values:
+      enabled: ${ENABLED}
+dev:
+  interactive:
+    terminal:
+      imageName: backend
+profiles:
+- name: production
+  merge:
+    images:
+      backend:
+        createPullSecret: ${PRODUCTION}
+`,
+			expected: `version: v1beta10
+images:
+  backend:
+    image: test
+    createPullSecret: ${CREATE}
+    build:
+      docker:
+        skipPush: ${SKIP}
+deployments:
+- name: backend
+  helm:
+    replaceImageTags: $!{REPLACE}
+    values:
+      enabled: ${ENABLED}
+dev:
+  terminal:
+    imageName: backend
+    disabled: true
+profiles:
+- name: production
+  merge:
+    images:
+      backend:
+        createPullSecret: ${PRODUCTION}
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		out, err := UpgradeConfig([]byte(testCase.in), log.Discard)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Equal(t, string(out), testCase.expected, "Unexpected config in testCase %s", testCase.name)
+	}
+}
@@ -33,6 +33,11 @@ func ValidContainerArch(arch latest.ContainerArchitecture) bool {
 		arch == latest.ContainerArchitectureArm64
 }
 
+// ValidateConfig validates the parsed config the same way the loader does after loading it
+func ValidateConfig(config *latest.Config, log log.Logger) error {
+	return validate(config, log)
+}
+
 func validate(config *latest.Config, log log.Logger) error {
 	err := validateRequire(config)
 	if err != nil {
@@ -89,6 +94,14 @@ func validateVars(vars []*latest.Variable) error {
 				return fmt.Errorf("multiple definitions for variable %s found", v.Name)
 			}
 		}
+
+		if v.Source == latest.VariableSourceFile && len(v.Files) == 0 {
+			return fmt.Errorf("vars[%d].files is required for source %s", i, v.Source)
+		} else if v.Source == latest.VariableSourceSecret && v.Secret == "" {
+			return fmt.Errorf("vars[%d].secret is required for source %s", i, v.Source)
+		} else if v.Source == latest.VariableSourceConfigMap && v.ConfigMap == "" {
+			return fmt.Errorf("vars[%d].configMap is required for source %s", i, v.Source)
+		}
 	}
 
 	return nil
@@ -239,9 +252,18 @@ func validateDeployments(config *latest.Config) error {
 		if deployConfig.Helm != nil && (deployConfig.Helm.Chart == nil || deployConfig.Helm.Chart.Name == "") && (deployConfig.Helm.ComponentChart == nil || *deployConfig.Helm.ComponentChart == false) {
 			return errors.Errorf("deployments[%d].helm.chart and deployments[%d].helm.chart.name or deployments[%d].helm.componentChart is required", index, index, index)
 		}
+		if deployConfig.Helm != nil && deployConfig.Helm.Native && deployConfig.Helm.V2 {
+			return errors.Errorf("deployments[%d].helm.native cannot be used together with deployments[%d].helm.v2", index, index)
+		}
+		if deployConfig.Helm != nil && deployConfig.Helm.Native && deployConfig.Helm.Driver != "" && deployConfig.Helm.Driver != "secret" && deployConfig.Helm.Driver != "configmap" && deployConfig.Helm.Driver != "memory" {
+			return errors.Errorf("deployments[%d].helm.driver %s is invalid. Please choose one of %v", index, deployConfig.Helm.Driver, []string{"secret", "configmap", "memory"})
+		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil {
 			return errors.Errorf("deployments[%d].kubectl.manifests is required", index)
 		}
+		if deployConfig.Kubectl != nil && deployConfig.Kubectl.ApplyMode != "" && deployConfig.Kubectl.ApplyMode != latest.KubectlApplyModeClient && deployConfig.Kubectl.ApplyMode != latest.KubectlApplyModeServerSide {
+			return errors.Errorf("deployments[%d].kubectl.applyMode %s is invalid. Please choose one of %v", index, string(deployConfig.Kubectl.ApplyMode), []latest.KubectlApplyMode{latest.KubectlApplyModeClient, latest.KubectlApplyModeServerSide})
+		}
 		if deployConfig.Helm != nil && deployConfig.Helm.ComponentChart != nil && *deployConfig.Helm.ComponentChart == true {
 			// Load override values from path
 			overwriteValues := map[interface{}]interface{}{}
@@ -278,6 +300,55 @@ func validateDeployments(config *latest.Config) error {
 		}
 	}
 
+	return validateDeploymentDependencies(config)
+}
+
+func validateDeploymentDependencies(config *latest.Config) error {
+	deployments := map[string]*latest.DeploymentConfig{}
+	for _, deployConfig := range config.Deployments {
+		deployments[deployConfig.Name] = deployConfig
+	}
+
+	for index, deployConfig := range config.Deployments {
+		for _, dependsOn := range deployConfig.DependsOn {
+			if dependsOn == deployConfig.Name {
+				return errors.Errorf("deployments[%d].dependsOn: deployment %s cannot depend on itself", index, deployConfig.Name)
+			} else if _, ok := deployments[dependsOn]; !ok {
+				return errors.Errorf("deployments[%d].dependsOn: deployment %s does not exist", index, dependsOn)
+			}
+		}
+	}
+
+	// check for cycles
+	visited := map[string]bool{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		for i, parent := range path {
+			if parent == name {
+				return errors.Errorf("deployments: cyclic dependsOn detected: %s", strings.Join(append(path[i:], name), " -> "))
+			}
+		}
+		if visited[name] {
+			return nil
+		}
+
+		for _, dependsOn := range deployments[name].DependsOn {
+			err := visit(dependsOn, append(path, name))
+			if err != nil {
+				return err
+			}
+		}
+
+		visited[name] = true
+		return nil
+	}
+	for _, deployConfig := range config.Deployments {
+		err := visit(deployConfig.Name, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -313,6 +384,9 @@ func validateImages(config *latest.Config) error {
 		if imageConf.RebuildStrategy != latest.RebuildStrategyDefault && imageConf.RebuildStrategy != latest.RebuildStrategyAlways && imageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
 			return errors.Errorf("images.%s.rebuildStrategy %s is invalid. Please choose one of %v", imageConfigName, string(imageConf.RebuildStrategy), []latest.RebuildStrategy{latest.RebuildStrategyAlways, latest.RebuildStrategyIgnoreContextChanges})
 		}
+		if imageConf.Build != nil && imageConf.Build.Kaniko != nil && imageConf.Build.Kaniko.Reuse != nil && imageConf.Build.Kaniko.Reuse.IncrementalContext && !imageConf.Build.Kaniko.Reuse.Enabled {
+			return errors.Errorf("images.%s.build.kaniko.reuse.incrementalContext requires images.%s.build.kaniko.reuse.enabled, because the build context is kept in the reusable build pod", imageConfigName, imageConfigName)
+		}
 		if imageConf.Build != nil && imageConf.Build.Kaniko != nil && imageConf.Build.Kaniko.EnvFrom != nil {
 			for _, v := range imageConf.Build.Kaniko.EnvFrom {
 				o, err := yaml.Marshal(v)
@@ -326,6 +400,13 @@ func validateImages(config *latest.Config) error {
 				}
 			}
 		}
+		if imageConf.Build != nil && imageConf.Build.Lint != nil {
+			for _, severity := range imageConf.Build.Lint.FailOn {
+				if severity != latest.LintSeverityError && severity != latest.LintSeverityWarning && severity != latest.LintSeverityInfo {
+					return errors.Errorf("images.%s.build.lint.failOn %s is invalid. Please choose one of %v", imageConfigName, string(severity), []latest.LintSeverity{latest.LintSeverityError, latest.LintSeverityWarning, latest.LintSeverityInfo})
+				}
+			}
+		}
 		images[imageConf.Image] = true
 	}
 
@@ -16,3 +16,7 @@ type cachedValueVariable struct {
 func (c *cachedValueVariable) Load(definition *latest.Variable) (interface{}, error) {
 	return c.value, nil
 }
+
+func (c *cachedValueVariable) Source() string {
+	return "predefined"
+}
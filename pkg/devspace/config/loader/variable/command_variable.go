@@ -28,6 +28,10 @@ func (c *commandVariable) Load(definition *latest.Variable) (interface{}, error)
 	return variableFromCommand(c.name, definition)
 }
 
+func (c *commandVariable) Source() string {
+	return "command"
+}
+
 func variableFromCommand(varName string, definition *latest.Variable) (interface{}, error) {
 	for _, c := range definition.Commands {
 		if command.ShouldExecuteOnOS(c.OperatingSystem) == false {
@@ -21,10 +21,13 @@ type defaultVariable struct {
 	name  string
 	cache map[string]string
 	log   log.Logger
+
+	source string
 }
 
 func (d *defaultVariable) Load(definition *latest.Variable) (interface{}, error) {
 	if definition.Command != "" || len(definition.Commands) > 0 {
+		d.source = "command"
 		return NewCommandVariable(d.name).Load(definition)
 	}
 
@@ -33,11 +36,13 @@ func (d *defaultVariable) Load(definition *latest.Variable) (interface{}, error)
 
 	// Did we find it in the environment variables?
 	if definition.Source != latest.VariableSourceInput && value != "" {
+		d.source = "env"
 		return valueByType(value, definition.Default)
 	}
 
 	// Is cached
 	if value, ok := d.cache[d.name]; !definition.NoCache && ok {
+		d.source = "cache"
 		return valueByType(value, definition.Default)
 	}
 
@@ -50,9 +55,14 @@ func (d *defaultVariable) Load(definition *latest.Variable) (interface{}, error)
 	if !definition.NoCache {
 		d.cache[d.name] = value
 	}
+	d.source = "input"
 	return valueByType(value, definition.Default)
 }
 
+func (d *defaultVariable) Source() string {
+	return d.source
+}
+
 func valueByType(value string, defaultValue interface{}) (interface{}, error) {
 	if defaultValue == nil {
 		return convertStringValue(value), nil
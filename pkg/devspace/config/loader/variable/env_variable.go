@@ -15,6 +15,8 @@ func NewEnvVariable(name string) Variable {
 
 type envVariable struct {
 	name string
+
+	source string
 }
 
 func (e *envVariable) Load(definition *latest.Variable) (interface{}, error) {
@@ -27,8 +29,14 @@ func (e *envVariable) Load(definition *latest.Variable) (interface{}, error) {
 			return nil, errors.Errorf("couldn't find environment variable %s, but is needed for loading the config", e.name)
 		}
 
+		e.source = "default"
 		return definition.Default, nil
 	}
 
+	e.source = "env"
 	return convertStringValue(value), nil
 }
+
+func (e *envVariable) Source() string {
+	return e.source
+}
@@ -0,0 +1,105 @@
+package variable
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// NewFileVariable creates a new variable that is loaded from dotenv files relative to the config
+func NewFileVariable(name, configPath string, cache map[string]string, log log.Logger) Variable {
+	return &fileVariable{
+		name:       name,
+		configPath: configPath,
+		cache:      cache,
+		log:        log,
+	}
+}
+
+type fileVariable struct {
+	name       string
+	configPath string
+	cache      map[string]string
+	log        log.Logger
+
+	source string
+}
+
+func (f *fileVariable) Load(definition *latest.Variable) (interface{}, error) {
+	if len(definition.Files) == 0 {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no files are specified", f.name, latest.VariableSourceFile)
+	}
+
+	// later files override the values of earlier ones, files that do not exist are skipped
+	key := variableKey(f.name, definition)
+	value, found, source := "", false, "file "+strings.Join(definition.Files, ", ")
+	for _, file := range definition.Files {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(f.configPath), path)
+		}
+
+		values, err := godotenv.Read(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "read %s for variable '%s'", file, f.name)
+		}
+
+		if fileValue, ok := values[key]; ok {
+			value, found, source = fileValue, true, "file "+file
+		}
+	}
+
+	var (
+		loaded interface{}
+		err    error
+	)
+	loaded, f.source, err = loadWithFallback(f.name, definition, f.cache, value, found, source, nil, f.log)
+	return loaded, err
+}
+
+func (f *fileVariable) Source() string {
+	return f.source
+}
+
+// variableKey returns the key of the variable within a dotenv file, secret or configmap
+func variableKey(name string, definition *latest.Variable) string {
+	if definition.Key != "" {
+		return definition.Key
+	}
+
+	return name
+}
+
+// loadWithFallback caches and returns the value if it was found in its source. If the source doesn't contain the
+// value, the cached value and then the default value are used. Errors while loading are returned, because the
+// cached value might be outdated. A nil cache disables caching. The returned source describes where the value
+// was loaded from
+func loadWithFallback(name string, definition *latest.Variable, cache map[string]string, value string, found bool, source string, loadErr error, log log.Logger) (interface{}, string, error) {
+	if loadErr != nil {
+		return nil, "", errors.Wrapf(loadErr, "couldn't load variable '%s' from %s", name, source)
+	} else if found {
+		if cache != nil && !definition.NoCache {
+			cache[name] = value
+		}
+
+		converted, err := valueByType(value, definition.Default)
+		return converted, source, err
+	} else if cached, ok := cache[name]; ok && !definition.NoCache {
+		log.Warnf("Couldn't find variable '%s' in %s, using the cached value", name, source)
+		converted, err := valueByType(cached, definition.Default)
+		return converted, "cache", err
+	} else if definition.Default != nil {
+		return definition.Default, "default", nil
+	}
+
+	return nil, "", errors.Errorf("couldn't find variable '%s' in %s, but is needed for loading the config", name, source)
+}
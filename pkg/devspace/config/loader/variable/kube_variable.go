@@ -0,0 +1,112 @@
+package variable
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewKubeVariable creates a new variable that is loaded from a secret or configmap in the current namespace
+func NewKubeVariable(name string, cache map[string]string, options *PredefinedVariableOptions, log log.Logger) Variable {
+	return &kubeVariable{
+		name:    name,
+		cache:   cache,
+		options: options,
+		log:     log,
+	}
+}
+
+type kubeVariable struct {
+	name    string
+	cache   map[string]string
+	options *PredefinedVariableOptions
+	log     log.Logger
+
+	source string
+}
+
+func (k *kubeVariable) Load(definition *latest.Variable) (interface{}, error) {
+	objectName := definition.Secret
+	if definition.Source == latest.VariableSourceConfigMap {
+		objectName = definition.ConfigMap
+	}
+	if objectName == "" {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no name is specified", k.name, definition.Source)
+	}
+
+	value, found, err := k.loadValue(definition.Source, objectName, variableKey(k.name, definition))
+	source := string(definition.Source) + " " + objectName
+
+	// values of secrets are never cached, so that they don't end up in the generated config
+	cache := k.cache
+	if definition.Source == latest.VariableSourceSecret {
+		delete(k.cache, k.name)
+		cache = nil
+	}
+
+	var loaded interface{}
+	loaded, k.source, err = loadWithFallback(k.name, definition, cache, value, found, source, err, k.log)
+	return loaded, err
+}
+
+func (k *kubeVariable) Source() string {
+	return k.source
+}
+
+// loadValue returns the value of the key within the secret or configmap and if the key was found
+func (k *kubeVariable) loadValue(source latest.VariableSource, name, key string) (string, bool, error) {
+	client, err := k.client()
+	if err != nil {
+		return "", false, err
+	}
+
+	if source == latest.VariableSourceSecret {
+		secret, err := client.KubeClient().CoreV1().Secrets(client.Namespace()).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return "", false, nil
+			}
+
+			return "", false, err
+		}
+
+		value, ok := secret.Data[key]
+		return string(value), ok, nil
+	}
+
+	configMap, err := client.KubeClient().CoreV1().ConfigMaps(client.Namespace()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	if value, ok := configMap.Data[key]; ok {
+		return value, true, nil
+	}
+
+	value, ok := configMap.BinaryData[key]
+	return string(value), ok, nil
+}
+
+// client returns the kube client of the options or creates a new one for the current kube context
+func (k *kubeVariable) client() (kubectl.Client, error) {
+	if k.options.KubeClient != nil {
+		return k.options.KubeClient, nil
+	}
+
+	client, err := kubectl.NewClientFromContext(k.options.KubeContextFlag, k.options.NamespaceFlag, false, k.options.KubeConfigLoader)
+	if err != nil {
+		return nil, errors.Wrap(err, "create kube client")
+	}
+
+	k.options.KubeClient = client
+	return client, nil
+}
@@ -0,0 +1,102 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type kubeVariableTestCase struct {
+	name string
+
+	definition *latest.Variable
+	cache      map[string]string
+	forbidden  bool
+
+	expectedValue  interface{}
+	expectedSource string
+	expectedCache  map[string]string
+	expectedErr    string
+}
+
+func TestKubeVariable(t *testing.T) {
+	testCases := []kubeVariableTestCase{
+		{
+			name:           "secret values are not cached",
+			definition:     &latest.Variable{Source: latest.VariableSourceSecret, Secret: "database", Key: "password"},
+			cache:          map[string]string{"VAR": "old"},
+			expectedValue:  "secret",
+			expectedSource: "secret database",
+			expectedCache:  map[string]string{},
+		},
+		{
+			name:           "configmap values are cached",
+			definition:     &latest.Variable{Source: latest.VariableSourceConfigMap, ConfigMap: "settings", Key: "registry"},
+			cache:          map[string]string{},
+			expectedValue:  "registry.example.com",
+			expectedSource: "configmap settings",
+			expectedCache:  map[string]string{"VAR": "registry.example.com"},
+		},
+		{
+			name:           "cached value if the configmap does not exist",
+			definition:     &latest.Variable{Source: latest.VariableSourceConfigMap, ConfigMap: "missing"},
+			cache:          map[string]string{"VAR": "cached"},
+			expectedValue:  "cached",
+			expectedSource: "cache",
+			expectedCache:  map[string]string{"VAR": "cached"},
+		},
+		{
+			name:           "default value if the key does not exist",
+			definition:     &latest.Variable{Source: latest.VariableSourceSecret, Secret: "database", Key: "missing", Default: "default"},
+			cache:          map[string]string{},
+			expectedValue:  "default",
+			expectedSource: "default",
+			expectedCache:  map[string]string{},
+		},
+		{
+			name:          "api errors are returned",
+			definition:    &latest.Variable{Source: latest.VariableSourceConfigMap, ConfigMap: "settings", Key: "registry"},
+			cache:         map[string]string{"VAR": "cached"},
+			forbidden:     true,
+			expectedCache: map[string]string{"VAR": "cached"},
+			expectedErr:   "couldn't load variable 'VAR' from configmap settings",
+		},
+	}
+
+	for _, testCase := range testCases {
+		kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "testNamespace"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "testNamespace"},
+			Data:       map[string]string{"registry": "registry.example.com"},
+		})
+		if testCase.forbidden {
+			kubeClient.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, kerrors.NewForbidden(schema.GroupResource{Resource: action.GetResource().Resource}, "settings", nil)
+			})
+		}
+
+		variable := NewKubeVariable("VAR", testCase.cache, &PredefinedVariableOptions{KubeClient: &fakekube.Client{Client: kubeClient}}, log.Discard)
+		value, err := variable.Load(testCase.definition)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		} else {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+			assert.Equal(t, value, testCase.expectedValue, "Unexpected value in testCase %s", testCase.name)
+			assert.Equal(t, variable.Source(), testCase.expectedSource, "Unexpected source in testCase %s", testCase.name)
+		}
+
+		assert.DeepEqual(t, testCase.cache, testCase.expectedCache)
+	}
+}
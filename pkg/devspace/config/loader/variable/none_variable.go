@@ -14,6 +14,8 @@ func NewNoneVariable(name string) Variable {
 
 type noneVariable struct {
 	name string
+
+	source string
 }
 
 func (n *noneVariable) Load(definition *latest.Variable) (interface{}, error) {
@@ -21,5 +23,15 @@ func (n *noneVariable) Load(definition *latest.Variable) (interface{}, error) {
 		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but the default value is empty", n.name, latest.VariableSourceNone)
 	}
 
+	// variables with a value are converted to variables with source none and the value as default
+	n.source = "default"
+	if definition.Value != nil {
+		n.source = "value"
+	}
+
 	return definition.Default, nil
 }
+
+func (n *noneVariable) Source() string {
+	return n.source
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/util"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
@@ -29,6 +30,10 @@ type PredefinedVariableOptions struct {
 	NamespaceFlag    string
 	KubeConfigLoader kubeconfig.Loader
 
+	// KubeClient is used to load variables from secrets and configmaps. If it is nil, a client for the
+	// kube context and namespace flags is created when needed
+	KubeClient kubectl.Client
+
 	Profile string
 }
 
@@ -165,6 +170,10 @@ type predefinedVariable struct {
 	options *PredefinedVariableOptions
 }
 
+func (p *predefinedVariable) Source() string {
+	return "predefined"
+}
+
 func (p *predefinedVariable) Load(definition *latest.Variable) (interface{}, error) {
 	name := strings.ToUpper(p.name)
 	getVar, ok := predefinedVars[name]
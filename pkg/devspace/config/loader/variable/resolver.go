@@ -14,6 +14,7 @@ import (
 func NewResolver(cache map[string]string, predefinedVariableOptions *PredefinedVariableOptions, log log.Logger) Resolver {
 	return &resolver{
 		memoryCache:     map[string]interface{}{},
+		sources:         map[string]string{},
 		persistentCache: cache,
 		options:         predefinedVariableOptions,
 		log:             log,
@@ -22,6 +23,7 @@ func NewResolver(cache map[string]string, predefinedVariableOptions *PredefinedV
 
 type resolver struct {
 	memoryCache     map[string]interface{}
+	sources         map[string]string
 	persistentCache map[string]string
 	options         *PredefinedVariableOptions
 	log             log.Logger
@@ -46,6 +48,10 @@ func (r *resolver) ResolvedVariables() map[string]interface{} {
 	return r.memoryCache
 }
 
+func (r *resolver) ResolvedSources() map[string]string {
+	return r.sources
+}
+
 func (r *resolver) ReplaceString(str string) (interface{}, error) {
 	return varspkg.ParseString(str, func(v string) (interface{}, error) {
 		val, err := r.Resolve(v, nil)
@@ -94,6 +100,7 @@ func (r *resolver) ConvertFlags(flags []string) (map[string]interface{}, error)
 		name := strings.TrimSpace(cmdVar[:idx])
 		value := convertStringValue(strings.TrimSpace(cmdVar[idx+1:]))
 		r.memoryCache[name] = value
+		r.sources[name] = "flag"
 		retVariables[name] = value
 	}
 
@@ -162,6 +169,14 @@ func (r *resolver) findVariablesInDefinition(definition *latest.Variable) map[st
 		})
 	}
 
+	// check files, secret, configmap and key
+	for _, str := range append([]string{definition.Secret, definition.ConfigMap, definition.Key}, definition.Files...) {
+		_, _ = varspkg.ParseString(str, func(v string) (interface{}, error) {
+			varsUsed[v] = true
+			return "", nil
+		})
+	}
+
 	// check commands
 	for _, osDef := range definition.Commands {
 		// check command
@@ -223,6 +238,28 @@ func (r *resolver) fillVariableDefinition(definition *latest.Variable) error {
 		}
 	}
 
+	// resolve files
+	for i := range definition.Files {
+		definition.Files[i], err = r.resolveDefinitionStringToString(definition.Files[i], definition)
+		if err != nil {
+			return err
+		}
+	}
+
+	// resolve secret, configmap and key
+	definition.Secret, err = r.resolveDefinitionStringToString(definition.Secret, definition)
+	if err != nil {
+		return err
+	}
+	definition.ConfigMap, err = r.resolveDefinitionStringToString(definition.ConfigMap, definition)
+	if err != nil {
+		return err
+	}
+	definition.Key, err = r.resolveDefinitionStringToString(definition.Key, definition)
+	if err != nil {
+		return err
+	}
+
 	// resolve commands
 	for ci := range definition.Commands {
 		definition.Commands[ci].Command, err = r.resolveDefinitionStringToString(definition.Commands[ci].Command, definition)
@@ -276,16 +313,33 @@ func (r *resolver) resolveDefaultValue(definition *latest.Variable) (interface{}
 	return r.resolveDefinitionString(defaultString, definition)
 }
 
+// fillVariable loads the value of the variable and remembers where the value was loaded from
 func (r *resolver) fillVariable(name string, definition *latest.Variable) (interface{}, error) {
+	variable, err := r.newVariable(name, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := variable.Load(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	r.sources[name] = variable.Source()
+	return value, nil
+}
+
+// newVariable returns the variable that loads the value from the source of the definition
+func (r *resolver) newVariable(name string, definition *latest.Variable) (Variable, error) {
 	// is predefined variable?
 	variable, err := NewPredefinedVariable(name, r.persistentCache, r.options)
 	if err == nil {
-		return variable.Load(definition)
+		return variable, nil
 	}
 
 	// fill variable without definition
 	if definition == nil {
-		return NewUndefinedVariable(name, r.persistentCache, r.log).Load(definition)
+		return NewUndefinedVariable(name, r.persistentCache, r.log), nil
 	}
 
 	// trim space from variable definition
@@ -294,14 +348,18 @@ func (r *resolver) fillVariable(name string, definition *latest.Variable) (inter
 	// fill variable by source
 	switch definition.Source {
 	case latest.VariableSourceEnv:
-		return NewEnvVariable(name).Load(definition)
+		return NewEnvVariable(name), nil
 	case latest.VariableSourceDefault, latest.VariableSourceInput, latest.VariableSourceAll:
-		return NewDefaultVariable(name, r.persistentCache, r.log).Load(definition)
+		return NewDefaultVariable(name, r.persistentCache, r.log), nil
 	case latest.VariableSourceNone:
-		return NewNoneVariable(name).Load(definition)
+		return NewNoneVariable(name), nil
 	case latest.VariableSourceCommand:
-		return NewCommandVariable(name).Load(definition)
+		return NewCommandVariable(name), nil
+	case latest.VariableSourceFile:
+		return NewFileVariable(name, r.options.ConfigPath, r.persistentCache, r.log), nil
+	case latest.VariableSourceSecret, latest.VariableSourceConfigMap:
+		return NewKubeVariable(name, r.persistentCache, r.options, r.log), nil
 	default:
-		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env' or 'none'", name)
+		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env', 'command', 'file', 'secret', 'configmap' or 'none'", name)
 	}
 }
@@ -5,6 +5,9 @@ import "github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 // Variable defines an interface to load a variable
 type Variable interface {
 	Load(definition *latest.Variable) (interface{}, error)
+
+	// Source returns where the value was loaded from after Load was called, e.g. env, cache or input
+	Source() string
 }
 
 // Resolver defines an interface to resolve defined variables
@@ -26,4 +29,7 @@ type Resolver interface {
 
 	// Returns the internal memory cache of the resolver with the resolved variables
 	ResolvedVariables() map[string]interface{}
+
+	// Returns where the resolved variables were loaded from, e.g. env, cache, command, default, input or flag
+	ResolvedSources() map[string]string
 }
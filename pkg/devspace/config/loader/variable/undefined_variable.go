@@ -22,16 +22,20 @@ type undefinedVariable struct {
 	name  string
 	cache map[string]string
 	log   log.Logger
+
+	source string
 }
 
 func (u *undefinedVariable) Load(definition *latest.Variable) (interface{}, error) {
 	// Is in environment?
 	if os.Getenv(u.name) != "" {
+		u.source = "env"
 		return convertStringValue(os.Getenv(u.name)), nil
 	}
 
 	// Is in generated config?
 	if _, ok := u.cache[u.name]; ok {
+		u.source = "cache"
 		return convertStringValue(u.cache[u.name]), nil
 	}
 
@@ -44,9 +48,14 @@ func (u *undefinedVariable) Load(definition *latest.Variable) (interface{}, erro
 		return "", err
 	}
 
+	u.source = "input"
 	return convertStringValue(u.cache[u.name]), nil
 }
 
+func (u *undefinedVariable) Source() string {
+	return u.source
+}
+
 func convertStringValue(value string) interface{} {
 	// Try to convert new value to boolean or integer
 	if i, err := strconv.Atoi(value); err == nil {
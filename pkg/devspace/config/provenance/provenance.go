@@ -0,0 +1,262 @@
+package provenance
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Value is a value of the loaded config and where it came from
+type Value struct {
+	// Path is the path of the value, e.g. deployments[0].helm.values.replicas
+	Path string
+
+	// Value is the final value
+	Value interface{}
+
+	// Origin are the steps that set or changed the value in the order they were applied, e.g.
+	// devspace.yaml:12, profile production (merge, devspace.yaml:40) and variable IMAGE (env)
+	Origin []string
+}
+
+// Tracker tracks where the values of a config come from while the config is loaded. Values are identified
+// by a key that is similar to the path, but identifies list entries with a name by their name instead of
+// their index, so that entries can be followed if profiles add or remove other entries
+type Tracker struct {
+	lines   map[string]string
+	origins map[string][]string
+	config  map[interface{}]interface{}
+}
+
+// NewTracker creates a new tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		lines:   map[string]string{},
+		origins: map[string][]string{},
+	}
+}
+
+// RecordFile sets the origin of every value within the yaml file to its position within the file
+func (t *Tracker) RecordFile(name string, content []byte) error {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(content, document)
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", name)
+	} else if len(document.Content) == 0 {
+		return nil
+	}
+
+	walkNode(document.Content[0], "", func(key string, node *yaml.Node) {
+		line := name + ":" + strconv.Itoa(node.Line)
+		t.lines[key] = line
+		t.origins[key] = []string{line}
+	})
+	return nil
+}
+
+// Line returns the position of the value with the given key in the recorded files
+func (t *Tracker) Line(key string) string {
+	return t.lines[key]
+}
+
+// Record appends a step to the origin of every value that was added or changed between before and after and
+// of every value whose key is in keys. Origins of removed values are dropped. The step function receives the
+// key of the value and the value before the change at the same key or the closest parent key
+func (t *Tracker) Record(before, after map[interface{}]interface{}, keys map[string]bool, step func(key string, before interface{}) string) {
+	beforeValues := map[string]interface{}{}
+	walkValue(before, "", "", func(_, key string, value interface{}) {
+		beforeValues[key] = value
+	})
+
+	afterValues := map[string]interface{}{}
+	walkValue(after, "", "", func(_, key string, value interface{}) {
+		afterValues[key] = value
+
+		beforeValue, ok := beforeValues[key]
+		if ok && !keys[key] && reflect.DeepEqual(beforeValue, value) {
+			return
+		} else if !ok {
+			// the value might be part of a new object or replace a parent value
+			for parent := parentKey(key); parent != ""; parent = parentKey(parent) {
+				if parentValue, ok := beforeValues[parent]; ok {
+					beforeValue = parentValue
+					break
+				}
+			}
+		}
+
+		if !ok {
+			delete(t.origins, key)
+		}
+		if description := step(key, beforeValue); description != "" {
+			t.origins[key] = append(t.origins[key], description)
+		}
+	})
+
+	for key := range t.origins {
+		if _, ok := afterValues[key]; !ok {
+			delete(t.origins, key)
+		}
+	}
+}
+
+// SetConfig sets the final config the values are explained for
+func (t *Tracker) SetConfig(config map[interface{}]interface{}) {
+	t.config = config
+}
+
+// Explain returns all values of the final config whose path starts with the given path and where they came from
+func (t *Tracker) Explain(path string) []*Value {
+	values := []*Value{}
+	walkValue(t.config, "", "", func(valuePath, key string, value interface{}) {
+		if path != "" && valuePath != path && !strings.HasPrefix(valuePath, path+".") && !strings.HasPrefix(valuePath, path+"[") {
+			return
+		}
+
+		values = append(values, &Value{
+			Path:   valuePath,
+			Value:  value,
+			Origin: t.origins[key],
+		})
+	})
+
+	return values
+}
+
+// Keys returns the keys of all values within the object, prefixed by the given key
+func Keys(prefix string, object interface{}) map[string]bool {
+	keys := map[string]bool{}
+	walkValue(object, prefix, prefix, func(_, key string, _ interface{}) {
+		keys[key] = true
+	})
+
+	return keys
+}
+
+// walkValue calls fn for every scalar value and empty object or list with its path and key
+func walkValue(value interface{}, path, key string, fn func(path, key string, value interface{})) {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		if len(typed) == 0 {
+			fn(path, key, value)
+			return
+		}
+
+		keys := []string{}
+		values := map[string]interface{}{}
+		for k, v := range typed {
+			keys = append(keys, fmt.Sprint(k))
+			values[fmt.Sprint(k)] = v
+		}
+
+		sort.Strings(keys)
+		for _, k := range keys {
+			walkValue(values[k], joinKey(path, k), joinKey(key, k), fn)
+		}
+	case map[string]interface{}:
+		converted := map[interface{}]interface{}{}
+		for k, v := range typed {
+			converted[k] = v
+		}
+
+		walkValue(converted, path, key, fn)
+	case []interface{}:
+		if len(typed) == 0 {
+			fn(path, key, value)
+			return
+		}
+
+		for i, item := range typed {
+			walkValue(item, path+"["+strconv.Itoa(i)+"]", key+listKey(i, entryName(item)), fn)
+		}
+	default:
+		fn(path, key, value)
+	}
+}
+
+// walkNode calls fn for every scalar node and empty mapping or sequence with its key
+func walkNode(node *yaml.Node, key string, fn func(key string, node *yaml.Node)) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			fn(key, node)
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Tag == "!!merge" {
+				continue
+			}
+
+			walkNode(node.Content[i+1], joinKey(key, node.Content[i].Value), fn)
+		}
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			fn(key, node)
+			return
+		}
+
+		for i, item := range node.Content {
+			name := ""
+			if item.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(item.Content); j += 2 {
+					if item.Content[j].Value == "name" && item.Content[j+1].Kind == yaml.ScalarNode {
+						name = item.Content[j+1].Value
+					}
+				}
+			}
+
+			walkNode(item, key+listKey(i, name), fn)
+		}
+	default:
+		fn(key, node)
+	}
+}
+
+func entryName(entry interface{}) string {
+	switch typed := entry.(type) {
+	case map[interface{}]interface{}:
+		name, _ := typed["name"].(string)
+		return name
+	case map[string]interface{}:
+		name, _ := typed["name"].(string)
+		return name
+	}
+
+	return ""
+}
+
+func listKey(index int, name string) string {
+	if name != "" {
+		return "[name=" + name + "]"
+	}
+
+	return "[" + strconv.Itoa(index) + "]"
+}
+
+func joinKey(key, child string) string {
+	if key == "" {
+		return child
+	}
+
+	return key + "." + child
+}
+
+// parentKey returns the key of the parent object or list of the value with the given key
+func parentKey(key string) string {
+	idx := strings.LastIndexAny(key, ".[")
+	if idx <= 0 {
+		return ""
+	}
+
+	return key[:idx]
+}
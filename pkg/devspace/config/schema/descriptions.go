@@ -0,0 +1,276 @@
+// Code generated by hack/schema. DO NOT EDIT.
+
+package schema
+
+// descriptions holds the doc comments of the latest config types and fields
+var descriptions = map[string]string{
+	"AutoReloadConfig":                           "AutoReloadConfig defines the struct for auto reloading devspace with additional paths",
+	"AutoScalingConfig":                          "AutoScalingConfig holds the autoscaling config of a component",
+	"AutoScalingHorizontalConfig":                "AutoScalingHorizontalConfig holds the horizontal autoscaling config of a component",
+	"BandwidthLimits":                            "BandwidthLimits defines the struct for specifying the sync bandwidth limits",
+	"BuildConfig":                                "BuildConfig defines the build process for an image. Only one of the options below can be specified.",
+	"BuildConfig.BuildKit":                       "If buildKit is specified, DevSpace will build the image either in-cluster or locally with BuildKit",
+	"BuildConfig.Custom":                         "If custom is specified, DevSpace will build the image with the help of a custom script.",
+	"BuildConfig.Disabled":                       "This overrides other options and is able to disable the build for this image. Useful if you just want to select the image in a sync path or via devspace enter --image",
+	"BuildConfig.Docker":                         "If docker is specified, DevSpace will build the image using the local docker daemon",
+	"BuildConfig.Kaniko":                         "If kaniko is specified, DevSpace will build the image in-cluster with kaniko",
+	"BuildConfig.Lint":                           "If lint is specified, DevSpace will check the Dockerfile for common problems before building",
+	"BuildKitConfig":                             "BuildKitConfig tells the DevSpace CLI to",
+	"BuildKitConfig.Args":                        "Additional arguments to call docker buildx build with",
+	"BuildKitConfig.Command":                     "Override the base command to create a builder and build images. Defaults to [\"docker\", \"buildx\"]",
+	"BuildKitConfig.InCluster":                   "If specified, DevSpace will use BuildKit to build the image within the cluster",
+	"BuildKitConfig.Options":                     "Additional build options",
+	"BuildKitConfig.PreferMinikube":              "If false, will not try to use the minikube docker daemon to build the image",
+	"BuildKitConfig.SkipPush":                    "If this is true, DevSpace will not push any images",
+	"BuildKitInClusterConfig":                    "BuildKitInClusterConfig holds the buildkit builder config",
+	"BuildKitInClusterConfig.CreateArgs":         "Additional args to create the builder with.",
+	"BuildKitInClusterConfig.Image":              "The docker image to use for the BuildKit deployment",
+	"BuildKitInClusterConfig.IncrementalContext": "If enabled, DevSpace keeps the build context in the BuildKit builder pod and only uploads changed files",
+	"BuildKitInClusterConfig.Name":               "Name is the name of the builder to use. If omitted, DevSpace will try to create or reuse a builder in the form devspace-$NAMESPACE",
+	"BuildKitInClusterConfig.Namespace":          "Namespace where to create the builder deployment in. Defaults to the current active namespace.",
+	"BuildKitInClusterConfig.NoCreate":           "By default, DevSpace will try to create a new builder if it cannot be found. If this is true, DevSpace will fail if the specified builder cannot be found.",
+	"BuildKitInClusterConfig.NoLoad":             "If enabled, DevSpace will not try to load the built image into the local docker daemon if skip push is defined",
+	"BuildKitInClusterConfig.NoRecreate":         "By default, DevSpace will try to recreate the builder if the builder configuration in the devspace.yaml differs from the actual builder configuration. If this is true, DevSpace will not try to do that.",
+	"BuildKitInClusterConfig.NodeSelector":       "The node selector to use for the BuildKit deployment",
+	"BuildKitInClusterConfig.Rootless":           "If enabled will create a rootless builder deployment.",
+	"BuildOptions":                               "BuildOptions defines options for building Docker images",
+	"ChartConfig":                                "ChartConfig defines the helm chart options",
+	"CommandConfig":                              "CommandConfig defines the command specification",
+	"CommandConfig.AppendArgs":                   "AppendArgs will append arguments passed to the DevSpace command automatically to the specified command.",
+	"CommandConfig.Args":                         "Args are optional and if defined, command is not executed within a shell and rather directly.",
+	"CommandConfig.Command":                      "Command is the command that should be executed. For example: 'echo 123'",
+	"CommandConfig.Description":                  "Description describes what the command is doing and can be seen in `devspace list commands`",
+	"CommandConfig.If":                           "If removes the command from the config if the expression evaluates to false",
+	"CommandConfig.Name":                         "Name is the name of a command that is used via `devspace run NAME`",
+	"ComponentConfig":                            "ComponentConfig holds the component information",
+	"Config":                                     "Config defines the configuration",
+	"Config.Commands":                            "Commands are custom commands that can be executed via 'devspace run COMMAND'",
+	"Config.Dependencies":                        "Dependencies are sub devspace projects that lie in a local folder or can be accessed via git",
+	"Config.Deployments":                         "Deployments is an ordered list of deployments to deploy via helm, kustomize or kubectl.",
+	"Config.Dev":                                 "Dev holds development configuration for the 'devspace dev' command.",
+	"Config.Hooks":                               "Hooks are actions that are executed at certain points within the pipeline. Hooks are ordered and are executed in the order they are specified.",
+	"Config.Images":                              "Images holds configuration of how devspace should build images",
+	"Config.Imports":                             "Imports are partial configs from local paths, urls or git repositories that are merged into this config before profiles are applied",
+	"Config.Profiles":                            "Profiles can be used to change the current configuration and change the behaviour of devspace",
+	"Config.PullSecrets":                         "PullSecrets are image pull secrets that will be created by devspace in the target namespace during devspace dev or devspace deploy",
+	"Config.Require":                             "Require defines what DevSpace, plugins and command versions are needed to use this config",
+	"Config.Secrets":                             "Secrets configures who can decrypt the values that are marked with !encrypted in this config",
+	"Config.Vars":                                "Vars are config variables that can be used inside other config sections to replace certain values dynamically",
+	"Config.Version":                             "Version holds the config version",
+	"ContainerConfig":                            "ContainerConfig holds the configurations of a container",
+	"CustomConfig":                               "CustomConfig tells the DevSpace CLI to build with a custom build script",
+	"CustomConfigCommand":                        "CustomConfigCommand holds the information about a command on a specific operating system",
+	"DependencyConfig":                           "DependencyConfig defines the devspace dependency",
+	"DependencyDev":                              "DependencyDev specifies which parts of the dependency dev config should be reused",
+	"DependencyDev.Ports":                        "If ports is true, DevSpace will forward and reverse forward the specified ports in the dependency's dev.ports config.",
+	"DependencyDev.ReplacePods":                  "If replacePods is true, DevSpace will replace the specified pods from the dependency's dev.replacePods config",
+	"DependencyDev.Sync":                         "If sync is true, DevSpace will run the specified sync paths from the dependency's dev.sync config",
+	"DependencyVar":                              "DependencyVar holds an override value for a config variable",
+	"DependencyVar.Name":                         "Name is the name of the variable",
+	"DependencyVar.Value":                        "Value is the value to override",
+	"DeploymentConfig":                           "DeploymentConfig defines the configuration how the devspace should be deployed",
+	"DeploymentConfig.DependsOn":                 "DependsOn are the names of other deployments that need to be deployed before this deployment",
+	"DeploymentConfig.If":                        "If removes the deployment from the config if the expression evaluates to false",
+	"DeploymentConfig.WaitForReady":              "WaitForReady waits after deploying until the rollouts of all deployed workloads are complete. Deployments that other deployments depend on are waited for by default",
+	"DevConfig":                                  "DevConfig defines the devspace deployment",
+	"DevConfig.InteractiveEnabled":               "DEPRECATED: Only used for backwards compatibility with older config versions",
+	"DevConfig.InteractiveImages":                "DEPRECATED: Only used for backwards compatibility with older config versions",
+	"DevConfig.ReplacePods":                      "Replace pods will replace the selected target pod/container with a new image and optionally apply pod patches.",
+	"DockerConfig":                               "DockerConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost",
+	"HelmConfig":                                 "HelmConfig defines the specific helm options used during deployment",
+	"HookConfig":                                 "HookConfig defines a hook",
+	"HookConfig.Args":                            "Args are additional arguments passed together with the command to execute.",
+	"HookConfig.Background":                      "If true, the hook will be executed in the background.",
+	"HookConfig.Command":                         "Command is the base command that is either executed locally or in a remote container. Command is mutually exclusive with other hook actions. In the case this is defined together with where.container, DevSpace will until the target container is running and only then execute the command. If the container does not start in time, DevSpace will fail.",
+	"HookConfig.Download":                        "Same as Upload, but with this option DevSpace will download files or folders from a remote container.",
+	"HookConfig.If":                              "If removes the hook from the config if the expression evaluates to false",
+	"HookConfig.Logs":                            "If logs is defined will print the logs of the target container. This is useful for containers that should finish like init containers or job pods. Otherwise this hook will never terminate.",
+	"HookConfig.OperatingSystem":                 "If an operating system is defined, the hook will only be executed for the given os. All supported golang OS types are supported and multiple can be combined with ','.",
+	"HookConfig.Silent":                          "If true, the hook will not output anything to the standard out of DevSpace except for the case when the hook fails, where DevSpace will show the error including the captured output streams of the hook.",
+	"HookConfig.Upload":                          "If Upload is specified, DevSpace will upload certain local files or folders into a remote container.",
+	"HookConfig.Wait":                            "If wait is defined the hook will wait until the matched pod or container is running or is terminated with a certain exit code.",
+	"HookConfig.When":                            "Specifies when the hook should be run.",
+	"HookConfig.Where":                           "Specifies where the hook should be run. If this is ommitted DevSpace expects a local command hook.",
+	"HookContainer":                              "HookContainer defines how to select one or more containers to execute a hook in",
+	"HookLogsConfig":                             "HookLogsConfig defines a hook logs config",
+	"HookLogsConfig.TailLines":                   "If set, the number of lines from the end of the logs to show. If not specified, logs are shown from the creation of the container",
+	"HookSyncConfig":                             "HookSyncConfig defines a hook upload config",
+	"HookWaitConfig":                             "HookWaitConfig defines a hook wait config",
+	"HookWaitConfig.Running":                     "If running is true, will wait until the matched containers are running. Can be used together with terminatedWithCode.",
+	"HookWaitConfig.TerminatedWithCode":          "If terminatedWithCode is not nil, will wait until the matched containers are terminated with the given exit code. If the container has exited with a different exit code, the hook will fail. Can be used together with running.",
+	"HookWaitConfig.Timeout":                     "The amount of seconds to wait until the hook will fail. Defaults to 150 seconds.",
+	"HookWhenAtConfig":                           "HookWhenAtConfig defines at which stage the hook should be executed",
+	"HookWhenConfig":                             "HookWhenConfig defines when the hook should be executed",
+	"HookWhereConfig":                            "HookWhereConfig defines where to execute the hook",
+	"ImageConfig":                                "ImageConfig defines the image specification",
+	"ImageConfig.AppendDockerfileInstructions":   "These instructions will be appended to the Dockerfile that is build at the current build target and are appended before the entrypoint and cmd instructions",
+	"ImageConfig.Build":                          "Specific build options how to build the specified image",
+	"ImageConfig.Cmd":                            "Cmd specifies the arguments for the entrypoint that will be appended during build in memory to the dockerfile",
+	"ImageConfig.Context":                        "The context path to build with",
+	"ImageConfig.CreatePullSecret":               "CreatePullSecret specifies if a pull secret should be created for this image in the target namespace. Defaults to true",
+	"ImageConfig.Dockerfile":                     "Specifies a path (relative or absolute) to the dockerfile",
+	"ImageConfig.Entrypoint":                     "Entrypoint specifies an entrypoint that will be appended to the dockerfile during image build in memory. Example: [\"sleep\", \"99999\"]",
+	"ImageConfig.If":                             "If removes the image from the config if the expression evaluates to false",
+	"ImageConfig.Image":                          "Image is the complete image name including registry and repository for example myregistry.com/mynamespace/myimage",
+	"ImageConfig.InjectRestartHelper":            "If true injects a small restart script into the container and wraps the entrypoint of that container, so that devspace is able to restart the complete container during sync. Please make sure you either have an Entrypoint defined in the devspace config or in the dockerfile for this image, otherwise devspace will fail.",
+	"ImageConfig.RebuildStrategy":                "RebuildStrategy is used to determine when DevSpace should rebuild an image. By default, devspace will rebuild an image if one of the following conditions is true:\n- The dockerfile has changed\n- The configuration within the devspace.yaml for the image has changed\n- A file within the docker context (excluding .dockerignore rules) has changed This option is ignored for custom builds.",
+	"ImageConfig.RestartHelperPath":              "If specified DevSpace will load the restart helper from this location instead of using the bundled one within DevSpace. Can be either a local path or an URL where to find the restart helper.",
+	"ImageConfig.Tags":                           "Tags is an array that specifes all tags that should be build during the build process. If this is empty, devspace will generate a random tag",
+	"ImportConfig":                               "ImportConfig defines a partial config that is imported into this config",
+	"ImportConfig.Source":                        "Source is where to load the imported config from. If the path points to a folder, the devspace.yaml or the file specified in configName within that folder is imported",
+	"IngressConfig":                              "IngressConfig holds the configuration of a component ingress",
+	"IngressRuleConfig":                          "IngressRuleConfig holds the port configuration of a component service",
+	"IngressRuleConfig.TLS":                      "DEPRECATED",
+	"InitialSyncCompareBy":                       "InitialSyncCompareBy is the type of how a change should be determined during the initial sync",
+	"InitialSyncStrategy":                        "InitialSyncStrategy is the type of a initial sync strategy",
+	"InteractiveImageConfig":                     "InteractiveImageConfig describes the interactive mode options for an image",
+	"KanikoAdditionalMount":                      "KanikoAdditionalMount tells devspace how the additional mount of the kaniko pod should look like",
+	"KanikoAdditionalMount.ConfigMap":            "The configMap that should be mounted",
+	"KanikoAdditionalMount.MountPath":            "Path within the container at which the volume should be mounted.  Must not contain ':'.",
+	"KanikoAdditionalMount.ReadOnly":             "Mounted read-only if true, read-write otherwise (false or unspecified). Defaults to false. +optional",
+	"KanikoAdditionalMount.Secret":               "The secret that should be mounted",
+	"KanikoAdditionalMount.SubPath":              "Path within the volume from which the container's volume should be mounted. Defaults to \"\" (volume's root). +optional",
+	"KanikoAdditionalMountConfigMap.DefaultMode": "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set. +optional",
+	"KanikoAdditionalMountConfigMap.Items":       "If unspecified, each key-value pair in the Data field of the referenced ConfigMap will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present. If a key is specified which is not present in the ConfigMap, the volume setup will error unless it is marked optional. Paths must be relative and may not contain the '..' path or start with '..'. +optional",
+	"KanikoAdditionalMountConfigMap.Name":        "Name of the configmap +optional",
+	"KanikoAdditionalMountKeyToPath.Key":         "The key to project.",
+	"KanikoAdditionalMountKeyToPath.Mode":        "Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set. +optional",
+	"KanikoAdditionalMountKeyToPath.Path":        "The relative path of the file to map the key to. May not be an absolute path. May not contain the path element '..'. May not start with the string '..'.",
+	"KanikoAdditionalMountSecret.DefaultMode":    "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set. +optional",
+	"KanikoAdditionalMountSecret.Items":          "If unspecified, each key-value pair in the Data field of the referenced Secret will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present. If a key is specified which is not present in the Secret, the volume setup will error unless it is marked optional. Paths must be relative and may not contain the '..' path or start with '..'. +optional",
+	"KanikoAdditionalMountSecret.Name":           "Name of the secret in the pod's namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret +optional",
+	"KanikoConfig":                               "KanikoConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost",
+	"KanikoConfig.AdditionalMounts":              "additional mounts that will be added to the build pod",
+	"KanikoConfig.Annotations":                   "extra annotations that will be added to the build pod",
+	"KanikoConfig.Args":                          "additional arguments that should be passed to kaniko",
+	"KanikoConfig.Cache":                         "if a cache repository should be used. defaults to true",
+	"KanikoConfig.Command":                       "replace the starting command for the kaniko container",
+	"KanikoConfig.Env":                           "extra environment variables that will be added to the build kaniko container Will populate the env.value field.",
+	"KanikoConfig.EnvFrom":                       "extra environment variables from configmap or secret that will be added to the build kaniko container Will populate the env.valueFrom field.",
+	"KanikoConfig.Image":                         "the image name of the kaniko pod to use",
+	"KanikoConfig.InitEnv":                       "extra environment variables that will be added to the build init container",
+	"KanikoConfig.InitImage":                     "the image to init the kaniko pod",
+	"KanikoConfig.Insecure":                      "if true pushing to insecure registries is allowed",
+	"KanikoConfig.Labels":                        "extra labels that will be added to the build pod",
+	"KanikoConfig.Namespace":                     "the namespace where the kaniko pod should be run",
+	"KanikoConfig.NodeSelector":                  "the node selector to use for the kaniko pod",
+	"KanikoConfig.Options":                       "other build options that will be passed to the kaniko pod",
+	"KanikoConfig.PullSecret":                    "the pull secret to mount by default",
+	"KanikoConfig.Resources":                     "the resources that should be set on the kaniko pod",
+	"KanikoConfig.Reuse":                         "if enabled, devspace will keep a warm build pod running and reuse it for subsequent builds",
+	"KanikoConfig.ServiceAccount":                "the service account to use for the kaniko pod",
+	"KanikoConfig.SkipPullSecretMount":           "If true will skip mounting the pull secret",
+	"KanikoConfig.SnapshotMode":                  "the snapshot mode kaniko should use. defaults to time",
+	"KanikoPodResources":                         "KanikoPodResources describes the resources section of the started kaniko pod",
+	"KanikoPodResources.Limits":                  "The limits part of the resources",
+	"KanikoPodResources.Requests":                "The requests part of the resources",
+	"KanikoReuseConfig":                          "KanikoReuseConfig tells devspace how to reuse a long-lived kaniko build pod",
+	"KanikoReuseConfig.Enabled":                  "if true the build pod will be reused across builds",
+	"KanikoReuseConfig.IdleTimeout":              "the time in seconds after which an idle build pod is removed. defaults to 600",
+	"KanikoReuseConfig.Image":                    "the image of the reusable build pod, needs to contain a shell. defaults to the kaniko debug image",
+	"KanikoReuseConfig.IncrementalContext":       "if true the build context is kept in the build pod and only changed files are uploaded. requires enabled",
+	"KubectlApplyMode":                           "KubectlApplyMode is the mode the kubectl deployer applies manifests with",
+	"KubectlConfig":                              "KubectlConfig defines the specific kubectl options used during deployment",
+	"KubectlConfig.ApplyMode":                    "ApplyMode defines how the manifests are applied. By default DevSpace uses kubectl apply, with serverSide DevSpace applies the objects itself via server-side apply",
+	"KubectlConfig.Prune":                        "Prune deletes objects that were applied by a previous deployment but are not part of the manifests anymore. Defaults to true",
+	"KustomizeMode":                              "KustomizeMode defines if and how the manifests of a kubectl deployment are rendered with kustomize. In the config it is either a boolean or embedded",
+	"LintConfig":                                 "LintConfig defines the static checks of the Dockerfile that are run before building",
+	"LintConfig.Disable":                         "Names of checks that should be skipped",
+	"LintConfig.Enabled":                         "If true, DevSpace checks the Dockerfile before building the image",
+	"LintConfig.FailOn":                          "Findings with one of these severities fail the build. Defaults to error",
+	"LintSeverity":                               "LintSeverity is the severity of a Dockerfile lint finding",
+	"LogsConfig":                                 "LogsConfig specifies the logs options for devspace dev",
+	"LogsSelector":                               "LogsSelector holds configuration how to select a log target",
+	"OpenConfig":                                 "OpenConfig defines what to open after services have been started",
+	"PatchConfig":                                "PatchConfig describes a config patch and how it should be applied",
+	"PodPatch":                                   "PodPatch will patch a pod's owning ReplicaSet, Deployment or StatefulSet with the givens patches or image",
+	"PodPatch.Image":                             "If image is specified, DevSpace will replace the target image",
+	"PodPatch.Patches":                           "Regular JSON patches that will be applied to the target Deployment, StatefulSet or ReplicaSet",
+	"PortForwardingConfig":                       "PortForwardingConfig defines the ports for a port forwarding to a DevSpace",
+	"PortForwardingConfig.Arch":                  "Target Container architecture to use for the devspacehelper (currently amd64 or arm64). Defaults to amd64",
+	"PortForwardingConfig.If":                    "If removes the port forwarding from the config if the expression evaluates to false",
+	"PortMapping":                                "PortMapping defines the ports for a PortMapping",
+	"ProfileActivation":                          "ProfileActivation defines rules that automatically activate a profile when evaluated to true",
+	"ProfileActivation.All":                      "All is a list of activation rules that must all evaluate to true to activate the profile",
+	"ProfileActivation.Any":                      "Any is a list of activation rules of which at least one must evaluate to true to activate the profile",
+	"ProfileActivation.Environment":              "Environment defines key/value pairs where the key is the name of the environment variable and the value is a regular expression used to match the variable's value. When multiple keys are specified, they must all evaluate to true to activate the profile.",
+	"ProfileActivation.Files":                    "Files is a list of paths relative to the devspace.yaml that must all exist to activate the profile",
+	"ProfileActivation.GitBranch":                "GitBranch is a glob pattern (e.g. release/*) that must match the currently checked out git branch",
+	"ProfileActivation.KubeContext":              "KubeContext is a glob pattern (e.g. prod-*) that must match the name of the current kube context",
+	"ProfileActivation.Namespace":                "Namespace is a glob pattern that must match the namespace devspace uses",
+	"ProfileActivation.OS":                       "OS is a glob pattern that must match the operating system devspace runs on (e.g. linux, darwin or windows)",
+	"ProfileActivation.Vars":                     "Vars defines key/value pairs where the key is the name of a config variable and the value is a regular expression used to match the resolved value of the variable",
+	"ProfileConfig":                              "ProfileConfig defines a profile config",
+	"ProfileConfigStructure":                     "ProfileConfigStructure is the base structure used to validate profiles",
+	"ProfileParent":                              "ProfileParent defines where to load the profile from",
+	"PullSecretConfig":                           "PullSecretConfig defines a pull secret that should be created by DevSpace",
+	"PullSecretConfig.Email":                     "The optional email to use",
+	"PullSecretConfig.Password":                  "The password to use for the registry. If this is empty, devspace will try to receive the auth data from the local docker",
+	"PullSecretConfig.Registry":                  "The registry to create the image pull secret for. e.g. gcr.io",
+	"PullSecretConfig.Secret":                    "The secret to create",
+	"PullSecretConfig.ServiceAccounts":           "The service account to add the secret to",
+	"PullSecretConfig.Username":                  "The username of the registry. If this is empty, devspace will try to receive the auth data from the local docker",
+	"RebuildStrategy":                            "RebuildStrategy is the type of a image rebuild strategy",
+	"ReplacePod":                                 "ReplacePod will replace the selected target pod/container with a new image and optionally apply pod patches.",
+	"RequireCommand.Name":                        "Name is the name of the command that should be installed",
+	"RequireCommand.Version":                     "Version constraint of the command that should be installed",
+	"RequireCommand.VersionArgs":                 "VersionArgs are the arguments to retrieve the version of the command",
+	"RequireCommand.VersionRegEx":                "VersionRegEx is the regex that is used to parse the version",
+	"RequireConfig.Commands":                     "Commands specifies an array of commands that need to be installed locally to use this config",
+	"RequireConfig.DevSpace":                     "DevSpace specifies the DevSpace version constraint that is needed to use this config",
+	"RequireConfig.Plugins":                      "Plugins specifies an array of plugins that need to be installed locally",
+	"RequirePlugin.Name":                         "Name of the plugin that should be installed",
+	"RequirePlugin.Version":                      "Version constraint of the plugin that should be installed",
+	"RollingUpdateConfig":                        "RollingUpdateConfig holds the configuration for rolling updates",
+	"SecretsConfig":                              "SecretsConfig defines how encrypted values are encrypted",
+	"SecretsConfig.Recipients":                   "Recipients are paths to armored public pgp keys relative to the devspace.yaml. New secrets are encrypted for these keys in addition to the key of the current user, so that every recipient can decrypt them",
+	"ServiceConfig":                              "ServiceConfig holds the configuration of a component service",
+	"ServicePortConfig":                          "ServicePortConfig holds the port configuration of a component service",
+	"SourceConfig":                               "SourceConfig defines the dependency source",
+	"SyncCommand":                                "SyncCommand holds a command definition",
+	"SyncConfig":                                 "SyncConfig defines the paths for a SyncFolder",
+	"SyncConfig.Arch":                            "Target Container architecture to use for the devspacehelper (currently amd64 or arm64). Defaults to amd64",
+	"SyncConfig.If":                              "If removes the sync from the config if the expression evaluates to false",
+	"SyncConfig.ThrottleChangeDetection":         "If greater zero, describes the amount of milliseconds to wait after each checked 100 files",
+	"SyncExecCommand":                            "SyncExecCommand holds the configuration of commands that should be executed when files / folders are change",
+	"SyncExecCommand.OnBatch":                    "OnBatch executes the given command after a batch of changes has been processed. DevSpace will wait for the command to finish and then will continue execution. This is useful for commands that shouldn't be executed after every single change that may take a little bit longer like recompiling etc.",
+	"SyncExecCommand.OnDirCreate":                "OnDirCreate is invoked after every directory that is created. DevSpace will wait for the command to successfully finish and then will continue to upload files & create folders",
+	"SyncExecCommand.OnFileChange":               "OnFileChange is invoked after every file change. DevSpace will wait for the command to successfully finish and then will continue to upload files & create folders",
+	"SyncOnDownload":                             "SyncOnDownload defines the struct for the command that should be executed when files / folders are downloaded",
+	"SyncOnUpload":                               "SyncOnUpload defines the struct for the command that should be executed when files / folders are uploaded",
+	"SyncOnUpload.ExecRemote":                    "Defines what commands should be executed on the container side if a change is uploaded and applied in the target container",
+	"SyncOnUpload.RestartContainer":              "If true restart container will try to restart the container after a change has been made. Make sure that images.*.injectRestartHelper is enabled for the container that should be restarted or the devspace-restart-helper script is present in the container root folder.",
+	"Terminal":                                   "Terminal describes the terminal options",
+	"Terminal.Disabled":                          "If disabled is true, DevSpace will not use the terminal",
+	"Variable":                                   "Variable describes the var definition",
+	"Variable.Args":                              "Args are optional args that will be used for the command",
+	"Variable.Command":                           "Command is the command how to retrieve the variable. If args is omitted, command is parsed as a shell command.",
+	"Variable.Commands":                          "Commands are additional commands that can be used to run a different command on a different operating system.",
+	"Variable.ConfigMap":                         "ConfigMap is the name of the configmap in the current namespace the variable is read from if source is configmap",
+	"Variable.Default":                           "Default is the default value the variable should have if not set by the user",
+	"Variable.Files":                             "Files are the dotenv files the variable is read from if source is file. Paths are relative to the config and later files override the values of earlier ones.",
+	"Variable.Key":                               "Key is the key within the dotenv files, secret or configmap. Defaults to the name of the variable",
+	"Variable.Secret":                            "Secret is the name of the secret in the current namespace the variable is read from if source is secret",
+	"Variable.Source":                            "Source defines where the variable should be taken from",
+	"Variable.Value":                             "Value is a shortcut for using source: none and default: my-value",
+	"VariableSource":                             "VariableSource is type of a variable source",
+	"VolumeConfig":                               "VolumeConfig holds the configuration for a specific volume",
+	"VolumeMountConfig":                          "VolumeMountConfig holds the configuration for a specific mount path",
+	"VolumeMountVolumeConfig":                    "VolumeMountVolumeConfig holds the configuration for a specfic mount path volume",
+	"WaitForReadyConfig":                         "WaitForReadyConfig defines how to wait for the workloads of a deployment",
+	"WaitForReadyConfig.Disabled":                "If true, DevSpace doesn't wait for the workloads, even if other deployments depend on this deployment",
+	"WaitForReadyConfig.IgnorePodRestarts":       "If true, container restarts are not reported as problems",
+	"WaitForReadyConfig.Timeout":                 "The amount of seconds to wait until the deployment will fail. Defaults to 300 seconds.",
+}
+
+// enums holds the allowed values of the latest config string types
+var enums = map[string][]string{
+	"ContainerArchitecture": {"amd64", "arm64"},
+	"InitialSyncCompareBy":  {"mtime", "size"},
+	"InitialSyncStrategy":   {"mirrorLocal", "mirrorRemote", "preferLocal", "preferRemote", "preferNewest", "keepAll"},
+	"KubectlApplyMode":      {"client", "serverSide"},
+	"KustomizeMode":         {"", "true", "embedded"},
+	"LintSeverity":          {"error", "warning", "info"},
+	"RebuildStrategy":       {"", "always", "ignoreContextChanges"},
+	"VariableSource":        {"", "all", "env", "input", "command", "none", "file", "secret", "configmap"},
+}
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+)
+
+//go:generate go run github.com/loft-sh/devspace/hack/schema
+
+// DraftVersion is the json schema version of the generated schema
+const DraftVersion = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a json schema. Only the keywords needed to describe the devspace.yaml are supported
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type string        `json:"type,omitempty"`
+	Enum []interface{} `json:"enum,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+
+	// AdditionalProperties is either false or a *Schema for the values of a map
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	Items       *Schema            `json:"items,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// overrides are types that are unmarshaled differently than their go type suggests
+var overrides = map[reflect.Type]func() *Schema{
+	reflect.TypeOf(latest.KustomizeMode("")): func() *Schema {
+		return &Schema{
+			Enum: []interface{}{true, false, string(latest.KustomizeModeEmbedded)},
+		}
+	},
+}
+
+// NewConfigSchema generates the json schema of the devspace.yaml from the latest config types
+func NewConfigSchema() *Schema {
+	g := &generator{
+		definitions: map[string]*Schema{},
+	}
+
+	schema := g.structSchema(reflect.TypeOf(latest.Config{}))
+	schema.Schema = DraftVersion
+	schema.Title = "devspace.yaml " + latest.Version
+	schema.Definitions = g.definitions
+	return schema
+}
+
+// Resolve returns the definition of the root schema the given schema references or the given schema
+// itself if it is no reference
+func (s *Schema) Resolve(schema *Schema) *Schema {
+	for schema.Ref != "" {
+		definition, ok := s.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+		if !ok {
+			return &Schema{}
+		}
+
+		schema = definition
+	}
+
+	return schema
+}
+
+type generator struct {
+	definitions map[string]*Schema
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	if override, ok := overrides[t]; ok {
+		return override()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			// add a placeholder first in case the type references itself
+			g.definitions[t.Name()] = &Schema{}
+			g.definitions[t.Name()] = g.structSchema(t)
+		}
+
+		return &Schema{Ref: "#/definitions/" + t.Name()}
+	case reflect.String:
+		schema := &Schema{Type: "string"}
+		for _, value := range enums[t.Name()] {
+			schema.Enum = append(schema.Enum, value)
+		}
+
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		// maps with interface{} values are free form like helm values or pod specs
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object"}
+		}
+
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	}
+
+	// interface{} can hold any value
+	return &Schema{}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Description:          descriptions[t.Name()],
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		} else if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		property := g.schema(field.Type)
+		property.Description = descriptions[t.Name()+"."+field.Name]
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNewConfigSchema(t *testing.T) {
+	schema := NewConfigSchema()
+	assert.Equal(t, schema.Schema, DraftVersion)
+	assert.Equal(t, schema.AdditionalProperties, false)
+	assert.Equal(t, schema.Properties["dev"].Ref, "#/definitions/DevConfig")
+	assert.Equal(t, schema.Properties["dev"].Description, "Dev holds development configuration for the 'devspace dev' command.")
+	assert.Equal(t, schema.Properties["images"].AdditionalProperties.(*Schema).Ref, "#/definitions/ImageConfig")
+
+	syncConfig := schema.Definitions["SyncConfig"]
+	assert.Assert(t, syncConfig != nil)
+	assert.DeepEqual(t, syncConfig.Properties["initialSync"].Enum, []interface{}{"mirrorLocal", "mirrorRemote", "preferLocal", "preferRemote", "preferNewest", "keepAll"})
+
+	kubectlConfig := schema.Definitions["KubectlConfig"]
+	assert.DeepEqual(t, kubectlConfig.Properties["kustomize"].Enum, []interface{}{true, false, "embedded"})
+
+	// free form maps must allow any key
+	helmConfig := schema.Definitions["HelmConfig"]
+	assert.Equal(t, helmConfig.Properties["values"].Type, "object")
+	assert.Assert(t, helmConfig.Properties["values"].AdditionalProperties == nil)
+
+	_, err := json.Marshal(schema)
+	assert.NilError(t, err)
+}
+
+type validateTestCase struct {
+	name string
+
+	config string
+
+	expectedErrors []string
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []validateTestCase{
+		{
+			name: "valid config",
+			config: `version: v1beta10
+images:
+  default:
+    image: myimage
+    rebuildStrategy: always
+deployments:
+- name: app
+  helm:
+    values:
+      anything:
+        goes: here
+dev:
+  sync:
+  - imageSelector: myimage
+    initialSync: preferLocal
+    waitInitialSync: yes
+`,
+		},
+		{
+			name: "unknown fields",
+			config: `version: v1beta10
+dev:
+  snyc:
+  - imageSelector: myimage
+deployments:
+- name: app
+  kubectl:
+    manifest: []
+`,
+			expectedErrors: []string{
+				"line 3, column 3: dev.snyc: unknown field snyc, did you mean sync?",
+				"line 8, column 5: deployments[0].kubectl.manifest: unknown field manifest, did you mean manifests?",
+			},
+		},
+		{
+			name: "wrong types and enums",
+			config: `version: v1beta10
+dev:
+  ports:
+  - imageSelector: myimage
+    forward:
+    - port: abc
+  sync:
+  - initialSync: mirror
+    waitInitialSync: maybe
+    excludePaths: node_modules
+`,
+			expectedErrors: []string{
+				`line 6, column 13: dev.ports[0].forward[0].port: expected an integer, but got "abc"`,
+				`line 8, column 18: dev.sync[0].initialSync: value "mirror" must be one of mirrorLocal, mirrorRemote, preferLocal, preferRemote, preferNewest, keepAll`,
+				`line 9, column 22: dev.sync[0].waitInitialSync: expected a boolean, but got "maybe"`,
+				`line 10, column 19: dev.sync[0].excludePaths: expected an array, but got "node_modules"`,
+			},
+		},
+		{
+			name: "variables and expressions are not validated",
+			config: `version: v1beta10
+dev:
+  ports:
+  - imageSelector: myimage
+    forward:
+    - port: ${PORT}
+  sync:
+  - initialSync: $(echo preferLocal)
+`,
+		},
+		{
+			name: "anchors and merge keys",
+			config: `version: v1beta10
+deployments:
+- &app
+  name: app
+  kubectl:
+    manifests: []
+- <<: *app
+  name: other
+  namespace: other
+- <<: *app
+  nam: typo
+`,
+			expectedErrors: []string{
+				"line 11, column 3: deployments[2].nam: unknown field nam, did you mean name?",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		errors, err := NewConfigSchema().Validate([]byte(testCase.config))
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		messages := []string{}
+		for _, err := range errors {
+			messages = append(messages, err.Error())
+		}
+		if testCase.expectedErrors == nil {
+			testCase.expectedErrors = []string{}
+		}
+		assert.DeepEqual(t, messages, testCase.expectedErrors)
+	}
+}
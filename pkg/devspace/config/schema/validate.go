@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/expression"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"gopkg.in/yaml.v3"
+)
+
+// Error is a schema violation within a yaml document
+type Error struct {
+	// Path is the path of the invalid value, e.g. dev.sync[0].initialSync
+	Path string
+
+	// Line and Column of the invalid value or key in the yaml document
+	Line   int
+	Column int
+
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// Validate validates the yaml document against the schema and returns all violations ordered by
+// their position. Values that contain variables or expressions are not validated, because their
+// type is only known after they are resolved
+func (s *Schema) Validate(data []byte) ([]*Error, error) {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(data, document)
+	if err != nil {
+		return nil, err
+	} else if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{root: s}
+	v.validate(document.Content[0], s, "")
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+
+		return v.errors[i].Column < v.errors[j].Column
+	})
+	return v.errors, nil
+}
+
+type validator struct {
+	root   *Schema
+	errors []*Error
+}
+
+func (v *validator) validate(node *yaml.Node, schema *Schema, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	schema = v.resolve(schema)
+	if node.Kind == yaml.ScalarNode && (node.Tag == "!!null" || (node.Tag == "!!str" && isDynamic(node.Value))) {
+		return
+	}
+
+	if len(schema.Enum) > 0 {
+		if node.Kind != yaml.ScalarNode || !inEnum(node.Value, schema.Enum) {
+			v.addError(node, path, "value %s must be one of %s", describe(node), formatEnum(schema.Enum))
+		}
+
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			v.addError(node, path, "expected an object, but got %s", describe(node))
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				v.validateMerge(value, schema, path)
+				continue
+			} else if isDynamic(key.Value) {
+				continue
+			}
+
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+
+			if property, ok := schema.Properties[key.Value]; ok {
+				v.validate(value, property, childPath)
+			} else if additionalProperties, ok := schema.AdditionalProperties.(*Schema); ok {
+				v.validate(value, additionalProperties, childPath)
+			} else if schema.AdditionalProperties == false {
+				message := fmt.Sprintf("unknown field %s", key.Value)
+				if suggestion := suggest(key.Value, schema.Properties); suggestion != "" {
+					message += fmt.Sprintf(", did you mean %s?", suggestion)
+				}
+
+				v.addError(key, childPath, "%s", message)
+			}
+		}
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			v.addError(node, path, "expected an array, but got %s", describe(node))
+			return
+		} else if schema.Items == nil {
+			return
+		}
+
+		for i, item := range node.Content {
+			v.validate(item, schema.Items, path+"["+strconv.Itoa(i)+"]")
+		}
+	case "string":
+		// yaml scalars of any type can be unmarshaled into a string
+		if node.Kind != yaml.ScalarNode {
+			v.addError(node, path, "expected a string, but got %s", describe(node))
+		}
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.addError(node, path, "expected an integer, but got %s", describe(node))
+		}
+	case "number":
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			v.addError(node, path, "expected a number, but got %s", describe(node))
+		}
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!bool" && !yaml11Bools[node.Value]) {
+			v.addError(node, path, "expected a boolean, but got %s", describe(node))
+		}
+	}
+}
+
+// validateMerge validates the mappings that are merged into another mapping with <<
+func (v *validator) validateMerge(node *yaml.Node, schema *Schema, path string) {
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			v.validate(item, schema, path)
+		}
+
+		return
+	}
+
+	v.validate(node, schema, path)
+}
+
+func (v *validator) resolve(schema *Schema) *Schema {
+	return v.root.Resolve(schema)
+}
+
+func (v *validator) addError(node *yaml.Node, path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{
+		Path:    path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// yaml11Bools are strings that are booleans in yaml 1.1, which is used to parse the config
+var yaml11Bools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "on": true, "On": true, "ON": true,
+	"n": true, "N": true, "no": true, "No": true, "NO": true, "off": true, "Off": true, "OFF": true,
+}
+
+// isDynamic returns true if the value contains a variable or an expression
+func isDynamic(value string) bool {
+	return varspkg.VarMatchRegex.MatchString(value) || expression.ExpressionMatchRegex.MatchString(value)
+}
+
+func inEnum(value string, enum []interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := []string{}
+	for _, value := range enum {
+		if value == "" {
+			continue
+		}
+
+		values = append(values, fmt.Sprint(value))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "an array"
+	}
+
+	return strconv.Quote(node.Value)
+}
+
+// suggest returns the property that is most similar to the misspelled key
+func suggest(key string, properties map[string]*Schema) string {
+	suggestion := ""
+	best := len(key)/2 + 1
+	for property := range properties {
+		distance := levenshtein(strings.ToLower(key), strings.ToLower(property))
+		if distance < best || (distance == best && suggestion != "" && property < suggestion) {
+			suggestion = property
+			best = distance
+		}
+	}
+
+	return suggestion
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,226 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// Tag is the yaml tag that marks encrypted values in devspace.yaml
+const Tag = "!encrypted"
+
+// KeyEnv is the environment variable that points to the armored private pgp key used for secrets
+const KeyEnv = "DEVSPACE_SECRETS_KEY"
+
+// PassphraseEnv is the environment variable that holds the passphrase of the private pgp key
+const PassphraseEnv = "DEVSPACE_SECRETS_PASSPHRASE"
+
+const messageType = "PGP MESSAGE"
+
+// KeyPath returns the path of the private pgp key that is used to encrypt and decrypt secrets
+func KeyPath() (string, error) {
+	if os.Getenv(KeyEnv) != "" {
+		return os.Getenv(KeyEnv), nil
+	}
+
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, constants.DefaultHomeDevSpaceFolder, "secrets", "key.asc"), nil
+}
+
+// PublicKeyPath returns the path of the public key that belongs to the generated private key
+func PublicKeyPath(keyPath string) string {
+	return strings.TrimSuffix(keyPath, ".asc") + ".pub.asc"
+}
+
+// LoadKey loads the private pgp key from the key path and decrypts it with the passphrase if necessary
+func LoadKey() (openpgp.EntityList, error) {
+	keyPath, err := KeyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("no secrets key found at %s. Run 'devspace set secret' to generate one or set %s to the path of an armored private pgp key", keyPath, KeyEnv)
+		}
+
+		return nil, err
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Wrapf(err, "read secrets key %s", keyPath)
+	}
+
+	for _, entity := range keyring {
+		keys := []*openpgp.Key{{PrivateKey: entity.PrivateKey}}
+		for _, subkey := range entity.Subkeys {
+			keys = append(keys, &openpgp.Key{PrivateKey: subkey.PrivateKey})
+		}
+
+		for _, key := range keys {
+			if key.PrivateKey == nil || !key.PrivateKey.Encrypted {
+				continue
+			} else if os.Getenv(PassphraseEnv) == "" {
+				return nil, errors.Errorf("secrets key %s is protected by a passphrase, please set %s", keyPath, PassphraseEnv)
+			}
+
+			err = key.PrivateKey.Decrypt([]byte(os.Getenv(PassphraseEnv)))
+			if err != nil {
+				return nil, errors.Wrapf(err, "decrypt secrets key %s", keyPath)
+			}
+		}
+	}
+
+	return keyring, nil
+}
+
+// EnsureKey loads the private pgp key or generates a new one if there is none yet
+func EnsureKey(log log.Logger) (openpgp.EntityList, error) {
+	keyPath, err := KeyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = os.Stat(keyPath)
+	if err == nil || !os.IsNotExist(err) {
+		return LoadKey()
+	}
+
+	entity, err := openpgp.NewEntity("DevSpace Secrets", "", "", &packet.Config{DefaultHash: crypto.SHA256, DefaultCipher: packet.CipherAES256})
+	if err != nil {
+		return nil, errors.Wrap(err, "generate secrets key")
+	}
+
+	privateKey := &bytes.Buffer{}
+	writer, err := armor.Encode(privateKey, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = entity.SerializePrivate(writer, nil)
+	if err != nil {
+		return nil, err
+	}
+	writer.Close()
+
+	publicKey := &bytes.Buffer{}
+	writer, err = armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = entity.Serialize(writer)
+	if err != nil {
+		return nil, err
+	}
+	writer.Close()
+
+	err = os.MkdirAll(filepath.Dir(keyPath), 0700)
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(keyPath, privateKey.Bytes(), 0600)
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(PublicKeyPath(keyPath), publicKey.Bytes(), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Generated a new secrets key at %s. Share %s with your team to let them encrypt secrets for you", keyPath, PublicKeyPath(keyPath))
+	return openpgp.EntityList{entity}, nil
+}
+
+// ReadRecipients reads the armored public pgp keys at the given paths. Relative paths are resolved
+// relative to the base path
+func ReadRecipients(basePath string, paths []string) (openpgp.EntityList, error) {
+	recipients := openpgp.EntityList{}
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(basePath, path)
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "read recipient")
+		}
+
+		keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "read recipient %s", path)
+		}
+
+		recipients = append(recipients, keyring...)
+	}
+
+	return recipients, nil
+}
+
+// Encrypt encrypts the value for the given recipients and returns the armored message
+func Encrypt(value string, recipients openpgp.EntityList) (string, error) {
+	message := &bytes.Buffer{}
+	armorWriter, err := armor.Encode(message, messageType, nil)
+	if err != nil {
+		return "", err
+	}
+
+	writer, err := openpgp.Encrypt(armorWriter, recipients, nil, nil, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "encrypt secret")
+	}
+
+	_, err = writer.Write([]byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = armorWriter.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return message.String() + "\n", nil
+}
+
+// Decrypt decrypts the armored message with the keyring
+func Decrypt(message string, keyring openpgp.EntityList) (string, error) {
+	block, err := armor.Decode(strings.NewReader(strings.TrimSpace(message)))
+	if err != nil {
+		return "", errors.Wrap(err, "decode secret")
+	} else if block.Type != messageType {
+		return "", errors.Errorf("expected a %s, but got %s", messageType, block.Type)
+	}
+
+	details, err := openpgp.ReadMessage(block.Body, keyring, nil, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "decrypt secret, make sure the secret was encrypted for your key")
+	}
+
+	value, err := ioutil.ReadAll(details.UnverifiedBody)
+	if err != nil {
+		return "", errors.Wrap(err, "decrypt secret")
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const testConfig = `version: v1beta10
+# the password of the database
+vars:
+- name: IMAGE
+  value: myimage
+- name: PASSWORD
+  source: input
+deployments:
+- name: db
+  helm:
+    values:
+      user: admin
+`
+
+func TestSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	os.Setenv(KeyEnv, filepath.Join(dir, "key.asc"))
+	defer os.Unsetenv(KeyEnv)
+
+	// files without encrypted values are returned unchanged
+	out, err := Placeholders([]byte(testConfig))
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), testConfig)
+
+	keyring, err := EnsureKey(log.Discard)
+	assert.NilError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "key.pub.asc"))
+	assert.NilError(t, err)
+
+	document, err := Parse([]byte(testConfig))
+	assert.NilError(t, err)
+	err = document.SetVariable("PASSWORD", "my-password", keyring)
+	assert.NilError(t, err)
+	err = document.SetVariable("TOKEN", "my-token", keyring)
+	assert.NilError(t, err)
+
+	encrypted, err := document.Bytes()
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(encrypted), "my-password"), "secret was written unencrypted")
+	assert.Assert(t, strings.Contains(string(encrypted), "# the password of the database"), "comments were removed")
+
+	document, err = Parse(encrypted)
+	assert.NilError(t, err)
+	paths := []string{}
+	for _, value := range document.Values() {
+		paths = append(paths, value.Path)
+	}
+	assert.DeepEqual(t, paths, []string{"vars[1].value", "vars[2].value"})
+
+	withPlaceholders, err := Placeholders(encrypted)
+	assert.NilError(t, err)
+
+	config := map[string]interface{}{}
+	err = yaml.Unmarshal(withPlaceholders, &config)
+	assert.NilError(t, err)
+	vars := config["vars"].([]interface{})
+	assert.Equal(t, len(vars), 3)
+	password := vars[1].(map[interface{}]interface{})["value"].(string)
+	assert.Assert(t, IsPlaceholder(password), "encrypted value was not replaced with a placeholder")
+	assert.Equal(t, vars[2].(map[interface{}]interface{})["name"], "TOKEN")
+
+	decrypted, err := DecryptPlaceholder(password, keyring)
+	assert.NilError(t, err)
+	assert.Equal(t, decrypted, "my-password")
+
+	// secrets encrypted for another key cannot be decrypted
+	os.Setenv(KeyEnv, filepath.Join(dir, "other.asc"))
+	otherKeyring, err := EnsureKey(log.Discard)
+	assert.NilError(t, err)
+	_, err = DecryptPlaceholder(password, otherKeyring)
+	assert.ErrorContains(t, err, "decrypt secret")
+}
@@ -0,0 +1,272 @@
+package secrets
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/yaml.v3"
+)
+
+// Value is an encrypted value within a yaml file
+type Value struct {
+	// Path is the path of the value, e.g. vars[0].value
+	Path string
+
+	node *yaml.Node
+}
+
+// Document is a parsed yaml file that may contain encrypted values
+type Document struct {
+	root *yaml.Node
+}
+
+// Parse parses the yaml content
+func Parse(content []byte) (*Document, error) {
+	root := &yaml.Node{}
+	err := yaml.Unmarshal(content, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(root.Content) == 0 {
+		root = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	return &Document{root: root}, nil
+}
+
+// Values returns all encrypted values of the document in the order they appear
+func (d *Document) Values() []*Value {
+	values := []*Value{}
+	walk(d.root.Content[0], "", func(path string, node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode && node.Tag == Tag {
+			values = append(values, &Value{Path: path, node: node})
+		}
+	})
+
+	return values
+}
+
+// Decrypt decrypts the value with the keyring
+func (v *Value) Decrypt(keyring openpgp.EntityList) (string, error) {
+	value, err := Decrypt(v.node.Value, keyring)
+	if err != nil {
+		return "", errors.Wrap(err, v.Path)
+	}
+
+	return value, nil
+}
+
+// Encrypt replaces the value with the encrypted value
+func (v *Value) Encrypt(value string, recipients openpgp.EntityList) error {
+	encrypted, err := Encrypt(value, recipients)
+	if err != nil {
+		return err
+	}
+
+	setEncrypted(v.node, encrypted)
+	return nil
+}
+
+// SetVariable sets the value of the variable in the vars section to the encrypted value. An existing
+// definition of the variable is replaced, otherwise the variable is appended to the vars section
+func (d *Document) SetVariable(name, value string, recipients openpgp.EntityList) error {
+	root := d.root.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return errors.New("expected the config to be an object")
+	}
+
+	encrypted, err := Encrypt(value, recipients)
+	if err != nil {
+		return err
+	}
+
+	valueNode := &yaml.Node{}
+	setEncrypted(valueNode, encrypted)
+	definition := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "name"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: name},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "value"},
+		valueNode,
+	}}
+
+	vars := mappingValue(root, "vars")
+	if vars == nil {
+		vars = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "vars"}, vars)
+	} else if vars.Kind != yaml.SequenceNode {
+		return errors.New("expected vars to be an array")
+	}
+
+	for i, entry := range vars.Content {
+		nameNode := mappingValue(entry, "name")
+		if nameNode != nil && strings.TrimSpace(nameNode.Value) == name {
+			vars.Content[i] = definition
+			return nil
+		}
+	}
+
+	vars.Content = append(vars.Content, definition)
+	return nil
+}
+
+// Recipients returns the paths of the public keys in secrets.recipients
+func (d *Document) Recipients() []string {
+	recipients := []string{}
+	secrets := mappingValue(d.root.Content[0], "secrets")
+	if secrets == nil {
+		return recipients
+	}
+
+	list := mappingValue(secrets, "recipients")
+	if list == nil {
+		return recipients
+	}
+
+	for _, recipient := range list.Content {
+		recipients = append(recipients, recipient.Value)
+	}
+
+	return recipients
+}
+
+// Bytes returns the yaml content of the document
+func (d *Document) Bytes() ([]byte, error) {
+	out := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	err := encoder.Encode(d.root)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// Placeholders replaces the encrypted values in the yaml content with placeholders, which are plain strings
+// that still hold the encrypted message. Content without encrypted values is returned unchanged
+func Placeholders(content []byte) ([]byte, error) {
+	if !bytes.Contains(content, []byte(Tag)) {
+		return content, nil
+	}
+
+	document, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	values := document.Values()
+	if len(values) == 0 {
+		return content, nil
+	}
+
+	for _, value := range values {
+		value.node.Tag = "!!str"
+		value.node.Value = Tag + " " + value.node.Value
+	}
+
+	return document.Bytes()
+}
+
+// IsPlaceholder returns true if the value is the placeholder of an encrypted value
+func IsPlaceholder(value string) bool {
+	return strings.HasPrefix(value, Tag+" ")
+}
+
+// DecryptPlaceholder decrypts the encrypted message of the placeholder with the keyring
+func DecryptPlaceholder(placeholder string, keyring openpgp.EntityList) (string, error) {
+	if !IsPlaceholder(placeholder) {
+		return "", errors.New("value is not encrypted")
+	}
+
+	return Decrypt(strings.TrimPrefix(placeholder, Tag+" "), keyring)
+}
+
+// ReadFile reads and parses the yaml file at the given path
+func ReadFile(path string) (*Document, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(content)
+}
+
+// WriteFile writes the document to the file at the given path
+func (d *Document) WriteFile(path string) error {
+	out, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, out, 0666)
+}
+
+// EncryptionKeys returns the keys new secrets are encrypted for, which are the key of the current user
+// and the recipients configured in the document at the given path. A key is generated if the user has none
+func (d *Document) EncryptionKeys(path string, log log.Logger) (openpgp.EntityList, error) {
+	keyring, err := EnsureKey(log)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := ReadRecipients(filepath.Dir(path), d.Recipients())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keyring, recipients...), nil
+}
+
+func setEncrypted(node *yaml.Node, encrypted string) {
+	node.Kind = yaml.ScalarNode
+	node.Tag = Tag
+	node.Style = yaml.LiteralStyle
+	node.Value = encrypted
+	node.Content = nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func walk(node *yaml.Node, path string, fn func(path string, node *yaml.Node)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if path != "" {
+				key = path + "." + key
+			}
+
+			walk(node.Content[i+1], key, fn)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			walk(item, path+"["+strconv.Itoa(i)+"]", fn)
+		}
+	default:
+		fn(path, node)
+	}
+}
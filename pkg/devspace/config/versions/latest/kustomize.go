@@ -0,0 +1,71 @@
+package latest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalYAML parses kustomize: true, kustomize: false and kustomize: embedded
+func (k *KustomizeMode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value interface{}
+	err := unmarshal(&value)
+	if err != nil {
+		return err
+	}
+
+	return k.parse(value)
+}
+
+// MarshalYAML writes the binary mode as boolean
+func (k KustomizeMode) MarshalYAML() (interface{}, error) {
+	if k == KustomizeModeBinary {
+		return true, nil
+	}
+
+	return string(k), nil
+}
+
+// UnmarshalJSON parses kustomize: true, kustomize: false and kustomize: embedded
+func (k *KustomizeMode) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	return k.parse(value)
+}
+
+// MarshalJSON writes the binary mode as boolean
+func (k KustomizeMode) MarshalJSON() ([]byte, error) {
+	if k == KustomizeModeBinary {
+		return json.Marshal(true)
+	}
+
+	return json.Marshal(string(k))
+}
+
+func (k *KustomizeMode) parse(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*k = KustomizeModeDisabled
+	case bool:
+		*k = KustomizeModeDisabled
+		if v {
+			*k = KustomizeModeBinary
+		}
+	case string:
+		switch KustomizeMode(v) {
+		case KustomizeModeDisabled, "false":
+			*k = KustomizeModeDisabled
+		case KustomizeModeBinary, KustomizeModeEmbedded:
+			*k = KustomizeMode(v)
+		default:
+			return fmt.Errorf("invalid value %s for kustomize, please use true, false or %s", v, KustomizeModeEmbedded)
+		}
+	default:
+		return fmt.Errorf("invalid value %v for kustomize, please use true, false or %s", value, KustomizeModeEmbedded)
+	}
+
+	return nil
+}
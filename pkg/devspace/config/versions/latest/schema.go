@@ -33,9 +33,16 @@ type Config struct {
 	// Require defines what DevSpace, plugins and command versions are needed to use this config
 	Require RequireConfig `yaml:"require,omitempty" json:"require,omitempty"`
 
+	// Imports are partial configs from local paths, urls or git repositories that are merged into this
+	// config before profiles are applied
+	Imports []*ImportConfig `yaml:"imports,omitempty" json:"imports,omitempty"`
+
 	// Vars are config variables that can be used inside other config sections to replace certain values dynamically
 	Vars []*Variable `yaml:"vars,omitempty" json:"vars,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 
+	// Secrets configures who can decrypt the values that are marked with !encrypted in this config
+	Secrets *SecretsConfig `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+
 	// PullSecrets are image pull secrets that will be created by devspace in the target namespace
 	// during devspace dev or devspace deploy
 	PullSecrets []*PullSecretConfig `yaml:"pullSecrets,omitempty" json:"pullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"registry"`
@@ -96,6 +103,13 @@ type RequireCommand struct {
 	Version string `yaml:"version,omitempty" json:"version,omitempty"`
 }
 
+// ImportConfig defines a partial config that is imported into this config
+type ImportConfig struct {
+	// Source is where to load the imported config from. If the path points to a folder, the devspace.yaml
+	// or the file specified in configName within that folder is imported
+	Source *SourceConfig `yaml:"source" json:"source"`
+}
+
 // ImageConfig defines the image specification
 type ImageConfig struct {
 	// Image is the complete image name including registry and repository
@@ -148,6 +162,9 @@ type ImageConfig struct {
 
 	// Specific build options how to build the specified image
 	Build *BuildConfig `yaml:"build,omitempty" json:"build,omitempty"`
+
+	// If removes the image from the config if the expression evaluates to false
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
 }
 
 // RebuildStrategy is the type of a image rebuild strategy
@@ -179,8 +196,33 @@ type BuildConfig struct {
 	// This overrides other options and is able to disable the build for this image.
 	// Useful if you just want to select the image in a sync path or via devspace enter --image
 	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// If lint is specified, DevSpace will check the Dockerfile for common problems before building
+	Lint *LintConfig `yaml:"lint,omitempty" json:"lint,omitempty"`
+}
+
+// LintConfig defines the static checks of the Dockerfile that are run before building
+type LintConfig struct {
+	// If true, DevSpace checks the Dockerfile before building the image
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Findings with one of these severities fail the build. Defaults to error
+	FailOn []LintSeverity `yaml:"failOn,omitempty" json:"failOn,omitempty"`
+
+	// Names of checks that should be skipped
+	Disable []string `yaml:"disable,omitempty" json:"disable,omitempty"`
 }
 
+// LintSeverity is the severity of a Dockerfile lint finding
+type LintSeverity string
+
+// List of lint severities
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+	LintSeverityInfo    LintSeverity = "info"
+)
+
 // DockerConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost
 type DockerConfig struct {
 	PreferMinikube  *bool         `yaml:"preferMinikube,omitempty" json:"preferMinikube,omitempty"`
@@ -247,6 +289,9 @@ type BuildKitInClusterConfig struct {
 
 	// Additional args to create the builder with.
 	CreateArgs []string `yaml:"createArgs,omitempty" json:"createArgs,omitempty"`
+
+	// If enabled, DevSpace keeps the build context in the BuildKit builder pod and only uploads changed files
+	IncrementalContext bool `yaml:"incrementalContext,omitempty" json:"incrementalContext,omitempty"`
 }
 
 // KanikoConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost
@@ -312,6 +357,24 @@ type KanikoConfig struct {
 
 	// other build options that will be passed to the kaniko pod
 	Options *BuildOptions `yaml:"options,omitempty" json:"options,omitempty"`
+
+	// if enabled, devspace will keep a warm build pod running and reuse it for subsequent builds
+	Reuse *KanikoReuseConfig `yaml:"reuse,omitempty" json:"reuse,omitempty"`
+}
+
+// KanikoReuseConfig tells devspace how to reuse a long-lived kaniko build pod
+type KanikoReuseConfig struct {
+	// if true the build pod will be reused across builds
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// the time in seconds after which an idle build pod is removed. defaults to 600
+	IdleTimeout *int64 `yaml:"idleTimeout,omitempty" json:"idleTimeout,omitempty"`
+
+	// the image of the reusable build pod, needs to contain a shell. defaults to the kaniko debug image
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+
+	// if true the build context is kept in the build pod and only changed files are uploaded. requires enabled
+	IncrementalContext bool `yaml:"incrementalContext,omitempty" json:"incrementalContext,omitempty"`
 }
 
 // KanikoPodResources describes the resources section of the started kaniko pod
@@ -446,6 +509,28 @@ type DeploymentConfig struct {
 	Namespace string         `yaml:"namespace,omitempty" json:"namespace,omitempty"`
 	Helm      *HelmConfig    `yaml:"helm,omitempty" json:"helm,omitempty"`
 	Kubectl   *KubectlConfig `yaml:"kubectl,omitempty" json:"kubectl,omitempty"`
+
+	// DependsOn are the names of other deployments that need to be deployed before this deployment
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
+	// WaitForReady waits after deploying until the rollouts of all deployed workloads are complete. Deployments
+	// that other deployments depend on are waited for by default
+	WaitForReady *WaitForReadyConfig `yaml:"waitForReady,omitempty" json:"waitForReady,omitempty"`
+
+	// If removes the deployment from the config if the expression evaluates to false
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
+}
+
+// WaitForReadyConfig defines how to wait for the workloads of a deployment
+type WaitForReadyConfig struct {
+	// If true, DevSpace doesn't wait for the workloads, even if other deployments depend on this deployment
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// The amount of seconds to wait until the deployment will fail. Defaults to 300 seconds.
+	Timeout int64 `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// If true, container restarts are not reported as problems
+	IgnorePodRestarts bool `yaml:"ignorePodRestarts,omitempty" json:"ignorePodRestarts,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -642,6 +727,7 @@ type HelmConfig struct {
 	Driver           string                      `yaml:"driver,omitempty" json:"driver,omitempty"`
 	Path             string                      `yaml:"path,omitempty" json:"path,omitempty"`
 	V2               bool                        `yaml:"v2,omitempty" json:"v2,omitempty"`
+	Native           bool                        `yaml:"native,omitempty" json:"native,omitempty"`
 	TillerNamespace  string                      `yaml:"tillerNamespace,omitempty" json:"tillerNamespace,omitempty"`
 
 	DeleteArgs   []string `yaml:"deleteArgs,omitempty" json:"deleteArgs,omitempty"`
@@ -661,16 +747,47 @@ type ChartConfig struct {
 
 // KubectlConfig defines the specific kubectl options used during deployment
 type KubectlConfig struct {
-	Manifests        []string `yaml:"manifests,omitempty" json:"manifests,omitempty"`
-	Kustomize        *bool    `yaml:"kustomize,omitempty" json:"kustomize,omitempty"`
-	KustomizeArgs    []string `yaml:"kustomizeArgs,omitempty" json:"kustomizeArgs,omitempty"`
-	ReplaceImageTags *bool    `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
-	DeleteArgs       []string `yaml:"deleteArgs,omitempty" json:"deleteArgs,omitempty"`
-	CreateArgs       []string `yaml:"createArgs,omitempty" json:"createArgs,omitempty"`
-	ApplyArgs        []string `yaml:"applyArgs,omitempty" json:"applyArgs,omitempty"`
-	CmdPath          string   `yaml:"cmdPath,omitempty" json:"cmdPath,omitempty"`
+	Manifests        []string      `yaml:"manifests,omitempty" json:"manifests,omitempty"`
+	Kustomize        KustomizeMode `yaml:"kustomize,omitempty" json:"kustomize,omitempty"`
+	KustomizeArgs    []string      `yaml:"kustomizeArgs,omitempty" json:"kustomizeArgs,omitempty"`
+	ReplaceImageTags *bool         `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
+	DeleteArgs       []string      `yaml:"deleteArgs,omitempty" json:"deleteArgs,omitempty"`
+	CreateArgs       []string      `yaml:"createArgs,omitempty" json:"createArgs,omitempty"`
+	ApplyArgs        []string      `yaml:"applyArgs,omitempty" json:"applyArgs,omitempty"`
+	CmdPath          string        `yaml:"cmdPath,omitempty" json:"cmdPath,omitempty"`
+
+	// ApplyMode defines how the manifests are applied. By default DevSpace uses kubectl apply,
+	// with serverSide DevSpace applies the objects itself via server-side apply
+	ApplyMode KubectlApplyMode `yaml:"applyMode,omitempty" json:"applyMode,omitempty"`
+
+	// Prune deletes objects that were applied by a previous deployment but are not part of the
+	// manifests anymore. Defaults to true
+	Prune *bool `yaml:"prune,omitempty" json:"prune,omitempty"`
 }
 
+// KubectlApplyMode is the mode the kubectl deployer applies manifests with
+type KubectlApplyMode string
+
+// List of kubectl apply modes
+const (
+	KubectlApplyModeClient     KubectlApplyMode = "client"
+	KubectlApplyModeServerSide KubectlApplyMode = "serverSide"
+)
+
+// KustomizeMode defines if and how the manifests of a kubectl deployment are rendered with kustomize.
+// In the config it is either a boolean or embedded
+type KustomizeMode string
+
+// List of kustomize modes
+const (
+	// KustomizeModeDisabled applies the manifests without kustomize
+	KustomizeModeDisabled KustomizeMode = ""
+	// KustomizeModeBinary renders the manifests with the kustomize or kubectl binary
+	KustomizeModeBinary KustomizeMode = "true"
+	// KustomizeModeEmbedded renders the manifests with the kustomize library built into DevSpace
+	KustomizeModeEmbedded KustomizeMode = "embedded"
+)
+
 // DevConfig defines the devspace deployment
 type DevConfig struct {
 	Ports      []*PortForwardingConfig `yaml:"ports,omitempty" json:"ports,omitempty"`
@@ -717,6 +834,9 @@ type PortForwardingConfig struct {
 
 	PortMappings        []*PortMapping `yaml:"forward,omitempty" json:"forward,omitempty"`
 	PortMappingsReverse []*PortMapping `yaml:"reverseForward,omitempty" json:"reverseForward,omitempty"`
+
+	// If removes the port forwarding from the config if the expression evaluates to false
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
 }
 
 // PortMapping defines the ports for a PortMapping
@@ -766,6 +886,9 @@ type SyncConfig struct {
 
 	OnUpload   *SyncOnUpload   `yaml:"onUpload,omitempty" json:"onUpload,omitempty"`
 	OnDownload *SyncOnDownload `yaml:"onDownload,omitempty" json:"onDownload,omitempty"`
+
+	// If removes the sync from the config if the expression evaluates to false
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
 }
 
 type ContainerArchitecture string
@@ -998,6 +1121,9 @@ type HookConfig struct {
 	Where HookWhereConfig `yaml:"where,omitempty" json:"where,omitempty"`
 	// Specifies when the hook should be run.
 	When *HookWhenConfig `yaml:"when,omitempty" json:"when,omitempty"`
+
+	// If removes the hook from the config if the expression evaluates to false
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
 }
 
 // HookWaitConfig defines a hook wait config
@@ -1078,7 +1204,10 @@ type CommandConfig struct {
 	AppendArgs *bool `yaml:"appendArgs,omitempty" json:"appendArgs,omitempty"`
 
 	// Description describes what the command is doing and can be seen in `devspace list commands`
-	Description string `yaml:"description" json:"description"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// If removes the command from the config if the expression evaluates to false
+	If string `yaml:"if,omitempty" json:"if,omitempty"`
 }
 
 // Variable describes the var definition
@@ -1109,6 +1238,19 @@ type Variable struct {
 	// Commands are additional commands that can be used to run a different command on a different operating
 	// system.
 	Commands []VariableCommand `yaml:"commands,omitempty" json:"commands,omitempty"`
+
+	// Files are the dotenv files the variable is read from if source is file. Paths are relative to the config
+	// and later files override the values of earlier ones.
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// Secret is the name of the secret in the current namespace the variable is read from if source is secret
+	Secret string `yaml:"secret,omitempty" json:"secret,omitempty"`
+
+	// ConfigMap is the name of the configmap in the current namespace the variable is read from if source is configmap
+	ConfigMap string `yaml:"configMap,omitempty" json:"configMap,omitempty"`
+
+	// Key is the key within the dotenv files, secret or configmap. Defaults to the name of the variable
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
 }
 
 type VariableCommand struct {
@@ -1122,12 +1264,15 @@ type VariableSource string
 
 // List of values that source can take
 const (
-	VariableSourceDefault VariableSource = ""
-	VariableSourceAll     VariableSource = "all"
-	VariableSourceEnv     VariableSource = "env"
-	VariableSourceInput   VariableSource = "input"
-	VariableSourceCommand VariableSource = "command"
-	VariableSourceNone    VariableSource = "none"
+	VariableSourceDefault   VariableSource = ""
+	VariableSourceAll       VariableSource = "all"
+	VariableSourceEnv       VariableSource = "env"
+	VariableSourceInput     VariableSource = "input"
+	VariableSourceCommand   VariableSource = "command"
+	VariableSourceNone      VariableSource = "none"
+	VariableSourceFile      VariableSource = "file"
+	VariableSourceSecret    VariableSource = "secret"
+	VariableSourceConfigMap VariableSource = "configmap"
 )
 
 // ProfileConfig defines a profile config
@@ -1161,11 +1306,43 @@ type ProfileParent struct {
 	Profile string        `yaml:"profile" json:"profile"`
 }
 
+// SecretsConfig defines how encrypted values are encrypted
+type SecretsConfig struct {
+	// Recipients are paths to armored public pgp keys relative to the devspace.yaml. New secrets are encrypted
+	// for these keys in addition to the key of the current user, so that every recipient can decrypt them
+	Recipients []string `yaml:"recipients,omitempty" json:"recipients,omitempty"`
+}
+
 // ProfileActivation defines rules that automatically activate a profile when evaluated to true
 type ProfileActivation struct {
 	// Environment defines key/value pairs where the key is the name of the environment variable and the value is a regular expression used to match the variable's value.
 	// When multiple keys are specified, they must all evaluate to true to activate the profile.
 	Environment map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// KubeContext is a glob pattern (e.g. prod-*) that must match the name of the current kube context
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty"`
+
+	// Namespace is a glob pattern that must match the namespace devspace uses
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// GitBranch is a glob pattern (e.g. release/*) that must match the currently checked out git branch
+	GitBranch string `yaml:"gitBranch,omitempty" json:"gitBranch,omitempty"`
+
+	// OS is a glob pattern that must match the operating system devspace runs on (e.g. linux, darwin or windows)
+	OS string `yaml:"os,omitempty" json:"os,omitempty"`
+
+	// Vars defines key/value pairs where the key is the name of a config variable and the value is a regular expression
+	// used to match the resolved value of the variable
+	Vars map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+
+	// Files is a list of paths relative to the devspace.yaml that must all exist to activate the profile
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// All is a list of activation rules that must all evaluate to true to activate the profile
+	All []*ProfileActivation `yaml:"all,omitempty" json:"all,omitempty"`
+
+	// Any is a list of activation rules of which at least one must evaluate to true to activate the profile
+	Any []*ProfileActivation `yaml:"any,omitempty" json:"any,omitempty"`
 }
 
 // PatchConfig describes a config patch and how it should be applied
@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/config"
@@ -52,18 +55,30 @@ var versionLoader = map[string]*loader{
 	latest.Version:   &loader{New: latest.New},
 }
 
+// LookupFn returns the value of the config variable with the given name
+type LookupFn func(name string) (interface{}, error)
+
+// ActivatedProfile is a profile that is activated automatically by one of its activation rules
+type ActivatedProfile struct {
+	Name   string
+	Reason string
+}
+
 // ParseProfile loads the base config & a certain profile
-func ParseProfile(basePath string, data map[interface{}]interface{}, profiles []string, update bool, disableProfileActivation bool, log log.Logger) ([]map[interface{}]interface{}, error) {
+func ParseProfile(basePath string, data map[interface{}]interface{}, profiles []string, update bool, disableProfileActivation bool, lookup LookupFn, log log.Logger) ([]map[interface{}]interface{}, error) {
 	parsedProfiles := []map[interface{}]interface{}{}
 
 	// auto activated root level profiles
 	activatedProfiles := []string{}
 	if !disableProfileActivation {
-		var err error
-		activatedProfiles, err = getActivatedProfiles(data)
+		activated, err := ActivatedProfiles(basePath, data, lookup)
 		if err != nil {
 			return nil, err
 		}
+
+		for _, profile := range activated {
+			activatedProfiles = append(activatedProfiles, profile.Name)
+		}
 	}
 
 	// Combine auto activated profiles with flag activated profiles
@@ -101,6 +116,16 @@ func ParseVariables(data map[interface{}]interface{}, log log.Logger) ([]*latest
 	return config.Vars, nil
 }
 
+// New returns an empty config of the given version or nil if the version is unknown
+func New(version string) config.Config {
+	loader, ok := versionLoader[version]
+	if !ok {
+		return nil
+	}
+
+	return loader.New()
+}
+
 // Parse parses the data into the latest config
 func Parse(data map[interface{}]interface{}, log log.Logger) (*latest.Config, error) {
 	version, ok := data["version"].(string)
@@ -379,8 +404,10 @@ func getProfiles(basePath string, data map[interface{}]interface{}, profile stri
 	return errors.Errorf("Couldn't find profile '%s'", profile)
 }
 
-func getActivatedProfiles(data map[interface{}]interface{}) ([]string, error) {
-	activatedProfiles := []string{}
+// ActivatedProfiles returns the profiles that are activated by their activation rules together with the
+// reason why they are activated. Config variables used in the rules are retrieved with lookup
+func ActivatedProfiles(basePath string, data map[interface{}]interface{}, lookup LookupFn) ([]*ActivatedProfile, error) {
+	activatedProfiles := []*ActivatedProfile{}
 
 	// Check if there are profiles
 	if data["profiles"] == nil {
@@ -407,14 +434,18 @@ func getActivatedProfiles(data map[interface{}]interface{}) ([]string, error) {
 			return activatedProfiles, fmt.Errorf("error parsing profile at profiles[%d]: %v", i, err)
 		}
 
-		for _, activation := range profileConfig.Activation {
-			activated, err := matchEnvironment(activation.Environment)
+		for j, activation := range profileConfig.Activation {
+			activated, reasons, err := matchActivation(basePath, activation, lookup)
 			if err != nil {
-				return activatedProfiles, err
+				return activatedProfiles, errors.Wrapf(err, "profiles[%d].activation[%d]", i, j)
 			}
 
 			if activated {
-				activatedProfiles = append(activatedProfiles, profileConfig.Name)
+				activatedProfiles = append(activatedProfiles, &ActivatedProfile{
+					Name:   profileConfig.Name,
+					Reason: strings.Join(reasons, ", "),
+				})
+				break
 			}
 		}
 	}
@@ -422,9 +453,106 @@ func getActivatedProfiles(data map[interface{}]interface{}) ([]string, error) {
 	return activatedProfiles, nil
 }
 
-func matchEnvironment(env map[string]string) (bool, error) {
-	for k, v := range env {
-		match, err := regexp.MatchString(v, os.Getenv(k))
+// matchActivation checks if all rules of the activation match and returns a description of the matched rules
+func matchActivation(basePath string, activation *latest.ProfileActivation, lookup LookupFn) (bool, []string, error) {
+	if activation == nil {
+		return false, nil, nil
+	}
+
+	reasons := []string{}
+	matched, err := matchEnvironment(activation.Environment, &reasons)
+	if err != nil || !matched {
+		return false, nil, err
+	}
+
+	for _, rule := range []struct {
+		name     string
+		pattern  string
+		variable string
+	}{
+		{name: "kubeContext", pattern: activation.KubeContext, variable: "DEVSPACE_CONTEXT"},
+		{name: "namespace", pattern: activation.Namespace, variable: "DEVSPACE_NAMESPACE"},
+		{name: "gitBranch", pattern: activation.GitBranch, variable: "DEVSPACE_GIT_BRANCH"},
+	} {
+		if rule.pattern == "" {
+			continue
+		}
+
+		// a kube context, namespace or git branch that cannot be determined does not match
+		value := ""
+		if lookup != nil {
+			if v, err := lookup(rule.variable); err == nil {
+				value = fmt.Sprint(v)
+			}
+		}
+		if value == "" || !matchGlob(rule.pattern, value) {
+			return false, nil, nil
+		}
+
+		reasons = append(reasons, fmt.Sprintf("%s %s matches %s", rule.name, value, rule.pattern))
+	}
+
+	if activation.OS != "" {
+		if !matchGlob(activation.OS, runtime.GOOS) {
+			return false, nil, nil
+		}
+
+		reasons = append(reasons, fmt.Sprintf("os %s matches %s", runtime.GOOS, activation.OS))
+	}
+
+	matched, err = matchVars(activation.Vars, lookup, &reasons)
+	if err != nil || !matched {
+		return false, nil, err
+	}
+
+	for _, file := range activation.Files {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(basePath, path)
+		}
+
+		_, err := os.Stat(path)
+		if err != nil {
+			return false, nil, nil
+		}
+
+		reasons = append(reasons, fmt.Sprintf("file %s exists", file))
+	}
+
+	for i, child := range activation.All {
+		matched, childReasons, err := matchActivation(basePath, child, lookup)
+		if err != nil {
+			return false, nil, errors.Wrapf(err, "all[%d]", i)
+		} else if !matched {
+			return false, nil, nil
+		}
+
+		reasons = append(reasons, childReasons...)
+	}
+
+	if len(activation.Any) > 0 {
+		matched := false
+		for i, child := range activation.Any {
+			childMatched, childReasons, err := matchActivation(basePath, child, lookup)
+			if err != nil {
+				return false, nil, errors.Wrapf(err, "any[%d]", i)
+			} else if childMatched {
+				matched = true
+				reasons = append(reasons, childReasons...)
+				break
+			}
+		}
+		if !matched {
+			return false, nil, nil
+		}
+	}
+
+	return true, reasons, nil
+}
+
+func matchEnvironment(env map[string]string, reasons *[]string) (bool, error) {
+	for _, k := range sortedKeys(env) {
+		match, err := regexp.MatchString(env[k], os.Getenv(k))
 		if err != nil {
 			return false, err
 		}
@@ -432,11 +560,57 @@ func matchEnvironment(env map[string]string) (bool, error) {
 		if !match {
 			return false, nil
 		}
+
+		*reasons = append(*reasons, fmt.Sprintf("env %s matches %s", k, env[k]))
 	}
 
 	return true, nil
 }
 
+func matchVars(vars map[string]string, lookup LookupFn, reasons *[]string) (bool, error) {
+	for _, k := range sortedKeys(vars) {
+		if lookup == nil {
+			return false, nil
+		}
+
+		value, err := lookup(k)
+		if err != nil {
+			return false, err
+		}
+
+		match, err := regexp.MatchString(vars[k], fmt.Sprint(value))
+		if err != nil {
+			return false, err
+		}
+
+		if !match {
+			return false, nil
+		}
+
+		*reasons = append(*reasons, fmt.Sprintf("var %s matches %s", k, vars[k]))
+	}
+
+	return true, nil
+}
+
+// matchGlob matches the value against a glob pattern where * matches any characters and ? a single character
+func matchGlob(pattern, value string) bool {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.Replace(expression, "\\*", ".*", -1)
+	expression = strings.Replace(expression, "\\?", ".", -1)
+	return regexp.MustCompile("^" + expression + "$").MatchString(value)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
 func filterProfileParents(profileParents []string) []string {
 	return util.Filter(profileParents, func(oidx int, os string) bool {
 		return !util.Contains(profileParents, func(iidx int, is string) bool {
@@ -1,13 +1,19 @@
 package versions
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/v1alpha1"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"github.com/pkg/errors"
 
+	yaml "gopkg.in/yaml.v2"
 	"gotest.tools/assert"
 )
 
@@ -42,3 +48,97 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, latest.Version, config.Version, "Conversion to latest version not correct")
 	assert.Equal(t, "TestImg", config.Images["TestImg"].Image, "Conversion to latest version not correct")
 }
+
+const activationTestConfig = `version: v1beta10
+profiles:
+- name: env
+  activation:
+  - env:
+      TEST_ACTIVATION_ENV: "prod-\\d+"
+- name: context
+  activation:
+  - kubeContext: prod-*
+    namespace: "team-?"
+- name: branch
+  activation:
+  - gitBranch: release/*
+- name: os
+  activation:
+  - os: ` + runtime.GOOS + `
+- name: vars
+  activation:
+  - vars:
+      STAGE: "^(staging|production)$"
+- name: files
+  activation:
+  - files:
+    - exists.txt
+    - missing.txt
+- name: any
+  activation:
+  - any:
+    - gitBranch: main
+    - files:
+      - exists.txt
+- name: all
+  activation:
+  - all:
+    - kubeContext: prod-eu
+    - vars:
+        STAGE: staging
+- name: multiple
+  activation:
+  - kubeContext: dev-*
+  - os: ` + runtime.GOOS + `
+`
+
+func TestActivatedProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "exists.txt"), []byte(""), 0644)
+	assert.NilError(t, err)
+
+	os.Setenv("TEST_ACTIVATION_ENV", "prod-12")
+	defer os.Unsetenv("TEST_ACTIVATION_ENV")
+
+	data := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(activationTestConfig), &data)
+	assert.NilError(t, err)
+
+	values := map[string]interface{}{
+		"DEVSPACE_CONTEXT":   "prod-eu",
+		"DEVSPACE_NAMESPACE": "team-a",
+		"STAGE":              "staging",
+	}
+	lookup := func(name string) (interface{}, error) {
+		if value, ok := values[name]; ok {
+			return value, nil
+		}
+
+		return nil, errors.Errorf("variable %s is not defined", name)
+	}
+
+	activated, err := ActivatedProfiles(dir, data, lookup)
+	assert.NilError(t, err)
+
+	reasons := map[string]string{}
+	names := []string{}
+	for _, profile := range activated {
+		names = append(names, profile.Name)
+		reasons[profile.Name] = profile.Reason
+	}
+
+	assert.DeepEqual(t, names, []string{"env", "context", "os", "vars", "any", "all", "multiple"})
+	assert.Equal(t, reasons["env"], "env TEST_ACTIVATION_ENV matches prod-\\d+")
+	assert.Equal(t, reasons["context"], "kubeContext prod-eu matches prod-*, namespace team-a matches team-?")
+	assert.Equal(t, reasons["any"], "file exists.txt exists")
+	assert.Equal(t, reasons["all"], "kubeContext prod-eu matches prod-eu, var STAGE matches staging")
+	assert.Equal(t, reasons["multiple"], "os "+runtime.GOOS+" matches "+runtime.GOOS)
+
+	// undefined variables used in vars rules are an error
+	values = map[string]interface{}{}
+	_, err = ActivatedProfiles(dir, data, lookup)
+	assert.ErrorContains(t, err, "profiles[4].activation[0]: variable STAGE is not defined")
+}
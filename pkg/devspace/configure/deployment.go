@@ -64,7 +64,7 @@ func (m *manager) AddKubectlDeployment(deploymentName string, isKustomization bo
 		}, m.config.Deployments...)
 
 		if isKustomization {
-			m.config.Deployments[0].Kubectl.Kustomize = ptr.Bool(isKustomization)
+			m.config.Deployments[0].Kubectl.Kustomize = v1.KustomizeModeBinary
 		}
 
 		break
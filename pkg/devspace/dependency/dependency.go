@@ -249,12 +249,20 @@ type RenderOptions struct {
 	SkipBuild          bool
 	Writer             io.Writer
 
+	// ManifestWriter writes the rendered manifests of every dependency into its own folder of the output directory
+	ManifestWriter *deploy.ManifestWriter
+
 	BuildOptions build.Options
 }
 
 func (m *manager) RenderAll(options RenderOptions) ([]types.Dependency, error) {
 	return m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, "Render", func(dependency *Dependency, log log.Logger) error {
-		return dependency.Render(options.SkipBuild, &options.BuildOptions, options.Writer, log)
+		var manifestWriter *deploy.ManifestWriter
+		if options.ManifestWriter != nil {
+			manifestWriter = options.ManifestWriter.Dependency(dependency.Name())
+		}
+
+		return dependency.Render(options.SkipBuild, &options.BuildOptions, options.Writer, manifestWriter, log)
 	})
 }
 
@@ -500,7 +508,7 @@ func (d *Dependency) Deploy(forceDependencies, skipBuild, skipDeploy, forceDeplo
 }
 
 // Render renders the dependency
-func (d *Dependency) Render(skipBuild bool, buildOptions *build.Options, out io.Writer, log log.Logger) error {
+func (d *Dependency) Render(skipBuild bool, buildOptions *build.Options, out io.Writer, manifestWriter *deploy.ManifestWriter, log log.Logger) error {
 	// Switch current working directory
 	currentWorkingDirectory, err := d.changeWorkingDirectory()
 	if err != nil {
@@ -517,7 +525,8 @@ func (d *Dependency) Render(skipBuild bool, buildOptions *build.Options, out io.
 
 	// Deploy all defined deployments
 	return d.deployController.Render(&deploy.Options{
-		BuiltImages: builtImages,
+		BuiltImages:    builtImages,
+		ManifestWriter: manifestWriter,
 	}, out, log)
 }
 
@@ -600,6 +609,13 @@ func (d *Dependency) buildImages(skipBuild bool, buildOptions *build.Options, lo
 	// Check if image build is enabled
 	builtImages := make(map[string]string)
 	if skipBuild == false && d.dependencyConfig.SkipBuild == false {
+		// Mark reported images as coming from this dependency
+		if buildOptions.Report != nil {
+			dependencyBuildOptions := *buildOptions
+			dependencyBuildOptions.Report = buildOptions.Report.WithDependency(d.Name())
+			buildOptions = &dependencyBuildOptions
+		}
+
 		// Build images
 		builtImages, err = d.buildController.Build(buildOptions, log)
 		if err != nil {
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"bytes"
 	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
@@ -11,6 +12,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
 	helmclient "github.com/loft-sh/devspace/pkg/devspace/helm"
 	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
@@ -27,13 +29,24 @@ type Options struct {
 	ForceDeploy bool
 	BuiltImages map[string]string
 	Deployments []string
+
+	// MaxConcurrentDeploys is the maximum number of deployments that are deployed in parallel,
+	// 0 or 1 deploys one deployment after another
+	MaxConcurrentDeploys int
+
+	// ManifestWriter writes the rendered manifests into an output directory instead of the writer during render
+	ManifestWriter *ManifestWriter
 }
 
 // Controller is the main deploying interface
 type Controller interface {
 	Deploy(options *Options, log log.Logger) error
 	Render(options *Options, out io.Writer, log log.Logger) error
+	Diff(options *Options, out io.Writer, log log.Logger) (bool, error)
+	Status(options *Options, log log.Logger) ([]*DeploymentStatus, error)
 	Purge(deployments []string, log log.Logger) error
+	History(deployment string, log log.Logger) ([]*deployer.Revision, error)
+	Rollback(deployment string, revision int, log log.Logger) error
 }
 
 type controller struct {
@@ -77,7 +90,7 @@ func (c *controller) Render(options *Options, out io.Writer, log log.Logger) err
 				}
 			}
 
-			deployClient, err := c.getDeployClient(deployConfig, helmV2Clients, log)
+			deployClient, err := c.getDeployClient(deployConfig, helmV2Clients, true, log)
 			if err != nil {
 				return err
 			}
@@ -89,7 +102,16 @@ func (c *controller) Render(options *Options, out io.Writer, log log.Logger) err
 				return pluginErr
 			}
 
-			err = deployClient.Render(options.BuiltImages, out)
+			renderOut := out
+			buffer := &bytes.Buffer{}
+			if options.ManifestWriter != nil {
+				renderOut = buffer
+			}
+
+			err = deployClient.Render(options.BuiltImages, renderOut)
+			if err == nil && options.ManifestWriter != nil {
+				err = options.ManifestWriter.Write(deployConfig.Name, buffer.String())
+			}
 			if err != nil {
 				pluginErr := plugin.ExecutePluginHookWithContext("deploy.errorRender", map[string]interface{}{
 					"DEPLOY_CONFIG": deployConfig,
@@ -114,7 +136,56 @@ func (c *controller) Render(options *Options, out io.Writer, log log.Logger) err
 	return nil
 }
 
-func (c *controller) getDeployClient(deployConfig *latest.DeploymentConfig, helmV2Clients map[string]helmtypes.Client, log log.Logger) (deployer.Interface, error) {
+// Diff prints the differences between the rendered and the deployed objects of all deployments
+// and returns true if there are any changes
+func (c *controller) Diff(options *Options, out io.Writer, log log.Logger) (bool, error) {
+	config := c.config.Config()
+	helmV2Clients := map[string]helmtypes.Client{}
+	hasChanges := false
+	for _, deployConfig := range config.Deployments {
+		if len(options.Deployments) > 0 {
+			shouldSkip := true
+
+			for _, deployment := range options.Deployments {
+				if deployment == strings.TrimSpace(deployConfig.Name) {
+					shouldSkip = false
+					break
+				}
+			}
+
+			if shouldSkip {
+				continue
+			}
+		}
+
+		deployClient, err := c.getDeployClient(deployConfig, helmV2Clients, true, log)
+		if err != nil {
+			return false, err
+		}
+
+		diffs, err := deployClient.Diff(options.BuiltImages)
+		if err != nil {
+			return false, errors.Errorf("error diffing %s: %v", deployConfig.Name, err)
+		}
+
+		diff.Print(out, deployConfig.Name, diffs)
+
+		counts := map[diff.Action]int{}
+		for _, objDiff := range diffs {
+			counts[objDiff.Action]++
+		}
+		if diff.HasChanges(diffs) {
+			hasChanges = true
+			log.Infof("Deployment %s: %d to create, %d to change, %d to delete", deployConfig.Name, counts[diff.ActionCreated], counts[diff.ActionChanged], counts[diff.ActionDeleted])
+		} else {
+			log.Donef("Deployment %s is up to date", deployConfig.Name)
+		}
+	}
+
+	return hasChanges, nil
+}
+
+func (c *controller) getDeployClient(deployConfig *latest.DeploymentConfig, helmV2Clients map[string]helmtypes.Client, dryInit bool, log log.Logger) (deployer.Interface, error) {
 	var (
 		deployClient deployer.Interface
 		err          error
@@ -127,7 +198,7 @@ func (c *controller) getDeployClient(deployConfig *latest.DeploymentConfig, helm
 
 	} else if deployConfig.Helm != nil {
 		// Get helm client
-		helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, true, log)
+		helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, dryInit, log)
 		if err != nil {
 			return nil, errors.Wrap(err, "get cached helm client")
 		}
@@ -142,18 +213,73 @@ func (c *controller) getDeployClient(deployConfig *latest.DeploymentConfig, helm
 	return deployClient, nil
 }
 
+// History returns the revisions of the given deployment
+func (c *controller) History(deployment string, log log.Logger) ([]*deployer.Revision, error) {
+	deployConfig, err := c.findDeployment(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	deployClient, err := c.getDeployClient(deployConfig, map[string]helmtypes.Client{}, false, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployClient.History()
+}
+
+// Rollback rolls the given deployment back to the given revision or to the previous revision if revision is 0
+func (c *controller) Rollback(deployment string, revision int, log log.Logger) error {
+	deployConfig, err := c.findDeployment(deployment)
+	if err != nil {
+		return err
+	}
+
+	deployClient, err := c.getDeployClient(deployConfig, map[string]helmtypes.Client{}, false, log)
+	if err != nil {
+		return err
+	}
+
+	pluginErr := plugin.ExecutePluginHookWithContext("deploy.beforeRollback", map[string]interface{}{
+		"DEPLOY_CONFIG": deployConfig,
+	})
+	if pluginErr != nil {
+		return pluginErr
+	}
+
+	err = deployClient.Rollback(revision)
+	if err != nil {
+		pluginErr := plugin.ExecutePluginHookWithContext("deploy.errorRollback", map[string]interface{}{
+			"DEPLOY_CONFIG": deployConfig,
+			"ERROR":         err,
+		})
+		if pluginErr != nil {
+			return pluginErr
+		}
+
+		return errors.Errorf("error rolling back %s: %v", deployConfig.Name, err)
+	}
+
+	return plugin.ExecutePluginHookWithContext("deploy.afterRollback", map[string]interface{}{
+		"DEPLOY_CONFIG": deployConfig,
+	})
+}
+
+func (c *controller) findDeployment(name string) (*latest.DeploymentConfig, error) {
+	for _, deployConfig := range c.config.Config().Deployments {
+		if deployConfig.Name == name {
+			return deployConfig, nil
+		}
+	}
+
+	return nil, errors.Errorf("deployment %s does not exist", name)
+}
+
 // Deploy deploys all deployments in the config
 func (c *controller) Deploy(options *Options, log log.Logger) error {
 	config := c.config.Config()
 	if config.Deployments != nil && len(config.Deployments) > 0 {
-		helmV2Clients := map[string]helmtypes.Client{}
-
-		// Execute before deployments deploy hook
-		err := c.hookExecuter.Execute(hook.Before, hook.StageDeployments, hook.All, hook.Context{Client: c.client}, log)
-		if err != nil {
-			return err
-		}
-
+		deployConfigs := []*latest.DeploymentConfig{}
 		for _, deployConfig := range config.Deployments {
 			if len(options.Deployments) > 0 {
 				shouldSkip := true
@@ -170,78 +296,41 @@ func (c *controller) Deploy(options *Options, log log.Logger) error {
 				}
 			}
 
-			var (
-				deployClient deployer.Interface
-				err          error
-				method       string
-			)
+			deployConfigs = append(deployConfigs, deployConfig)
 
-			if deployConfig.Kubectl != nil {
-				deployClient, err = kubectl.New(c.config, c.dependencies, c.client, deployConfig, log)
-				if err != nil {
-					return errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
-				}
+			// make sure the deployment caches exist before we deploy in parallel
+			c.config.Generated().GetActive().GetDeploymentCache(deployConfig.Name)
+		}
 
-				method = "kubectl"
-			} else if deployConfig.Helm != nil {
-				// Get helm client
-				helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, false, log)
-				if err != nil {
-					return err
-				}
+		deployConfigs, err := SortDeployments(deployConfigs)
+		if err != nil {
+			return err
+		}
 
-				deployClient, err = helm.New(c.config, c.dependencies, helmClient, c.client, deployConfig, log)
+		// Execute before deployments deploy hook
+		err = c.hookExecuter.Execute(hook.Before, hook.StageDeployments, hook.All, hook.Context{Client: c.client}, log)
+		if err != nil {
+			return err
+		}
+
+		scheduler := &deployScheduler{
+			controller:    c,
+			options:       options,
+			helmV2Clients: map[string]helmtypes.Client{},
+			dependedOn:    dependedOn(deployConfigs),
+		}
+		if options.MaxConcurrentDeploys <= 1 || len(deployConfigs) <= 1 {
+			for _, deployConfig := range deployConfigs {
+				err = scheduler.deploy(deployConfig, log)
 				if err != nil {
-					return errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+					return err
 				}
-
-				method = "helm"
-			} else {
-				return errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
 			}
-
-			// Execute before deployment deploy hook
-			err = c.hookExecuter.Execute(hook.Before, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
+		} else {
+			err = scheduler.deployParallel(deployConfigs, log)
 			if err != nil {
 				return err
 			}
-			pluginErr := plugin.ExecutePluginHookWithContext("deploy.beforeDeploy", map[string]interface{}{
-				"DEPLOY_CONFIG": deployConfig,
-			})
-			if pluginErr != nil {
-				return pluginErr
-			}
-
-			wasDeployed, err := deployClient.Deploy(options.ForceDeploy, options.BuiltImages)
-			if err != nil {
-				c.hookExecuter.OnError(hook.StageDeployments, []string{hook.All, deployConfig.Name}, hook.Context{Client: c.client, Error: err}, log)
-				pluginErr := plugin.ExecutePluginHookWithContext("deploy.errorDeploy", map[string]interface{}{
-					"DEPLOY_CONFIG": deployConfig,
-					"ERROR":         err,
-				})
-				if pluginErr != nil {
-					return pluginErr
-				}
-				return errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
-			}
-
-			if wasDeployed {
-				log.Donef("Successfully deployed %s with %s", deployConfig.Name, method)
-
-				// Execute after deployment deploy hook
-				err = c.hookExecuter.Execute(hook.After, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
-				if err != nil {
-					return err
-				}
-				pluginErr := plugin.ExecutePluginHookWithContext("deploy.afterDeploy", map[string]interface{}{
-					"DEPLOY_CONFIG": deployConfig,
-				})
-				if pluginErr != nil {
-					return pluginErr
-				}
-			} else {
-				log.Infof("Skipping deployment %s", deployConfig.Name)
-			}
 		}
 
 		// Execute after deployments deploy hook
@@ -264,18 +353,23 @@ func (c *controller) Purge(deployments []string, log log.Logger) error {
 	if config.Deployments != nil {
 		helmV2Clients := map[string]helmtypes.Client{}
 
+		// Purge in reverse dependency order
+		deployConfigs, err := SortDeployments(config.Deployments)
+		if err != nil {
+			return err
+		}
+
 		// Execute before deployments purge hook
-		err := c.hookExecuter.Execute(hook.Before, hook.StagePurgeDeployments, hook.All, hook.Context{Client: c.client}, log)
+		err = c.hookExecuter.Execute(hook.Before, hook.StagePurgeDeployments, hook.All, hook.Context{Client: c.client}, log)
 		if err != nil {
 			return err
 		}
 
-		// Reverse them
-		for i := len(config.Deployments) - 1; i >= 0; i-- {
+		for i := len(deployConfigs) - 1; i >= 0; i-- {
 			var (
 				err          error
 				deployClient deployer.Interface
-				deployConfig = config.Deployments[i]
+				deployConfig = deployConfigs[i]
 			)
 
 			// Check if we should skip deleting deployment
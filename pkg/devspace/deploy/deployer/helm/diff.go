@@ -0,0 +1,82 @@
+package helm
+
+import (
+	"bytes"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// hookAnnotation marks helm hooks, which are not part of the release manifest
+const hookAnnotation = "helm.sh/hook"
+
+// Diff compares the rendered chart with the manifest of the deployed release
+func (d *DeployConfig) Diff(builtImages map[string]string) ([]*diff.ObjectDiff, error) {
+	rendered, err := d.renderObjects(builtImages)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := d.Helm.GetManifest(d.DeploymentConfig.Name, d.DeploymentConfig.Namespace, d.DeploymentConfig.Helm)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed, err := diff.ParseObjects(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Objects(deployed, rendered)
+}
+
+// Drift compares the chart rendered with the last built images with the objects in the cluster
+func (d *DeployConfig) Drift() ([]*diff.ObjectDiff, error) {
+	rendered, err := d.renderObjects(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := d.DeploymentConfig.Namespace
+	if namespace == "" {
+		namespace = d.Kube.Namespace()
+	}
+
+	return kubectl.LiveDiff(d.Kube, namespace, rendered)
+}
+
+// renderObjects renders the chart and returns all objects that are not hooks
+func (d *DeployConfig) renderObjects(builtImages map[string]string) ([]*unstructured.Unstructured, error) {
+	buffer := &bytes.Buffer{}
+	err := d.Render(builtImages, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := diff.ParseObjects(buffer.String())
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		if _, ok := obj.GetAnnotations()[hookAnnotation]; ok {
+			continue
+		}
+
+		rendered = append(rendered, obj)
+	}
+
+	return rendered, nil
+}
+
+// DeployedObjects returns the objects of the deployed release
+func (d *DeployConfig) DeployedObjects() ([]*unstructured.Unstructured, error) {
+	manifest, err := d.Helm.GetManifest(d.DeploymentConfig.Name, d.DeploymentConfig.Namespace, d.DeploymentConfig.Helm)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.ParseObjects(manifest)
+}
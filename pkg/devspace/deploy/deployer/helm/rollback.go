@@ -0,0 +1,55 @@
+package helm
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+)
+
+// History returns the revisions of the release
+func (d *DeployConfig) History() ([]*deployer.Revision, error) {
+	revisions, err := d.Helm.History(d.DeploymentConfig.Name, d.DeploymentConfig.Namespace, d.DeploymentConfig.Helm)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*deployer.Revision{}
+	for _, revision := range revisions {
+		result = append(result, &deployer.Revision{
+			Revision:    revision.Revision,
+			Updated:     revision.Updated,
+			Status:      revision.Status,
+			Description: revision.Description,
+		})
+	}
+
+	return result, nil
+}
+
+// Rollback rolls the release back to the given revision or to the previous revision if revision is 0
+func (d *DeployConfig) Rollback(revision int) error {
+	if revision == 0 {
+		revisions, err := d.History()
+		if err != nil {
+			return err
+		}
+
+		revision, err = deployer.PreviousRevision(revisions)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.Log.StartWait("Rolling back release " + d.DeploymentConfig.Name)
+	err := d.Helm.Rollback(d.DeploymentConfig.Name, d.DeploymentConfig.Namespace, revision, d.DeploymentConfig.Helm)
+	d.Log.StopWait()
+	if err != nil {
+		return err
+	}
+
+	// make sure the next deploy upgrades the release with the current config again
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	deployCache.DeploymentConfigHash = ""
+	deployCache.HelmReleaseRevision = ""
+
+	d.Log.Donef("Rolled back %s to revision %d", d.DeploymentConfig.Name, revision)
+	return nil
+}
@@ -0,0 +1,95 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+)
+
+type rollbackTestCase struct {
+	name string
+
+	revisions []*helmtypes.Revision
+	revision  int
+
+	expectedRevisions []int
+	expectedErr       string
+}
+
+func TestRollback(t *testing.T) {
+	testCases := []rollbackTestCase{
+		{
+			name: "Roll back to previous revision",
+			revisions: []*helmtypes.Revision{
+				{Revision: 1, Status: "superseded"},
+				{Revision: 2, Status: "deployed"},
+			},
+			expectedRevisions: []int{1, 2, 3},
+		},
+		{
+			name: "Roll back to specific revision",
+			revisions: []*helmtypes.Revision{
+				{Revision: 1, Status: "superseded"},
+				{Revision: 2, Status: "superseded"},
+				{Revision: 3, Status: "deployed"},
+			},
+			revision:          1,
+			expectedRevisions: []int{1, 2, 3, 4},
+		},
+		{
+			name: "No previous revision",
+			revisions: []*helmtypes.Revision{
+				{Revision: 1, Status: "deployed"},
+			},
+			expectedRevisions: []int{1},
+			expectedErr:       "there is no previous revision to roll back to",
+		},
+	}
+
+	for _, testCase := range testCases {
+		cache := generated.New()
+		cache.Profiles[""] = &generated.CacheConfig{
+			Deployments: map[string]*generated.DeploymentCache{
+				"deploy": &generated.DeploymentCache{
+					DeploymentConfigHash: "hash",
+					HelmReleaseRevision:  "2",
+				},
+			},
+		}
+		deployer := &DeployConfig{
+			Helm: &fakehelm.Client{
+				Revisions: map[string][]*helmtypes.Revision{"deploy": testCase.revisions},
+			},
+			DeploymentConfig: &latest.DeploymentConfig{
+				Name: "deploy",
+				Helm: &latest.HelmConfig{},
+			},
+			config: config.NewConfig(nil, latest.NewRaw(), cache, nil, constants.DefaultConfigPath),
+			Log:    &log.FakeLogger{},
+		}
+
+		err := deployer.Rollback(testCase.revision)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+			assert.Equal(t, cache.Profiles[""].Deployments["deploy"].DeploymentConfigHash, "", "Deploy cache not reset in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		revisions, err := deployer.History()
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		numbers := []int{}
+		for _, revision := range revisions {
+			numbers = append(numbers, revision.Revision)
+		}
+		assert.DeepEqual(t, numbers, testCase.expectedRevisions)
+	}
+}
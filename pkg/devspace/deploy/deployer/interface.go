@@ -2,6 +2,9 @@ package deployer
 
 import (
 	"io"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // Interface defines the common interface used for the deployment methods
@@ -9,6 +12,11 @@ type Interface interface {
 	Status() (*StatusResult, error)
 	Deploy(forceDeploy bool, builtImages map[string]string) (bool, error)
 	Render(builtImages map[string]string, out io.Writer) error
+	Diff(builtImages map[string]string) ([]*diff.ObjectDiff, error)
+	Drift() ([]*diff.ObjectDiff, error)
+	DeployedObjects() ([]*unstructured.Unstructured, error)
+	History() ([]*Revision, error)
+	Rollback(revision int) error
 	Delete() error
 }
 
@@ -0,0 +1,284 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// FieldManager is the field manager DevSpace uses for server-side apply
+const FieldManager = "devspace"
+
+// ApplyResult describes what happened to an object during apply
+type ApplyResult string
+
+// List of apply results
+const (
+	ApplyResultCreated    ApplyResult = "created"
+	ApplyResultConfigured ApplyResult = "configured"
+	ApplyResultUnchanged  ApplyResult = "unchanged"
+)
+
+// serverSideApplier applies objects directly through the dynamic client with server-side apply
+type serverSideApplier struct {
+	dynamicClient dynamic.Interface
+	mapper        *restmapper.DeferredDiscoveryRESTMapper
+	namespace     string
+}
+
+func newServerSideApplier(client kubectl.Client, namespace string) (*serverSideApplier, error) {
+	if client == nil {
+		return nil, errors.New("server-side apply requires a kubernetes context")
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(client.RestConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(client.RestConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "create discovery client")
+	}
+
+	return &serverSideApplier{
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		namespace:     namespace,
+	}, nil
+}
+
+// resourceFor returns the resource interface for the object and sets the namespace of namespaced objects
+// to the default namespace if it is not set
+func (s *serverSideApplier) resourceFor(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the resource could have been created during this deployment, e.g. by a CustomResourceDefinition
+		s.mapper.Reset()
+		mapping, err = s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "find resource for %s", gvk.String())
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return s.dynamicClient.Resource(mapping.Resource), nil
+	}
+
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(s.namespace)
+	}
+
+	return s.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// Apply applies the object with server-side apply and returns what has changed
+func (s *serverSideApplier) Apply(obj *unstructured.Unstructured) (ApplyResult, error) {
+	resource, err := s.resourceFor(obj)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := get(resource, obj)
+	if err != nil {
+		return "", err
+	}
+
+	applied, err := patch(resource, obj, false)
+	if err != nil {
+		return "", err
+	}
+
+	return applyResult(existing, applied), nil
+}
+
+// Get returns the object from the cluster or nil if it does not exist
+func (s *serverSideApplier) Get(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	resource, err := s.resourceFor(obj)
+	if err != nil {
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return get(resource, obj)
+}
+
+// DryRun applies the object with server-side dry run and returns the object from the cluster (nil if it does not exist)
+// and the object how it would look like after the apply
+func (s *serverSideApplier) DryRun(obj *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	resource, err := s.resourceFor(obj)
+	if err != nil {
+		// the resource type will be created by this deployment, so the object can only be new
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			return nil, obj, nil
+		}
+
+		return nil, nil, err
+	}
+
+	existing, err := get(resource, obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := patch(resource, obj, true)
+	if err != nil {
+		// the namespace of a new object might not exist yet
+		if existing == nil && kerrors.IsNotFound(errors.Cause(err)) {
+			return nil, obj, nil
+		}
+
+		return nil, nil, err
+	}
+
+	return existing, applied, nil
+}
+
+func get(resource dynamic.ResourceInterface, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	existing, err := resource.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "get %s", objectName(obj))
+	}
+
+	return existing, nil
+}
+
+func patch(resource dynamic.ResourceInterface, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	options := metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        ptr.Bool(true),
+	}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	applied, err := resource.Patch(context.TODO(), obj.GetName(), types.ApplyPatchType, data, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "apply %s", objectName(obj))
+	}
+
+	return applied, nil
+}
+
+// Delete deletes the object and returns false if the object did not exist
+func (s *serverSideApplier) Delete(obj *unstructured.Unstructured) (bool, error) {
+	resource, err := s.resourceFor(obj)
+	if err != nil {
+		// if the resource type does not exist anymore, the object can't exist either
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = resource.Delete(context.TODO(), obj.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "delete %s", objectName(obj))
+	}
+
+	return true, nil
+}
+
+// ObjectRef returns the reference of the object with the namespace the object is applied to
+func (s *serverSideApplier) ObjectRef(obj *unstructured.Unstructured) (ObjectRef, error) {
+	obj = obj.DeepCopy()
+	_, err := s.resourceFor(obj)
+	if err != nil {
+		return ObjectRef{}, err
+	}
+
+	return ObjectRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}, nil
+}
+
+// applyResult compares the object before and after the apply. The api server does not
+// persist no-op applies, so an unchanged resource version means nothing has changed.
+func applyResult(existing, applied *unstructured.Unstructured) ApplyResult {
+	if existing == nil {
+		return ApplyResultCreated
+	} else if applied != nil && existing.GetResourceVersion() == applied.GetResourceVersion() {
+		return ApplyResultUnchanged
+	}
+
+	return ApplyResultConfigured
+}
+
+// flattenObjects replaces lists (kind: List) with their items
+func flattenObjects(objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	flattened := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		if obj == nil || obj.Object == nil {
+			continue
+		}
+
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				itemObj, ok := item.(*unstructured.Unstructured)
+				if !ok {
+					return errors.Errorf("unexpected list item %T", item)
+				}
+
+				flattened = append(flattened, itemObj)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		flattened = append(flattened, obj)
+	}
+
+	return flattened, nil
+}
+
+// objectName returns the name of the object in the kubectl form, e.g. deployment.apps/my-app
+func objectName(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		kind += "." + gvk.Group
+	}
+
+	return fmt.Sprintf("%s/%s", kind, obj.GetName())
+}
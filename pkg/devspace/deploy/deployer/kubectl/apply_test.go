@@ -0,0 +1,91 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"gotest.tools/assert"
+)
+
+func TestApplyResult(t *testing.T) {
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	existing.SetResourceVersion("1")
+	unchanged := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	unchanged.SetResourceVersion("1")
+	changed := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	changed.SetResourceVersion("2")
+
+	assert.Equal(t, applyResult(nil, changed), ApplyResultCreated)
+	assert.Equal(t, applyResult(existing, unchanged), ApplyResultUnchanged)
+	assert.Equal(t, applyResult(existing, changed), ApplyResultConfigured)
+}
+
+func TestFlattenObjects(t *testing.T) {
+	objects, err := stringToUnstructuredArray(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: second
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: third
+`)
+	assert.NilError(t, err)
+
+	flattened, err := flattenObjects(objects)
+	assert.NilError(t, err)
+
+	names := []string{}
+	for _, obj := range flattened {
+		names = append(names, objectName(obj))
+	}
+	assert.DeepEqual(t, names, []string{"configmap/first", "deployment.apps/second", "service/third"})
+}
+
+func TestManifestReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"b.yaml":     "apiVersion: v1\nkind: Service\nmetadata:\n  name: b\n",
+		"a.yml":      "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n",
+		"c.json":     `{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "c"}}`,
+		"README.md":  "not a manifest",
+		"sub/d.yaml": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: d\n",
+	}
+	for name, content := range files {
+		err = os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		assert.NilError(t, err)
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err)
+	}
+
+	objects, err := NewManifestReader().Build(dir, nil)
+	assert.NilError(t, err)
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, objectName(obj))
+	}
+	assert.DeepEqual(t, names, []string{"configmap/a", "service/b", "secret/c"})
+
+	objects, err = NewManifestReader().Build(filepath.Join(dir, "sub", "d.yaml"), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 1)
+	assert.Equal(t, objectName(objects[0]), "pod/d")
+}
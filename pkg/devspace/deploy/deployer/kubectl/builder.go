@@ -6,9 +6,16 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/pkg/errors"
+	"io/ioutil"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
 	"strings"
 )
 
@@ -52,6 +59,82 @@ func (k *kustomizeBuilder) Build(manifest string, cmd RunCommand) ([]*unstructur
 	return stringToUnstructuredArray(string(output))
 }
 
+type embeddedKustomizeBuilder struct {
+	config *latest.DeploymentConfig
+	log    log.Logger
+}
+
+// NewEmbeddedKustomizeBuilder creates a new manifest builder that renders kustomizations with the
+// kustomize library built into devspace instead of a kustomize or kubectl binary
+func NewEmbeddedKustomizeBuilder(config *latest.DeploymentConfig, log log.Logger) Builder {
+	return &embeddedKustomizeBuilder{
+		config: config,
+		log:    log,
+	}
+}
+
+func (k *embeddedKustomizeBuilder) Build(manifest string, cmd RunCommand) ([]*unstructured.Unstructured, error) {
+	options, err := embeddedKustomizeOptions(k.config.Kubectl.KustomizeArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	k.log.Infof("Render manifests with embedded kustomize %s", manifest)
+	resources, err := krusty.MakeKustomizer(options).Run(filesys.MakeFsOnDisk(), manifest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "kustomize build %s", manifest)
+	}
+
+	// parse the output like the output of the kustomize binary to get the same objects
+	output, err := resources.AsYaml()
+	if err != nil {
+		return nil, errors.Wrapf(err, "kustomize build %s", manifest)
+	}
+
+	return stringToUnstructuredArray(string(output))
+}
+
+// embeddedKustomizeOptions converts the kustomize build flags of kustomizeArgs into kustomize options
+func embeddedKustomizeOptions(args []string) (*krusty.Options, error) {
+	// like kustomize build the resources are sorted by default
+	options := krusty.MakeDefaultOptions()
+	options.DoLegacyResourceSort = true
+	for i := 0; i < len(args); i++ {
+		name, value := args[i], ""
+		if idx := strings.Index(name, "="); idx != -1 {
+			name, value = name[:idx], name[idx+1:]
+		} else if i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "--load-restrictor", "--load_restrictor":
+			switch value {
+			case types.LoadRestrictionsRootOnly.String():
+				options.LoadRestrictions = types.LoadRestrictionsRootOnly
+			case types.LoadRestrictionsNone.String():
+				options.LoadRestrictions = types.LoadRestrictionsNone
+			default:
+				return nil, errors.Errorf("invalid value %s for %s", value, name)
+			}
+		case "--reorder":
+			switch value {
+			case "legacy":
+				options.DoLegacyResourceSort = true
+			case "none":
+				options.DoLegacyResourceSort = false
+			default:
+				return nil, errors.Errorf("invalid value %s for %s", value, name)
+			}
+		default:
+			return nil, errors.Errorf("kustomizeArgs %s is not supported with kustomize: %s", name, latest.KustomizeModeEmbedded)
+		}
+	}
+
+	return options, nil
+}
+
 type kubectlBuilder struct {
 	path        string
 	config      *latest.DeploymentConfig
@@ -81,7 +164,7 @@ func (k *kubectlBuilder) Build(manifest string, cmd RunCommand) ([]*unstructured
 	}
 
 	args = append(args, "--dry-run", "--output", "yaml", "--validate=false")
-	if k.config.Kubectl.Kustomize != nil && *k.config.Kubectl.Kustomize == true {
+	if isKustomize(k.config) {
 		args = append(args, "--kustomize", manifest)
 	} else {
 		args = append(args, "--filename", manifest)
@@ -104,6 +187,76 @@ func (k *kubectlBuilder) Build(manifest string, cmd RunCommand) ([]*unstructured
 	return stringToUnstructuredArray(string(output))
 }
 
+type manifestReader struct{}
+
+// NewManifestReader creates a new manifest builder that reads the manifests from files, directories
+// or urls directly without the need of a kubectl binary
+func NewManifestReader() Builder {
+	return &manifestReader{}
+}
+
+func (m *manifestReader) Build(manifest string, cmd RunCommand) ([]*unstructured.Unstructured, error) {
+	if strings.HasPrefix(manifest, "http://") || strings.HasPrefix(manifest, "https://") {
+		resp, err := http.Get(manifest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "download %s", manifest)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("download %s: unexpected status code %d", manifest, resp.StatusCode)
+		}
+
+		out, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "download %s", manifest)
+		}
+
+		return stringToUnstructuredArray(string(out))
+	}
+
+	stat, err := os.Stat(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	// like kubectl we only read the yaml and json files directly within a directory
+	files := []string{manifest}
+	if stat.IsDir() {
+		files = []string{}
+		entries, err := ioutil.ReadDir(manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+				continue
+			}
+
+			files = append(files, filepath.Join(manifest, entry.Name()))
+		}
+	}
+
+	objects := []*unstructured.Unstructured{}
+	for _, file := range files {
+		out, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		fileObjects, err := stringToUnstructuredArray(string(out))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s", file)
+		}
+
+		objects = append(objects, fileObjects...)
+	}
+
+	return objects, nil
+}
+
 var diffSeparator = regexp.MustCompile(`\n---`)
 
 // stringToUnstructuredArray splits a YAML file into unstructured objects. Returns a list of all unstructured objects
@@ -0,0 +1,88 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type embeddedKustomizeTestCase struct {
+	name string
+
+	manifest      string
+	kustomizeArgs []string
+
+	expectedObjects  []string
+	expectedReplicas int64
+	expectedErr      string
+}
+
+func TestEmbeddedKustomizeBuilder(t *testing.T) {
+	testCases := []embeddedKustomizeTestCase{
+		{
+			name:             "base",
+			manifest:         "testdata/kustomize/base",
+			expectedObjects:  []string{"Service//app", "Deployment//app"},
+			expectedReplicas: 1,
+		},
+		{
+			name:             "overlay",
+			manifest:         "testdata/kustomize/overlay",
+			expectedObjects:  []string{"Service/dev/dev-app", "Deployment/dev/dev-app"},
+			expectedReplicas: 3,
+		},
+		{
+			name:             "keep resource order",
+			manifest:         "testdata/kustomize/base",
+			kustomizeArgs:    []string{"--reorder", "none"},
+			expectedObjects:  []string{"Deployment//app", "Service//app"},
+			expectedReplicas: 1,
+		},
+		{
+			name:        "files outside of the kustomization",
+			manifest:    "testdata/kustomize/outside",
+			expectedErr: "security; file",
+		},
+		{
+			name:            "disabled load restrictions",
+			manifest:        "testdata/kustomize/outside",
+			kustomizeArgs:   []string{"--load-restrictor=LoadRestrictionsNone"},
+			expectedObjects: []string{"Service//app"},
+		},
+		{
+			name:          "unsupported argument",
+			manifest:      "testdata/kustomize/base",
+			kustomizeArgs: []string{"--enable-helm"},
+			expectedErr:   "kustomizeArgs --enable-helm is not supported with kustomize: embedded",
+		},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewEmbeddedKustomizeBuilder(&latest.DeploymentConfig{
+			Kubectl: &latest.KubectlConfig{
+				Kustomize:     latest.KustomizeModeEmbedded,
+				KustomizeArgs: testCase.kustomizeArgs,
+			},
+		}, &log.FakeLogger{})
+
+		objects, err := builder.Build(testCase.manifest, nil)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		names := []string{}
+		for _, obj := range objects {
+			names = append(names, obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName())
+			if obj.GetKind() == "Deployment" {
+				replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+				assert.Equal(t, replicas, testCase.expectedReplicas, "Unexpected replicas in testCase %s", testCase.name)
+			}
+		}
+		assert.DeepEqual(t, names, testCase.expectedObjects)
+	}
+}
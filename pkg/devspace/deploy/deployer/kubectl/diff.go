@@ -0,0 +1,168 @@
+package kubectl
+
+import (
+	"bytes"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Diff compares the rendered manifests with the objects in the cluster. Objects that would be pruned are marked as deleted
+func (d *DeployConfig) Diff(builtImages map[string]string) ([]*diff.ObjectDiff, error) {
+	// kubectl apply doesn't need the permissions for a server-side dry-run, so client mode deployments
+	// are compared with the configuration that was applied last
+	return d.diff(builtImages, isServerSideApply(d.DeploymentConfig), isPrune(d.DeploymentConfig))
+}
+
+// Drift compares the manifests rendered with the last built images with the live objects in the cluster via a server-side
+// dry-run, so that changes that were made in the cluster are detected independent of the apply mode. Objects of the
+// last deployment that are not rendered anymore are always reported as deleted
+func (d *DeployConfig) Drift() ([]*diff.ObjectDiff, error) {
+	return d.diff(nil, true, true)
+}
+
+// diff compares the rendered manifests with the live objects via a server-side dry-run or with the configurations that were applied last and marks the
+// objects of the inventory that are not rendered anymore as deleted if orphans is true
+func (d *DeployConfig) diff(builtImages map[string]string, dryRun, orphans bool) ([]*diff.ObjectDiff, error) {
+	buffer := &bytes.Buffer{}
+	err := d.Render(builtImages, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := diff.ParseObjects(buffer.String())
+	if err != nil {
+		return nil, err
+	}
+
+	applier, err := newServerSideApplier(d.KubeClient, d.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployed, applied []*unstructured.Unstructured
+	if dryRun {
+		deployed, applied, err = liveObjects(applier, rendered)
+	} else {
+		deployed, applied, err = lastAppliedObjects(applier, rendered)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	renderedRefs := map[string]bool{}
+	for _, obj := range applied {
+		renderedRefs[ObjectRef{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}.Key()] = true
+	}
+
+	// objects of the last deployment that are not rendered anymore
+	if orphans {
+		refs, err := loadInventory(d.KubeClient.KubeClient(), d.Namespace, d.inventoryName())
+		if err != nil {
+			return nil, err
+		}
+		refs, err = resolveObjectRefs(applier, refs, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ref := range refs {
+			if renderedRefs[ref.Key()] {
+				continue
+			}
+
+			live, err := applier.Get(ref.Object())
+			if err != nil {
+				return nil, err
+			} else if live != nil {
+				deployed = append(deployed, diff.Normalize(live))
+			}
+		}
+	}
+
+	return diff.Objects(deployed, applied)
+}
+
+// LiveDiff compares the given objects with the objects in the cluster via a server-side dry-run apply,
+// so that fields defaulted by the cluster do not show up as changes
+func LiveDiff(client kubectl.Client, namespace string, objects []*unstructured.Unstructured) ([]*diff.ObjectDiff, error) {
+	applier, err := newServerSideApplier(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed, applied, err := liveObjects(applier, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Objects(deployed, applied)
+}
+
+// liveObjects returns the normalized live objects and the normalized objects as they would look like after applying them
+func liveObjects(applier *serverSideApplier, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, []*unstructured.Unstructured, error) {
+	deployed := []*unstructured.Unstructured{}
+	applied := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		live, dryRun, err := applier.DryRun(obj)
+		if err != nil {
+			return nil, nil, err
+		} else if live != nil {
+			deployed = append(deployed, diff.Normalize(live))
+		}
+
+		applied = append(applied, diff.Normalize(dryRun))
+	}
+
+	return deployed, applied, nil
+}
+
+// lastAppliedObjects returns the configurations that were applied last with kubectl apply and the normalized objects.
+// Live objects without a last applied configuration are normalized instead
+func lastAppliedObjects(applier *serverSideApplier, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, []*unstructured.Unstructured, error) {
+	deployed := []*unstructured.Unstructured{}
+	applied := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		obj = obj.DeepCopy()
+		live, err := applier.Get(obj)
+		if err != nil {
+			return nil, nil, err
+		} else if live != nil {
+			lastApplied, err := lastAppliedConfiguration(live)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			deployed = append(deployed, diff.Normalize(lastApplied))
+		}
+
+		applied = append(applied, diff.Normalize(obj))
+	}
+
+	return deployed, applied, nil
+}
+
+// lastAppliedConfiguration returns the configuration that was stored by kubectl apply in the annotation of the object
+func lastAppliedConfiguration(live *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	annotation := live.GetAnnotations()[lastAppliedConfigAnnotation]
+	if annotation == "" {
+		return live, nil
+	}
+
+	lastApplied := &unstructured.Unstructured{}
+	err := lastApplied.UnmarshalJSON([]byte(annotation))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse last applied configuration of %s", objectName(live))
+	}
+
+	// the namespace is not part of the configuration if it was set via the --namespace flag
+	if lastApplied.GetNamespace() == "" {
+		lastApplied.SetNamespace(live.GetNamespace())
+	}
+
+	return lastApplied, nil
+}
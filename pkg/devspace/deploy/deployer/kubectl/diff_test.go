@@ -0,0 +1,36 @@
+package kubectl
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"gotest.tools/assert"
+)
+
+func TestLastAppliedConfiguration(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	live.SetAPIVersion("v1")
+	live.SetKind("ConfigMap")
+	live.SetNamespace("test")
+	live.SetName("config")
+	live.SetResourceVersion("1")
+
+	// objects that were not applied with kubectl apply are returned unchanged
+	lastApplied, err := lastAppliedConfiguration(live)
+	assert.NilError(t, err)
+	assert.Equal(t, lastApplied, live)
+
+	live.SetAnnotations(map[string]string{
+		lastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config"},"data":{"key":"value"}}`,
+	})
+	lastApplied, err = lastAppliedConfiguration(live)
+	assert.NilError(t, err)
+	assert.Equal(t, lastApplied.GetNamespace(), "test")
+	assert.Equal(t, lastApplied.GetResourceVersion(), "")
+	assert.DeepEqual(t, lastApplied.Object["data"], map[string]interface{}{"key": "value"})
+
+	live.SetAnnotations(map[string]string{lastAppliedConfigAnnotation: "{"})
+	_, err = lastAppliedConfiguration(live)
+	assert.ErrorContains(t, err, "parse last applied configuration of configmap/config")
+}
@@ -0,0 +1,324 @@
+package kubectl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/encryption"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MaxHistory is the number of revisions that are kept for every kubectl deployment
+const MaxHistory = 10
+
+const historyFolder = "history"
+
+// historyEntry is a locally stored revision of a kubectl deployment. The rendered manifest may contain decrypted
+// secrets, so it is stored encrypted with the key of the current user
+type historyEntry struct {
+	Revision    int       `json:"revision"`
+	Updated     time.Time `json:"updated"`
+	Description string    `json:"description"`
+	Context     string    `json:"context,omitempty"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Manifest    string    `json:"manifest"`
+	Encrypted   bool      `json:"encrypted,omitempty"`
+}
+
+// historyKey returns the key of the current user that encrypts the stored manifests
+func historyKey() ([]byte, error) {
+	keyPath, err := generated.UserKeyPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "get key path")
+	}
+
+	key, err := encryption.LoadOrCreateKey(keyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load key")
+	}
+
+	return key, nil
+}
+
+// historyPath returns the folder of the revisions of the deployment. Deployments with the same name in different
+// configs, kube-contexts or namespaces have separate histories, because these are part of the folder name
+func historyPath(configPath, kubeContext, namespace, deploymentName string) string {
+	key := hash.String(strings.Join([]string{configPath, kubeContext, namespace, deploymentName}, ":"))[0:8]
+	return filepath.Join(constants.DefaultCacheFolder, historyFolder, encoding.SafeConcatName(encoding.SafeDNS1123Name(deploymentName), key))
+}
+
+// historyPath returns the folder of the revisions of this deployment
+func (d *DeployConfig) historyPath() string {
+	configPath := ""
+	if d.config != nil {
+		configPath = d.config.Path()
+	}
+
+	return historyPath(configPath, d.Context, d.Namespace, d.Name)
+}
+
+// loadHistory returns the revisions stored in the given folder sorted by revision
+func loadHistory(path string) ([]*historyEntry, error) {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "read history")
+	}
+
+	var key []byte
+	history := []*historyEntry{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		out, err := ioutil.ReadFile(filepath.Join(path, file.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "read history")
+		}
+
+		entry := &historyEntry{}
+		err = json.Unmarshal(out, entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse history file %s", file.Name())
+		}
+
+		if entry.Encrypted {
+			if key == nil {
+				key, err = historyKey()
+				if err != nil {
+					return nil, errors.Wrap(err, "decrypt history")
+				}
+			}
+
+			// revisions that were encrypted with another key, e.g. by another user, cannot be rolled back to
+			decoded, err := base64.StdEncoding.DecodeString(entry.Manifest)
+			if err != nil {
+				continue
+			}
+			decrypted, err := encryption.DecryptAES(key, decoded)
+			if err != nil {
+				continue
+			}
+
+			entry.Manifest = string(decrypted)
+			entry.Encrypted = false
+		}
+
+		history = append(history, entry)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Revision < history[j].Revision
+	})
+	return history, nil
+}
+
+// recordHistory stores the entry as new revision in the given folder, if it differs from the latest revision,
+// and removes the oldest revisions that exceed MaxHistory
+func recordHistory(path string, entry *historyEntry) error {
+	history, err := loadHistory(path)
+	if err != nil {
+		return err
+	}
+
+	entry.Revision = 1
+	if len(history) > 0 {
+		latest := history[len(history)-1]
+		if latest.Manifest == entry.Manifest && latest.Context == entry.Context && latest.Namespace == entry.Namespace {
+			return nil
+		}
+
+		entry.Revision = latest.Revision + 1
+	}
+
+	key, err := historyKey()
+	if err != nil {
+		return errors.Wrap(err, "encrypt history")
+	}
+
+	encrypted, err := encryption.EncryptAES(key, []byte(entry.Manifest))
+	if err != nil {
+		return errors.Wrap(err, "encrypt history")
+	}
+
+	stored := *entry
+	stored.Manifest = base64.StdEncoding.EncodeToString(encrypted)
+	stored.Encrypted = true
+	out, err := json.Marshal(&stored)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path, 0755)
+	if err != nil {
+		return errors.Wrap(err, "create history folder")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(path, strconv.Itoa(entry.Revision)+".json"), out, 0600)
+	if err != nil {
+		return errors.Wrap(err, "write history")
+	}
+
+	for i := 0; i < len(history)+1-MaxHistory; i++ {
+		err = os.Remove(filepath.Join(path, strconv.Itoa(history[i].Revision)+".json"))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "remove old revision")
+		}
+	}
+
+	return nil
+}
+
+// recordRevision stores the applied objects as new revision in the local history of the deployment
+func (d *DeployConfig) recordRevision(objects []*unstructured.Unstructured, description string) error {
+	if d.KubeClient == nil {
+		return nil
+	}
+
+	manifest, err := objectsToManifest(objects)
+	if err != nil {
+		return err
+	}
+
+	return recordHistory(d.historyPath(), &historyEntry{
+		Updated:     time.Now(),
+		Description: description,
+		Context:     d.Context,
+		Namespace:   d.Namespace,
+		Manifest:    manifest,
+	})
+}
+
+// History returns the locally stored revisions of the deployment
+func (d *DeployConfig) History() ([]*deployer.Revision, error) {
+	history, err := loadHistory(d.historyPath())
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []*deployer.Revision{}
+	for i, entry := range history {
+		status := "superseded"
+		if i == len(history)-1 {
+			status = "deployed"
+		}
+
+		revisions = append(revisions, &deployer.Revision{
+			Revision:    entry.Revision,
+			Updated:     entry.Updated.Format(time.RFC3339),
+			Status:      status,
+			Description: entry.Description,
+		})
+	}
+
+	return revisions, nil
+}
+
+// Rollback applies the manifests of the given revision or of the previous revision if revision is 0
+func (d *DeployConfig) Rollback(revision int) error {
+	history, err := loadHistory(d.historyPath())
+	if err != nil {
+		return err
+	}
+
+	if revision == 0 {
+		revisions, err := d.History()
+		if err != nil {
+			return err
+		}
+
+		revision, err = deployer.PreviousRevision(revisions)
+		if err != nil {
+			return err
+		}
+	}
+
+	var entry *historyEntry
+	for _, e := range history {
+		if e.Revision == revision {
+			entry = e
+			break
+		}
+	}
+	if entry == nil {
+		return errors.Errorf("revision %d of deployment %s not found (only the last %d revisions are kept)", revision, d.Name, MaxHistory)
+	} else if entry.Context != d.Context || entry.Namespace != d.Namespace {
+		return errors.Errorf("revision %d was deployed to namespace %s in context %s, but the current namespace is %s in context %s", revision, entry.Namespace, entry.Context, d.Namespace, d.Context)
+	}
+
+	objects, err := stringToUnstructuredArray(entry.Manifest)
+	if err != nil {
+		return err
+	}
+
+	err = d.applyObjects(objects)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.updateInventory(objects)
+	if err != nil {
+		return err
+	}
+
+	err = d.recordRevision(objects, fmt.Sprintf("Rollback to %d", revision))
+	if err != nil {
+		return err
+	}
+
+	// make sure the next deploy applies the current manifests again
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	deployCache.DeploymentConfigHash = ""
+	deployCache.KubectlManifestsHash = ""
+
+	d.Log.Donef("Rolled back %s to revision %d", d.Name, revision)
+	return nil
+}
+
+// applyObjects applies the given objects with server-side apply or kubectl apply
+func (d *DeployConfig) applyObjects(objects []*unstructured.Unstructured) error {
+	if isServerSideApply(d.DeploymentConfig) {
+		applier, err := newServerSideApplier(d.KubeClient, d.Namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range objects {
+			result, err := applier.Apply(obj)
+			if err != nil {
+				return err
+			}
+
+			d.Log.Infof("%s %s", objectName(obj), result)
+		}
+
+		return nil
+	}
+
+	manifest, err := objectsToManifest(objects)
+	if err != nil {
+		return err
+	}
+
+	args := d.getCmdArgs("apply", "--force")
+	args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
+	return d.commandExecuter.GetCommand(d.CmdPath, args).Run(d.Log, d.Log, strings.NewReader(manifest))
+}
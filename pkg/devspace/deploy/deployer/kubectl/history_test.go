@@ -0,0 +1,84 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"gotest.tools/assert"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	userKeyPathBackup := generated.UserKeyPath
+	generated.UserKeyPath = func() (string, error) {
+		return filepath.Join(dir, "home", "vars.key"), nil
+	}
+	defer func() { generated.UserKeyPath = userKeyPathBackup }()
+
+	path := filepath.Join(dir, "history", "deploy")
+	history, err := loadHistory(path)
+	assert.NilError(t, err)
+	assert.Equal(t, len(history), 0)
+
+	for i := 0; i < MaxHistory+2; i++ {
+		err = recordHistory(path, &historyEntry{
+			Updated:     time.Now(),
+			Description: "Deploy",
+			Namespace:   "test",
+			Manifest:    "manifest-" + strconv.Itoa(i),
+		})
+		assert.NilError(t, err)
+	}
+
+	// an unchanged manifest does not create a new revision
+	err = recordHistory(path, &historyEntry{
+		Updated:     time.Now(),
+		Description: "Deploy",
+		Namespace:   "test",
+		Manifest:    "manifest-" + strconv.Itoa(MaxHistory+1),
+	})
+	assert.NilError(t, err)
+
+	history, err = loadHistory(path)
+	assert.NilError(t, err)
+	assert.Equal(t, len(history), MaxHistory)
+	assert.Equal(t, history[0].Revision, 3)
+	assert.Equal(t, history[0].Manifest, "manifest-2")
+	assert.Equal(t, history[len(history)-1].Revision, MaxHistory+2)
+	assert.Equal(t, history[len(history)-1].Namespace, "test")
+
+	// the manifests may contain secrets and are not stored in plain text
+	out, err := ioutil.ReadFile(filepath.Join(path, strconv.Itoa(MaxHistory+2)+".json"))
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(out), "manifest-"), "manifest stored in plain text: %s", string(out))
+
+	// revisions that cannot be decrypted with the key of the current user are ignored
+	generated.UserKeyPath = func() (string, error) {
+		return filepath.Join(dir, "other", "vars.key"), nil
+	}
+	history, err = loadHistory(path)
+	assert.NilError(t, err)
+	assert.Equal(t, len(history), 0)
+}
+
+func TestHistoryPath(t *testing.T) {
+	path := historyPath("/project/devspace.yaml", "context", "test", "Backend")
+	assert.Equal(t, filepath.Dir(path), filepath.Join(".devspace", historyFolder))
+	assert.Assert(t, strings.HasPrefix(filepath.Base(path), "backend-"), "unexpected history path %s", path)
+	assert.Equal(t, path, historyPath("/project/devspace.yaml", "context", "test", "Backend"))
+
+	// deployments that only differ in case, config, context or namespace don't share their history
+	assert.Assert(t, path != historyPath("/project/devspace.yaml", "context", "test", "backend"))
+	assert.Assert(t, path != historyPath("/project/dependency/devspace.yaml", "context", "test", "Backend"))
+	assert.Assert(t, path != historyPath("/project/devspace.yaml", "other", "test", "Backend"))
+	assert.Assert(t, path != historyPath("/project/devspace.yaml", "context", "other", "Backend"))
+}
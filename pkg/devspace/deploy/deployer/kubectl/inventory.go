@@ -0,0 +1,299 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// InventoryLabel is the label that is set on the inventory config maps and holds the sanitized deployment name
+	InventoryLabel = "devspace.sh/inventory"
+
+	inventoryPrefix  = "devspace-inventory-"
+	inventoryDataKey = "objects"
+)
+
+// ObjectRef references an object that was applied by a kubectl deployment
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Key returns a unique key for the object, the api version is left out on purpose
+// so that objects that only changed their version are not pruned
+func (o ObjectRef) Key() string {
+	group := o.APIVersion
+	if idx := strings.LastIndex(group, "/"); idx != -1 {
+		group = group[:idx]
+	} else {
+		group = ""
+	}
+
+	return strings.Join([]string{group, o.Kind, o.Namespace, o.Name}, "/")
+}
+
+// Object returns a partial object that can be used to delete the referenced object
+func (o ObjectRef) Object() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(o.APIVersion)
+	obj.SetKind(o.Kind)
+	obj.SetNamespace(o.Namespace)
+	obj.SetName(o.Name)
+	return obj
+}
+
+func (o ObjectRef) String() string {
+	name := objectName(o.Object())
+	if o.Namespace != "" {
+		name = o.Namespace + "/" + name
+	}
+
+	return name
+}
+
+// newObjectRef returns the reference of the object as it was rendered. The namespace is not defaulted,
+// because that would require to look up whether the resource is namespaced
+func newObjectRef(obj *unstructured.Unstructured) ObjectRef {
+	return ObjectRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// resolveObjectRefs defaults the namespaces of the references with the resources known by the applier.
+// If ignoreUnknown is true, references of resources that don't exist anymore are returned unchanged
+func resolveObjectRefs(applier *serverSideApplier, refs []ObjectRef, ignoreUnknown bool) ([]ObjectRef, error) {
+	resolved := []ObjectRef{}
+	for _, ref := range refs {
+		resolvedRef, err := applier.ObjectRef(ref.Object())
+		if err != nil {
+			if ignoreUnknown && meta.IsNoMatchError(errors.Cause(err)) {
+				resolved = append(resolved, ref)
+				continue
+			}
+
+			return nil, err
+		}
+
+		resolved = append(resolved, resolvedRef)
+	}
+
+	return resolved, nil
+}
+
+// inventoryName returns the name of the inventory config map of the deployment. Deployments with the same
+// name in different configs or dependencies deploy to different inventories, because the config key is part of the name
+func inventoryName(configKey, deploymentName string) string {
+	return encoding.SafeConcatName(inventoryPrefix+encoding.SafeDNS1123Name(deploymentName), hash.String(configKey + ":" + deploymentName)[0:8])
+}
+
+// inventoryName returns the name of the inventory config map of this deployment
+func (d *DeployConfig) inventoryName() string {
+	configKey := ""
+	if d.config != nil && d.config.Path() != "" {
+		configKey = inventoryConfigKey(d.config.Path())
+	}
+
+	return inventoryName(configKey, d.Name)
+}
+
+// inventoryConfigKey returns a key for the config at the given path that doesn't depend on where the project is checked out.
+// Downloaded dependencies are identified by their path within the dependency folder, which starts with the dependency id,
+// and configs within a git repository by the origin of the repository and their path within it. Configs outside of a git
+// repository are identified by their absolute path
+func inventoryConfigKey(configPath string) string {
+	relPath, err := filepath.Rel(dependencyutil.DependencyFolderPath, configPath)
+	if err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "dependency:" + filepath.ToSlash(relPath)
+	}
+
+	for dir := filepath.Dir(configPath); ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			relPath, err := filepath.Rel(dir, configPath)
+			if err != nil {
+				break
+			}
+
+			return gitOrigin(dir) + ":" + filepath.ToSlash(relPath)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	return configPath
+}
+
+// gitOrigin returns the url of the origin remote of the repository without scheme, credentials and .git suffix, so that
+// ssh and https clones of a repository return the same origin, or an empty string if there is no origin
+func gitOrigin(repositoryPath string) string {
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return ""
+	}
+
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return ""
+	}
+
+	origin := remote.Config().URLs[0]
+	if idx := strings.Index(origin, "://"); idx != -1 {
+		origin = origin[idx+3:]
+	} else {
+		// scp-like syntax, e.g. git@github.com:org/repo.git
+		origin = strings.Replace(origin, ":", "/", 1)
+	}
+	if idx := strings.Index(origin, "@"); idx != -1 && idx < strings.Index(origin+"/", "/") {
+		origin = origin[idx+1:]
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(origin, "/"), ".git")
+}
+
+// loadInventory returns the object references of the last deployment or nil if there is no inventory
+func loadInventory(client kubernetes.Interface, namespace, name string) ([]ObjectRef, error) {
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "get inventory")
+	}
+
+	refs := []ObjectRef{}
+	if configMap.Data[inventoryDataKey] != "" {
+		err = json.Unmarshal([]byte(configMap.Data[inventoryDataKey]), &refs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse inventory %s", configMap.Name)
+		}
+	}
+
+	return refs, nil
+}
+
+// saveInventory creates or updates the inventory with the given name
+func saveInventory(client kubernetes.Interface, namespace, name, deploymentName string, refs []ObjectRef) error {
+	data, err := json.Marshal(refs)
+	if err != nil {
+		return err
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	configMap, err := configMaps.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return errors.Wrap(err, "get inventory")
+		}
+
+		_, err = configMaps.Create(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					InventoryLabel: encoding.SafeDNS1123Name(deploymentName),
+				},
+			},
+			Data: map[string]string{
+				inventoryDataKey: string(data),
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "create inventory")
+		}
+
+		return nil
+	}
+
+	configMap.Data = map[string]string{
+		inventoryDataKey: string(data),
+	}
+	_, err = configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "update inventory")
+	}
+
+	return nil
+}
+
+// deleteInventory removes the inventory with the given name
+func deleteInventory(client kubernetes.Interface, namespace, name string) error {
+	err := client.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && kerrors.IsNotFound(err) == false {
+		return errors.Wrap(err, "delete inventory")
+	}
+
+	return nil
+}
+
+// objectDeleter deletes objects from the cluster
+type objectDeleter interface {
+	Delete(obj *unstructured.Unstructured) (bool, error)
+}
+
+// pruneObjects deletes all objects of the previous inventory that are not part of the current one
+// in reverse order and returns the pruned objects
+func pruneObjects(deleter objectDeleter, previous, current []ObjectRef) ([]ObjectRef, error) {
+	keep := map[string]bool{}
+	for _, ref := range current {
+		keep[ref.Key()] = true
+	}
+
+	pruned := []ObjectRef{}
+	for i := len(previous) - 1; i >= 0; i-- {
+		if keep[previous[i].Key()] {
+			continue
+		}
+
+		deleted, err := deleter.Delete(previous[i].Object())
+		if err != nil {
+			return pruned, err
+		} else if deleted {
+			pruned = append(pruned, previous[i])
+		}
+	}
+
+	return pruned, nil
+}
+
+// DeployedObjects returns the objects that were applied by the last deployment
+func (d *DeployConfig) DeployedObjects() ([]*unstructured.Unstructured, error) {
+	if d.KubeClient == nil {
+		return nil, nil
+	}
+
+	refs, err := loadInventory(d.KubeClient.KubeClient(), d.Namespace, d.inventoryName())
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*unstructured.Unstructured{}
+	for _, ref := range refs {
+		objects = append(objects, ref.Object())
+	}
+
+	return objects, nil
+}
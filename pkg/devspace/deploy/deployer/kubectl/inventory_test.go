@@ -0,0 +1,179 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	gitconfig "gopkg.in/src-d/go-git.v4/config"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+type fakeDeleter struct {
+	deleted  []string
+	notFound map[string]bool
+	fail     map[string]bool
+}
+
+func (f *fakeDeleter) Delete(obj *unstructured.Unstructured) (bool, error) {
+	name := objectName(obj)
+	if f.fail[name] {
+		return false, errors.Errorf("delete %s failed", name)
+	} else if f.notFound[name] {
+		return false, nil
+	}
+
+	f.deleted = append(f.deleted, name)
+	return true, nil
+}
+
+func TestInventoryName(t *testing.T) {
+	name := inventoryName("devspace.yaml", "Backend_API")
+	assert.Assert(t, len(validation.IsDNS1123Label(name)) == 0, "invalid inventory name %s", name)
+	assert.Assert(t, strings.HasPrefix(name, inventoryPrefix+"backend-api-"), "unexpected inventory name %s", name)
+
+	// same deployment name in another config or with another case
+	assert.Assert(t, name != inventoryName("dependency/devspace.yaml", "Backend_API"))
+	assert.Assert(t, name != inventoryName("devspace.yaml", "backend_api"))
+	assert.Equal(t, name, inventoryName("devspace.yaml", "Backend_API"))
+}
+
+func TestInventoryNameCheckoutIndependent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// returns the deploy config of a config within a checkout of the repository with the given origin
+	loadDeployConfig := func(checkout, origin string) *DeployConfig {
+		repo, err := git.PlainInit(filepath.Join(dir, checkout), false)
+		assert.NilError(t, err)
+		if origin != "" {
+			_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{origin}})
+			assert.NilError(t, err)
+		}
+
+		configPath := filepath.Join(dir, checkout, "project", "devspace.yaml")
+		return &DeployConfig{Name: "backend", config: config.NewConfig(nil, nil, nil, nil, configPath)}
+	}
+
+	name := loadDeployConfig("checkout", "git@github.com:loft-sh/app.git").inventoryName()
+	assert.Equal(t, name, loadDeployConfig("ci/workspace", "https://token@github.com/loft-sh/app").inventoryName())
+	assert.Assert(t, name != loadDeployConfig("other", "git@github.com:loft-sh/other.git").inventoryName())
+	assert.Assert(t, name != loadDeployConfig("no-origin", "").inventoryName())
+}
+
+func TestInventory(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	name := inventoryName("devspace.yaml", "backend")
+
+	refs, err := loadInventory(client, "test", name)
+	assert.NilError(t, err)
+	assert.Assert(t, refs == nil, "expected no inventory")
+
+	saved := []ObjectRef{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "config"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "test", Name: "backend"},
+	}
+	err = saveInventory(client, "test", name, "backend", saved)
+	assert.NilError(t, err)
+
+	refs, err = loadInventory(client, "test", name)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refs, saved)
+
+	// update the existing inventory
+	err = saveInventory(client, "test", name, "backend", saved[1:])
+	assert.NilError(t, err)
+
+	refs, err = loadInventory(client, "test", name)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refs, saved[1:])
+
+	err = deleteInventory(client, "test", name)
+	assert.NilError(t, err)
+	err = deleteInventory(client, "test", name)
+	assert.NilError(t, err)
+
+	refs, err = loadInventory(client, "test", name)
+	assert.NilError(t, err)
+	assert.Assert(t, refs == nil, "expected no inventory after delete")
+}
+
+type pruneObjectsTestCase struct {
+	name string
+
+	previous []ObjectRef
+	current  []ObjectRef
+	notFound map[string]bool
+	fail     map[string]bool
+
+	expectedDeleted []string
+	expectedPruned  int
+	expectedErr     string
+}
+
+func TestPruneObjects(t *testing.T) {
+	config := ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "config"}
+	service := ObjectRef{APIVersion: "v1", Kind: "Service", Namespace: "test", Name: "backend"}
+	deployment := ObjectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "test", Name: "backend"}
+	oldDeployment := ObjectRef{APIVersion: "apps/v1beta1", Kind: "Deployment", Namespace: "test", Name: "backend"}
+
+	testCases := []pruneObjectsTestCase{
+		{
+			name:            "nothing removed",
+			previous:        []ObjectRef{config, deployment},
+			current:         []ObjectRef{config, deployment},
+			expectedDeleted: []string{},
+		},
+		{
+			name:            "removed objects are deleted in reverse order",
+			previous:        []ObjectRef{config, service, deployment},
+			current:         []ObjectRef{deployment},
+			expectedDeleted: []string{"service/backend", "configmap/config"},
+			expectedPruned:  2,
+		},
+		{
+			name:            "changed api version is not pruned",
+			previous:        []ObjectRef{oldDeployment},
+			current:         []ObjectRef{deployment},
+			expectedDeleted: []string{},
+		},
+		{
+			name:            "already deleted objects are not reported",
+			previous:        []ObjectRef{config, service},
+			notFound:        map[string]bool{"service/backend": true},
+			expectedDeleted: []string{"configmap/config"},
+			expectedPruned:  1,
+		},
+		{
+			name:            "delete error",
+			previous:        []ObjectRef{config, service},
+			fail:            map[string]bool{"configmap/config": true},
+			expectedDeleted: []string{"service/backend"},
+			expectedPruned:  1,
+			expectedErr:     "delete configmap/config failed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		deleter := &fakeDeleter{deleted: []string{}, notFound: testCase.notFound, fail: testCase.fail}
+		pruned, err := pruneObjects(deleter, testCase.previous, testCase.current)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.DeepEqual(t, deleter.deleted, testCase.expectedDeleted)
+		assert.Equal(t, len(pruned), testCase.expectedPruned, "Unexpected pruned objects in testCase %s", testCase.name)
+	}
+}
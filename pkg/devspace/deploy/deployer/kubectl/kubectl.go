@@ -69,6 +69,8 @@ func New(config config2.Config, dependencies []types.Dependency, kubeClient kube
 	)
 	if deployConfig.Kubectl.CmdPath != "" {
 		cmdPath = deployConfig.Kubectl.CmdPath
+	} else if isServerSideApply(deployConfig) && (!isKustomize(deployConfig) || isEmbeddedKustomize(deployConfig)) {
+		// server-side apply reads and applies the manifests without kubectl
 	} else {
 		home, err := homedir.Dir()
 		if err != nil {
@@ -91,7 +93,7 @@ func New(config config2.Config, dependencies []types.Dependency, kubeClient kube
 	manifests := []string{}
 	for _, ptrManifest := range deployConfig.Kubectl.Manifests {
 		manifest := strings.Replace(ptrManifest, "*", "", -1)
-		if deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true {
+		if isKustomize(deployConfig) {
 			manifest = strings.TrimSuffix(manifest, "kustomization.yaml")
 		}
 
@@ -137,6 +139,23 @@ func New(config config2.Config, dependencies []types.Dependency, kubeClient kube
 	}, nil
 }
 
+// isPrune returns true if objects that were removed from the manifests should be deleted, which is the default
+func isPrune(deployConfig *latest.DeploymentConfig) bool {
+	return deployConfig.Kubectl != nil && (deployConfig.Kubectl.Prune == nil || *deployConfig.Kubectl.Prune)
+}
+
+func isServerSideApply(deployConfig *latest.DeploymentConfig) bool {
+	return deployConfig.Kubectl != nil && deployConfig.Kubectl.ApplyMode == latest.KubectlApplyModeServerSide
+}
+
+func isKustomize(deployConfig *latest.DeploymentConfig) bool {
+	return deployConfig.Kubectl != nil && deployConfig.Kubectl.Kustomize != latest.KustomizeModeDisabled
+}
+
+func isEmbeddedKustomize(deployConfig *latest.DeploymentConfig) bool {
+	return deployConfig.Kubectl != nil && deployConfig.Kubectl.Kustomize == latest.KustomizeModeEmbedded
+}
+
 func isValidKubectl(command string, executer *executer) (bool, error) {
 	out, err := executer.RunCommand(command, []string{"version", "--client"})
 	if err != nil {
@@ -183,6 +202,21 @@ func (d *DeployConfig) Status() (*deployer.StatusResult, error) {
 
 // Delete deletes all matched manifests from kubernetes
 func (d *DeployConfig) Delete() error {
+	// delete exactly the objects that were applied by the last deployment, deployments
+	// without an inventory (e.g. from older versions) delete their current manifests instead
+	if d.KubeClient != nil {
+		refs, err := loadInventory(d.KubeClient.KubeClient(), d.Namespace, d.inventoryName())
+		if err != nil {
+			return err
+		} else if refs != nil {
+			return d.deleteInventoryObjects(refs)
+		}
+	}
+
+	if isServerSideApply(d.DeploymentConfig) {
+		return d.deleteServerSide()
+	}
+
 	d.Log.StartWait("Deleting manifests with kubectl")
 	defer d.Log.StopWait()
 
@@ -242,17 +276,49 @@ func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (
 	// forceDeploy = forceDeploy || deployCache.KubectlManifestsHash != manifestsHash || deployCache.DeploymentConfigHash != deploymentConfigHash
 	forceDeploy = true
 
+	if isServerSideApply(d.DeploymentConfig) {
+		wasDeployed, appliedObjects, err := d.deployServerSide(builtImages)
+		if err != nil {
+			return false, err
+		}
+
+		pruned, err := d.updateInventory(appliedObjects)
+		if err != nil {
+			return false, err
+		} else if pruned {
+			wasDeployed = true
+		}
+
+		err = d.recordRevision(appliedObjects, "Deploy")
+		if err != nil {
+			return false, err
+		}
+
+		deployCache.KubectlManifestsHash = manifestsHash
+		deployCache.DeploymentConfigHash = deploymentConfigHash
+		return wasDeployed, nil
+	}
+
 	d.Log.StartWait("Applying manifests with kubectl")
 	defer d.Log.StopWait()
 
 	wasDeployed := false
 
+	appliedObjects := []*unstructured.Unstructured{}
 	for _, manifest := range d.Manifests {
-		shouldRedeploy, replacedManifest, err := d.getReplacedManifest(manifest, builtImages)
+		shouldRedeploy, objects, err := d.getReplacedObjects(manifest, builtImages)
 		if err != nil {
 			return false, errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
 		}
 
+		replacedManifest, err := objectsToManifest(objects)
+		if err != nil {
+			return false, err
+		}
+
+		// skipped manifests are still part of the deployment and should not be pruned
+		appliedObjects = append(appliedObjects, objects...)
+
 		if shouldRedeploy || forceDeploy {
 			stringReader := strings.NewReader(replacedManifest)
 			args := d.getCmdArgs("apply", "--force")
@@ -270,47 +336,237 @@ func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (
 		}
 	}
 
+	pruned, err := d.updateInventory(appliedObjects)
+	if err != nil {
+		return false, err
+	} else if pruned {
+		wasDeployed = true
+	}
+
+	err = d.recordRevision(appliedObjects, "Deploy")
+	if err != nil {
+		return false, err
+	}
+
 	deployCache.KubectlManifestsHash = manifestsHash
 	deployCache.DeploymentConfigHash = deploymentConfigHash
 
 	return wasDeployed, nil
 }
 
+// deployServerSide applies all objects with server-side apply and returns true if any object was created or changed
+// together with all applied objects
+func (d *DeployConfig) deployServerSide(builtImages map[string]string) (bool, []*unstructured.Unstructured, error) {
+	applier, err := newServerSideApplier(d.KubeClient, d.Namespace)
+	if err != nil {
+		return false, nil, err
+	}
+
+	d.Log.StartWait("Applying manifests with server-side apply")
+	defer d.Log.StopWait()
+
+	wasDeployed := false
+	appliedObjects := []*unstructured.Unstructured{}
+	for _, manifest := range d.Manifests {
+		_, objects, err := d.getReplacedObjects(manifest, builtImages)
+		if err != nil {
+			return false, nil, errors.Errorf("%v\nPlease make sure the manifest `%s` is valid", err, manifest)
+		}
+
+		for _, obj := range objects {
+			result, err := applier.Apply(obj)
+			if err != nil {
+				return false, nil, err
+			}
+
+			appliedObjects = append(appliedObjects, obj)
+
+			if result == ApplyResultUnchanged {
+				d.Log.Infof("%s %s", objectName(obj), result)
+				continue
+			}
+
+			d.Log.Donef("%s %s", objectName(obj), result)
+			wasDeployed = true
+		}
+	}
+
+	if !wasDeployed {
+		d.Log.Infof("Skipping deployment %s because nothing has changed", d.Name)
+	}
+
+	return wasDeployed, appliedObjects, nil
+}
+
+// deleteServerSide deletes all objects of the manifests in reverse order without kubectl
+func (d *DeployConfig) deleteServerSide() error {
+	applier, err := newServerSideApplier(d.KubeClient, d.Namespace)
+	if err != nil {
+		return err
+	}
+
+	d.Log.StartWait("Deleting manifests")
+	defer d.Log.StopWait()
+
+	for i := len(d.Manifests) - 1; i >= 0; i-- {
+		_, objects, err := d.getReplacedObjects(d.Manifests[i], nil)
+		if err != nil {
+			return err
+		}
+
+		for j := len(objects) - 1; j >= 0; j-- {
+			deleted, err := applier.Delete(objects[j])
+			if err != nil {
+				return err
+			} else if deleted {
+				d.Log.Donef("%s deleted", objectName(objects[j]))
+			}
+		}
+	}
+
+	delete(d.config.Generated().GetActive().Deployments, d.DeploymentConfig.Name)
+	return nil
+}
+
 func (d *DeployConfig) getReplacedManifest(manifest string, builtImages map[string]string) (bool, string, error) {
-	objects, err := d.buildManifests(manifest)
+	shouldRedeploy, objects, err := d.getReplacedObjects(manifest, builtImages)
 	if err != nil {
 		return false, "", err
 	}
 
-	// Split output into the yamls
-	var (
-		replaceManifests = []string{}
-		shouldRedeploy   = false
-	)
+	replacedManifest, err := objectsToManifest(objects)
+	if err != nil {
+		return false, "", err
+	}
+
+	return shouldRedeploy, replacedManifest, nil
+}
 
+func objectsToManifest(objects []*unstructured.Unstructured) (string, error) {
+	replaceManifests := []string{}
 	for _, resource := range objects {
-		if resource.Object == nil {
+		replacedManifest, err := yaml.Marshal(resource)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal yaml")
+		}
+
+		replaceManifests = append(replaceManifests, string(replacedManifest))
+	}
+
+	return strings.Join(replaceManifests, "\n---\n"), nil
+}
+
+// updateInventory records the applied objects of the deployment and prunes objects that were applied
+// by the previous deployment, but are not part of the manifests anymore. Returns true if an object was pruned.
+func (d *DeployConfig) updateInventory(objects []*unstructured.Unstructured) (bool, error) {
+	if d.KubeClient == nil {
+		return false, nil
+	}
+
+	refs := []ObjectRef{}
+	seen := map[string]bool{}
+	for _, obj := range objects {
+		ref := newObjectRef(obj)
+		if seen[ref.Key()] {
 			continue
 		}
 
+		seen[ref.Key()] = true
+		refs = append(refs, ref)
+	}
+
+	previous, err := loadInventory(d.KubeClient.KubeClient(), d.Namespace, d.inventoryName())
+	if err != nil {
+		return false, err
+	}
+
+	pruned := []ObjectRef{}
+	if previous != nil && isPrune(d.DeploymentConfig) {
+		applier, err := newServerSideApplier(d.KubeClient, d.Namespace)
+		if err != nil {
+			return false, err
+		}
+
+		// default the namespaces of both inventories, otherwise an object without namespace would be
+		// pruned if the previous deployment recorded it with the namespace
+		refs, err = resolveObjectRefs(applier, refs, false)
+		if err != nil {
+			return false, err
+		}
+		previous, err = resolveObjectRefs(applier, previous, true)
+		if err != nil {
+			return false, err
+		}
+
+		pruned, err = pruneObjects(applier, previous, refs)
+		for _, ref := range pruned {
+			d.Log.Donef("%s pruned", ref.String())
+		}
+		if err != nil {
+			return false, errors.Wrap(err, "prune objects")
+		}
+	}
+
+	err = saveInventory(d.KubeClient.KubeClient(), d.Namespace, d.inventoryName(), d.Name, refs)
+	if err != nil {
+		return false, err
+	}
+
+	return len(pruned) > 0, nil
+}
+
+// deleteInventoryObjects deletes all objects of the inventory in reverse order and the inventory itself
+func (d *DeployConfig) deleteInventoryObjects(refs []ObjectRef) error {
+	applier, err := newServerSideApplier(d.KubeClient, d.Namespace)
+	if err != nil {
+		return err
+	}
+
+	d.Log.StartWait("Deleting deployed objects")
+	defer d.Log.StopWait()
+
+	deleted, err := pruneObjects(applier, refs, nil)
+	for _, ref := range deleted {
+		d.Log.Donef("%s deleted", ref.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	err = deleteInventory(d.KubeClient.KubeClient(), d.Namespace, d.inventoryName())
+	if err != nil {
+		return err
+	}
+
+	delete(d.config.Generated().GetActive().Deployments, d.DeploymentConfig.Name)
+	return nil
+}
+
+// getReplacedObjects builds the manifest and replaces the image names of the resulting objects
+func (d *DeployConfig) getReplacedObjects(manifest string, builtImages map[string]string) (bool, []*unstructured.Unstructured, error) {
+	objects, err := d.buildManifests(manifest)
+	if err != nil {
+		return false, nil, err
+	}
+
+	objects, err = flattenObjects(objects)
+	if err != nil {
+		return false, nil, err
+	}
+
+	shouldRedeploy := false
+	for _, resource := range objects {
 		if d.DeploymentConfig.Kubectl.ReplaceImageTags == nil || *d.DeploymentConfig.Kubectl.ReplaceImageTags == true {
 			redeploy, err := util.ReplaceImageNamesStringMap(resource.Object, d.config, d.dependencies, builtImages, map[string]bool{"image": true})
 			if err != nil {
-				return false, "", err
+				return false, nil, err
 			} else if redeploy {
 				shouldRedeploy = true
 			}
 		}
-
-		replacedManifest, err := yaml.Marshal(resource)
-		if err != nil {
-			return false, "", errors.Wrap(err, "marshal yaml")
-		}
-
-		replaceManifests = append(replaceManifests, string(replacedManifest))
 	}
 
-	return shouldRedeploy, strings.Join(replaceManifests, "\n---\n"), nil
+	return shouldRedeploy, objects, nil
 }
 
 func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []string {
@@ -333,10 +589,17 @@ func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []str
 
 func (d *DeployConfig) buildManifests(manifest string) ([]*unstructured.Unstructured, error) {
 	// Check if we should use kustomize or kubectl
-	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true && d.isKustomizeInstalled("kustomize") {
+	if isEmbeddedKustomize(d.DeploymentConfig) {
+		return NewEmbeddedKustomizeBuilder(d.DeploymentConfig, d.Log).Build(manifest, d.commandExecuter.RunCommand)
+	} else if isKustomize(d.DeploymentConfig) && d.isKustomizeInstalled("kustomize") {
 		return NewKustomizeBuilder("kustomize", d.DeploymentConfig, d.Log).Build(manifest, d.commandExecuter.RunCommand)
 	}
 
+	// Read the manifests directly without kubectl
+	if isServerSideApply(d.DeploymentConfig) && !isKustomize(d.DeploymentConfig) {
+		return NewManifestReader().Build(manifest, d.commandExecuter.RunCommand)
+	}
+
 	// Build with kubectl
 	return NewKubectlBuilder(d.CmdPath, d.DeploymentConfig, d.Context, d.Namespace, d.IsInCluster).Build(manifest, d.commandExecuter.RunCommand)
 }
@@ -16,7 +16,6 @@ import (
 	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
 	"github.com/loft-sh/devspace/pkg/util/command"
 	log "github.com/loft-sh/devspace/pkg/util/log/testing"
-	"github.com/loft-sh/devspace/pkg/util/ptr"
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v2"
 	"gotest.tools/assert"
@@ -42,7 +41,7 @@ func TestNew(t *testing.T) {
 				Kubectl: &latest.KubectlConfig{
 					CmdPath:   "someCmdPath",
 					Manifests: []string{"*someManifestkustomization.yaml"},
-					Kustomize: ptr.Bool(true),
+					Kustomize: latest.KustomizeModeBinary,
 				},
 			},
 			expectedDeployer: &DeployConfig{
@@ -55,7 +54,7 @@ func TestNew(t *testing.T) {
 					Kubectl: &latest.KubectlConfig{
 						CmdPath:   "someCmdPath",
 						Manifests: []string{"*someManifestkustomization.yaml"},
-						Kustomize: ptr.Bool(true),
+						Kustomize: latest.KustomizeModeBinary,
 					},
 				},
 			},
@@ -158,7 +157,7 @@ type renderTestCase struct {
 
 	output      string
 	manifests   []string
-	kustomize   bool
+	kustomize   latest.KustomizeMode
 	cache       *generated.CacheConfig
 	builtImages map[string]string
 
@@ -184,7 +183,7 @@ func TestRender(t *testing.T) {
 			Manifests: testCase.manifests,
 			DeploymentConfig: &latest.DeploymentConfig{
 				Kubectl: &latest.KubectlConfig{
-					Kustomize: &testCase.kustomize,
+					Kustomize: testCase.kustomize,
 				},
 			},
 			commandExecuter: &fakeExecuter{
@@ -266,7 +265,7 @@ type deleteTestCase struct {
 	output    string
 	cmdPath   string
 	manifests []string
-	kustomize bool
+	kustomize latest.KustomizeMode
 	cache     *generated.CacheConfig
 
 	expectedDeployments map[string]*generated.DeploymentCache
@@ -299,7 +298,7 @@ func TestDelete(t *testing.T) {
 			DeploymentConfig: &latest.DeploymentConfig{
 				Name: "someDeploy",
 				Kubectl: &latest.KubectlConfig{
-					Kustomize: &testCase.kustomize,
+					Kustomize: testCase.kustomize,
 				},
 			},
 			commandExecuter: &fakeExecuter{
@@ -341,7 +340,7 @@ type deployTestCase struct {
 	context      string
 	namespace    string
 	manifests    []string
-	kustomize    bool
+	kustomize    latest.KustomizeMode
 	kubectlFlags []string
 	cache        *generated.CacheConfig
 	forceDeploy  bool
@@ -382,7 +381,7 @@ func TestDeploy(t *testing.T) {
 			Manifests: testCase.manifests,
 			DeploymentConfig: &latest.DeploymentConfig{
 				Kubectl: &latest.KubectlConfig{
-					Kustomize: &testCase.kustomize,
+					Kustomize: testCase.kustomize,
 					ApplyArgs: testCase.kubectlFlags,
 				},
 			},
@@ -419,7 +418,7 @@ type getReplacedManifestTestCase struct {
 
 	cmdOutput    interface{}
 	manifest     string
-	kustomize    bool
+	kustomize    latest.KustomizeMode
 	cache        *generated.CacheConfig
 	imageConfigs map[string]*latest.ImageConfig
 	builtImages  map[string]string
@@ -461,6 +460,29 @@ func TestGetReplacedManifest(t *testing.T) {
 			expectedRedeploy: true,
 			expectedManifest: "apiVersion: v1\nimage: myimage:mytag\nkind: Pod\n",
 		},
+		getReplacedManifestTestCase{
+			name:      "embedded kustomize",
+			manifest:  "testdata/kustomize/base",
+			kustomize: latest.KustomizeModeEmbedded,
+			cache: &generated.CacheConfig{
+				Images: map[string]*generated.ImageCache{
+					"myimage": &generated.ImageCache{
+						ImageName: "myimage",
+						Tag:       "mytag",
+					},
+				},
+			},
+			imageConfigs: map[string]*latest.ImageConfig{
+				"myimage": &latest.ImageConfig{
+					Image: "myimage",
+				},
+			},
+			builtImages: map[string]string{
+				"myimage": "",
+			},
+			expectedRedeploy: true,
+			expectedManifest: "apiVersion: v1\nkind: Service\nmetadata:\n  name: app\nspec:\n  ports:\n  - port: 80\n  selector:\n    app: app\n\n---\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 1\n  selector:\n    matchLabels:\n      app: app\n  template:\n    metadata:\n      labels:\n        app: app\n    spec:\n      containers:\n      - image: myimage:mytag\n        name: app\n",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -469,7 +491,7 @@ func TestGetReplacedManifest(t *testing.T) {
 		deployer := &DeployConfig{
 			DeploymentConfig: &latest.DeploymentConfig{
 				Kubectl: &latest.KubectlConfig{
-					Kustomize: &testCase.kustomize,
+					Kustomize: testCase.kustomize,
 				},
 			},
 			commandExecuter: &fakeExecuter{
@@ -478,6 +500,7 @@ func TestGetReplacedManifest(t *testing.T) {
 			config: config.NewConfig(nil, &latest.Config{
 				Images: testCase.imageConfigs,
 			}, cache, nil, constants.DefaultConfigPath),
+			Log: &log.FakeLogger{},
 		}
 
 		shouldRedeploy, replacedManifest, err := deployer.getReplacedManifest(testCase.manifest, testCase.builtImages)
@@ -492,3 +515,10 @@ func TestGetReplacedManifest(t *testing.T) {
 		assert.Equal(t, replacedManifest, testCase.expectedManifest, "Unexpected replaced manifest in testCase %s", testCase.name)
 	}
 }
+
+func TestIsPrune(t *testing.T) {
+	disabled := false
+	assert.Equal(t, isPrune(&latest.DeploymentConfig{Kubectl: &latest.KubectlConfig{}}), true)
+	assert.Equal(t, isPrune(&latest.DeploymentConfig{Kubectl: &latest.KubectlConfig{Prune: &disabled}}), false)
+	assert.Equal(t, isPrune(&latest.DeploymentConfig{}), false)
+}
@@ -0,0 +1,29 @@
+package deployer
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Revision is a deployed revision of a deployment
+type Revision struct {
+	Revision    int
+	Updated     string
+	Status      string
+	Description string
+}
+
+// PreviousRevision returns the revision that was deployed before the latest revision
+func PreviousRevision(revisions []*Revision) (int, error) {
+	if len(revisions) < 2 {
+		return 0, errors.New("there is no previous revision to roll back to")
+	}
+
+	sorted := append([]*Revision{}, revisions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Revision < sorted[j].Revision
+	})
+
+	return sorted[len(sorted)-2].Revision, nil
+}
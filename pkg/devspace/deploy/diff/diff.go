@@ -0,0 +1,231 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Action describes what would happen to an object during deployment
+type Action string
+
+// List of actions
+const (
+	ActionCreated   Action = "created"
+	ActionDeleted   Action = "deleted"
+	ActionChanged   Action = "changed"
+	ActionUnchanged Action = "unchanged"
+)
+
+// ObjectDiff is the difference of a single object between the deployed and the rendered state
+type ObjectDiff struct {
+	// Name is the name of the object in the form kind.group/namespace/name
+	Name   string
+	Action Action
+
+	// Diff is the unified diff between the deployed and the rendered object
+	Diff string
+}
+
+// HasChanges returns true if any of the diffs is not unchanged
+func HasChanges(diffs []*ObjectDiff) bool {
+	for _, d := range diffs {
+		if d.Action != ActionUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+var separator = regexp.MustCompile("(?m)^---.*$")
+
+// ParseObjects parses a multi document yaml manifest into objects. Lists are replaced with their items
+func ParseObjects(manifest string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	for _, part := range separator.Split(manifest, -1) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		objMap := map[string]interface{}{}
+		err := yaml.Unmarshal([]byte(part), &objMap)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal manifest")
+		} else if len(objMap) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		err = yaml.Unmarshal([]byte(part), obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal manifest")
+		}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// ObjectName returns a unique name for the object in the form kind.group/namespace/name
+func ObjectName(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	kind := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		kind += "." + gvk.Group
+	}
+	if obj.GetNamespace() != "" {
+		return kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	}
+
+	return kind + "/" + obj.GetName()
+}
+
+// Normalize removes fields that are set by the api server and would only clutter the diff
+func Normalize(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj == nil {
+		return nil
+	}
+
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "uid")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "selfLink")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", "deployment.kubernetes.io/revision")
+	unstructured.RemoveNestedField(obj.Object, "status")
+	if annotations, found, _ := unstructured.NestedMap(obj.Object, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	}
+
+	return obj
+}
+
+// Objects compares the deployed objects with the rendered objects. Objects are matched by their name,
+// objects that are only deployed are marked as deleted, objects that are only rendered as created.
+func Objects(deployed, rendered []*unstructured.Unstructured) ([]*ObjectDiff, error) {
+	deployedMap := map[string]*unstructured.Unstructured{}
+	for _, obj := range deployed {
+		deployedMap[ObjectName(obj)] = obj
+	}
+
+	diffs := []*ObjectDiff{}
+	seen := map[string]bool{}
+	for _, obj := range rendered {
+		name := ObjectName(obj)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		objDiff, err := objectDiff(name, deployedMap[name], obj)
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, objDiff)
+	}
+
+	for _, obj := range deployed {
+		name := ObjectName(obj)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		objDiff, err := objectDiff(name, obj, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, objDiff)
+	}
+
+	return diffs, nil
+}
+
+func objectDiff(name string, deployed, rendered *unstructured.Unstructured) (*ObjectDiff, error) {
+	from, err := toYAML(deployed)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toYAML(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	action := ActionChanged
+	if deployed == nil {
+		action = ActionCreated
+	} else if rendered == nil {
+		action = ActionDeleted
+	} else if from == to {
+		action = ActionUnchanged
+	}
+
+	return &ObjectDiff{
+		Name:   name,
+		Action: action,
+		Diff:   Unified("deployed/"+name, "rendered/"+name, from, to, DefaultContext),
+	}, nil
+}
+
+func toYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal object")
+	}
+
+	return string(out), nil
+}
+
+// Print writes the diffs of all changed objects colored to the writer
+func Print(out io.Writer, deployment string, diffs []*ObjectDiff) {
+	for _, d := range diffs {
+		if d.Action == ActionUnchanged {
+			continue
+		}
+
+		fmt.Fprintln(out, ansi.Color(fmt.Sprintf("%s: %s (%s)", deployment, d.Name, d.Action), "white+b"))
+		for i, line := range splitLines(d.Diff) {
+			switch {
+			case i < 2:
+				fmt.Fprintln(out, ansi.Color(line, "white+b"))
+			case strings.HasPrefix(line, "@@"):
+				fmt.Fprintln(out, ansi.Color(line, "cyan"))
+			case strings.HasPrefix(line, "-"):
+				fmt.Fprintln(out, ansi.Color(line, "red"))
+			case strings.HasPrefix(line, "+"):
+				fmt.Fprintln(out, ansi.Color(line, "green"))
+			default:
+				fmt.Fprintln(out, line)
+			}
+		}
+		fmt.Fprintln(out)
+	}
+}
@@ -0,0 +1,122 @@
+package diff
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type unifiedTestCase struct {
+	name string
+
+	from string
+	to   string
+
+	expected string
+}
+
+func TestUnified(t *testing.T) {
+	testCases := []unifiedTestCase{
+		{
+			name:     "equal",
+			from:     "a\nb\n",
+			to:       "a\nb\n",
+			expected: "",
+		},
+		{
+			name:     "created",
+			from:     "",
+			to:       "a\nb\n",
+			expected: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "deleted",
+			from:     "a\nb\n",
+			to:       "",
+			expected: "--- from\n+++ to\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name:     "changed line with context",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			to:       "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected: "--- from\n+++ to\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:     "separate hunks",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:       "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			expected: "--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			name:     "nearby changes are merged",
+			from:     "1\n2\n3\n4\n5\n",
+			to:       "one\n2\n3\n4\nfive\n",
+			expected: "--- from\n+++ to\n@@ -1,5 +1,5 @@\n-1\n+one\n 2\n 3\n 4\n-5\n+five\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, Unified("from", "to", testCase.from, testCase.to, DefaultContext), testCase.expected, "Unexpected diff in testCase %s", testCase.name)
+	}
+}
+
+func TestObjects(t *testing.T) {
+	deployed, err := ParseObjects(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+data:
+  key: old
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: deleted
+`)
+	assert.NilError(t, err)
+
+	rendered, err := ParseObjects(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: changed
+  data:
+    key: new
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: unchanged
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: created
+  namespace: test
+`)
+	assert.NilError(t, err)
+
+	diffs, err := Objects(deployed, rendered)
+	assert.NilError(t, err)
+	assert.Equal(t, len(diffs), 4)
+
+	actions := map[string]Action{}
+	for _, d := range diffs {
+		actions[d.Name] = d.Action
+	}
+	assert.DeepEqual(t, actions, map[string]Action{
+		"configmap/changed":            ActionChanged,
+		"configmap/unchanged":          ActionUnchanged,
+		"deployment.apps/test/created": ActionCreated,
+		"service/deleted":              ActionDeleted,
+	})
+	assert.Equal(t, diffs[0].Diff, "--- deployed/configmap/changed\n+++ rendered/configmap/changed\n@@ -1,6 +1,6 @@\n apiVersion: v1\n data:\n-  key: old\n+  key: new\n kind: ConfigMap\n metadata:\n   name: changed\n")
+	assert.Equal(t, HasChanges(diffs), true)
+	assert.Equal(t, HasChanges(diffs[1:2]), false)
+}
@@ -0,0 +1,165 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around a change
+const DefaultContext = 3
+
+type operation struct {
+	kind byte
+	line string
+}
+
+// Unified returns a unified diff between from and to or an empty string if both are equal
+func Unified(fromName, toName, from, to string, context int) string {
+	if from == to {
+		return ""
+	}
+
+	ops := lineDiff(splitLines(from), splitLines(to))
+	hunks := []string{}
+
+	// collect the ranges of changes including the surrounding context
+	i := 0
+	for i < len(ops) {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+
+		// extend the hunk as long as the next change is within reach
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j
+			} else if j-end > 2*context {
+				break
+			}
+		}
+		end += context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		hunks = append(hunks, hunk(ops, start, end))
+		i = end
+	}
+
+	return fmt.Sprintf("--- %s\n+++ %s\n", fromName, toName) + strings.Join(hunks, "")
+}
+
+// hunk formats the operations between start and end as unified diff hunk
+func hunk(ops []operation, start, end int) string {
+	fromLine, toLine := 1, 1
+	for _, op := range ops[:start] {
+		if op.kind != '+' {
+			fromLine++
+		}
+		if op.kind != '-' {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+	body := strings.Builder{}
+	for _, op := range ops[start:end] {
+		if op.kind != '+' {
+			fromCount++
+		}
+		if op.kind != '-' {
+			toCount++
+		}
+
+		body.WriteByte(op.kind)
+		body.WriteString(op.line)
+		body.WriteByte('\n')
+	}
+
+	// empty ranges start at the line before
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount) + body.String()
+}
+
+// lineDiff computes the edit script between a and b based on their longest common subsequence
+func lineDiff(a, b []string) []operation {
+	// strip common prefix and suffix to keep the table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := []operation{}
+	for _, line := range a[:prefix] {
+		ops = append(ops, operation{kind: ' ', line: line})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		if midA[i] == midB[j] {
+			ops = append(ops, operation{kind: ' ', line: midA[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			ops = append(ops, operation{kind: '-', line: midA[i]})
+			i++
+		} else {
+			ops = append(ops, operation{kind: '+', line: midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		ops = append(ops, operation{kind: '-', line: midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		ops = append(ops, operation{kind: '+', line: midB[j]})
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, operation{kind: ' ', line: line})
+	}
+
+	return ops
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
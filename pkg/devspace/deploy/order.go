@@ -0,0 +1,75 @@
+package deploy
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// SortDeployments sorts the deployments so that every deployment comes after the deployments it depends on.
+// Otherwise the order of the config is kept. Dependencies on deployments that are not part of the given
+// deployments are ignored.
+func SortDeployments(deployments []*latest.DeploymentConfig) ([]*latest.DeploymentConfig, error) {
+	sorted := []*latest.DeploymentConfig{}
+	done := map[string]bool{}
+	pending := append([]*latest.DeploymentConfig{}, deployments...)
+	for len(pending) > 0 {
+		// always take the first deployment that can be deployed to stay as close as possible to the config order
+		next := -1
+		for i, deployConfig := range pending {
+			if dependenciesDone(deployConfig, done, deployments) {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			names := []string{}
+			for _, deployConfig := range pending {
+				names = append(names, deployConfig.Name)
+			}
+
+			return nil, errors.Errorf("cannot determine deployment order, because of cyclic dependsOn in deployments %v", names)
+		}
+
+		sorted = append(sorted, pending[next])
+		done[pending[next].Name] = true
+		pending = append(pending[:next], pending[next+1:]...)
+	}
+
+	return sorted, nil
+}
+
+// dependenciesDone checks if all dependencies of the deployment that are part of the given deployments are done
+func dependenciesDone(deployConfig *latest.DeploymentConfig, done map[string]bool, deployments []*latest.DeploymentConfig) bool {
+	for _, dependsOn := range deployConfig.DependsOn {
+		if done[dependsOn] {
+			continue
+		}
+
+		for _, other := range deployments {
+			if other.Name == dependsOn {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// dependedOn returns the names of the deployments that other deployments of the given deployments depend on
+func dependedOn(deployments []*latest.DeploymentConfig) map[string]bool {
+	names := map[string]bool{}
+	for _, deployConfig := range deployments {
+		names[deployConfig.Name] = true
+	}
+
+	dependedOn := map[string]bool{}
+	for _, deployConfig := range deployments {
+		for _, dependsOn := range deployConfig.DependsOn {
+			if names[dependsOn] {
+				dependedOn[dependsOn] = true
+			}
+		}
+	}
+
+	return dependedOn
+}
@@ -0,0 +1,92 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type sortDeploymentsTestCase struct {
+	name string
+
+	deployments []*latest.DeploymentConfig
+
+	expectedOrder []string
+	expectedErr   string
+}
+
+func TestSortDeployments(t *testing.T) {
+	testCases := []sortDeploymentsTestCase{
+		{
+			name: "keep config order without dependencies",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "c"},
+			},
+			expectedOrder: []string{"a", "b", "c"},
+		},
+		{
+			name: "dependencies first",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "backend", DependsOn: []string{"database"}},
+				{Name: "frontend", DependsOn: []string{"backend"}},
+				{Name: "other"},
+				{Name: "database"},
+			},
+			expectedOrder: []string{"other", "database", "backend", "frontend"},
+		},
+		{
+			name: "ignore dependencies that are not deployed",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "backend", DependsOn: []string{"database"}},
+				{Name: "frontend", DependsOn: []string{"backend"}},
+			},
+			expectedOrder: []string{"backend", "frontend"},
+		},
+		{
+			name: "cycle",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c"},
+			},
+			expectedErr: "cannot determine deployment order, because of cyclic dependsOn in deployments [a b]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		sorted, err := SortDeployments(testCase.deployments)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		order := []string{}
+		for _, deployConfig := range sorted {
+			order = append(order, deployConfig.Name)
+		}
+		assert.DeepEqual(t, order, testCase.expectedOrder)
+	}
+}
+
+func TestWaitForReadyConfig(t *testing.T) {
+	deployments := []*latest.DeploymentConfig{
+		{Name: "database"},
+		{Name: "cache", WaitForReady: &latest.WaitForReadyConfig{Disabled: true}},
+		{Name: "queue", WaitForReady: &latest.WaitForReadyConfig{Timeout: 60}},
+		{Name: "backend", DependsOn: []string{"database", "cache", "external"}},
+		{Name: "frontend"},
+	}
+	scheduler := &deployScheduler{dependedOn: dependedOn(deployments)}
+	assert.DeepEqual(t, scheduler.dependedOn, map[string]bool{"database": true, "cache": true})
+
+	assert.DeepEqual(t, scheduler.waitForReadyConfig(deployments[0]), &latest.WaitForReadyConfig{})
+	assert.Assert(t, scheduler.waitForReadyConfig(deployments[1]) == nil, "expected no wait for disabled waitForReady")
+	assert.DeepEqual(t, scheduler.waitForReadyConfig(deployments[2]), &latest.WaitForReadyConfig{Timeout: 60})
+	assert.Assert(t, scheduler.waitForReadyConfig(deployments[3]) == nil, "expected no wait without dependent deployments")
+	assert.Assert(t, scheduler.waitForReadyConfig(deployments[4]) == nil, "expected no wait without dependent deployments")
+}
@@ -0,0 +1,303 @@
+package deploy
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GeneratedHeader is the first line of every file written by the ManifestWriter. Only files
+// with this header are removed or overwritten
+const GeneratedHeader = "# Generated by devspace render. DO NOT EDIT."
+
+// DependenciesDir is the folder within the output dir the manifests of dependencies are written to
+const DependenciesDir = "dependencies"
+
+const kustomizationFile = "kustomization.yaml"
+
+// ManifestWriter writes the rendered manifests of deployments into an output directory. Every deployment
+// is written to <dir>/<deployment>.yaml or, if split, every object to <dir>/<deployment>/<kind>-<name>.yaml
+type ManifestWriter struct {
+	// Dir is the absolute output directory
+	Dir string
+
+	// Split writes every object into its own file
+	Split bool
+
+	// Kustomization generates a kustomization.yaml for the written files
+	Kustomization bool
+
+	prefix  string
+	written map[string]bool
+	owned   map[string]bool
+}
+
+// NewManifestWriter creates a new manifest writer for the given output directory
+func NewManifestWriter(dir string, split, kustomization bool) (*ManifestWriter, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManifestWriter{
+		Dir:           absDir,
+		Split:         split,
+		Kustomization: kustomization,
+		written:       map[string]bool{},
+		owned:         map[string]bool{},
+	}, nil
+}
+
+// Dependency returns a writer that writes the deployments of the dependency into <dir>/dependencies/<dependency>
+func (w *ManifestWriter) Dependency(name string) *ManifestWriter {
+	return &ManifestWriter{
+		Dir:           w.Dir,
+		Split:         w.Split,
+		Kustomization: w.Kustomization,
+		prefix:        filepath.Join(w.prefix, DependenciesDir, fileName(name)),
+		written:       w.written,
+		owned:         w.owned,
+	}
+}
+
+// Write writes the rendered manifests of the deployment
+func (w *ManifestWriter) Write(deployment string, manifests string) error {
+	objects, err := diff.ParseObjects(manifests)
+	if err != nil {
+		return err
+	}
+
+	base := filepath.Join(w.Dir, w.prefix, fileName(deployment))
+	w.owned[base] = true
+	w.owned[base+".yaml"] = true
+	if len(objects) == 0 {
+		return nil
+	}
+
+	if !w.Split {
+		docs := []string{}
+		for _, obj := range objects {
+			out, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				return err
+			}
+
+			docs = append(docs, string(out))
+		}
+
+		return w.writeFile(base+".yaml", strings.Join(docs, "---\n"))
+	}
+
+	used := map[string]bool{}
+	for _, obj := range objects {
+		out, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+
+		err = w.writeFile(filepath.Join(base, objectFileName(obj, used)), string(out))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Finish removes generated files that were not written by this writer and generates the kustomization.yaml
+// files. If all is false, only stale files of the written deployments are removed
+func (w *ManifestWriter) Finish(all bool) error {
+	err := w.removeStale(all)
+	if err != nil {
+		return err
+	}
+
+	if w.Kustomization {
+		_, err = w.writeKustomization(w.Dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *ManifestWriter) writeFile(path string, content string) error {
+	if w.written[path] {
+		return errors.Errorf("%s was already written by another deployment", path)
+	}
+
+	exists, generated, err := isGenerated(path)
+	if err != nil {
+		return err
+	} else if exists && !generated {
+		return errors.Errorf("%s already exists and was not generated by devspace render", path)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(path, []byte(GeneratedHeader+"\n"+content), 0644)
+	if err != nil {
+		return err
+	}
+
+	w.written[path] = true
+	return nil
+}
+
+// removeStale removes generated files in the output directory that were not written
+func (w *ManifestWriter) removeStale(all bool) error {
+	return filepath.Walk(w.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		} else if info.IsDir() || w.written[path] || (!all && !w.isOwned(path)) {
+			return nil
+		}
+
+		_, generated, err := isGenerated(path)
+		if err != nil || !generated {
+			return err
+		}
+
+		err = os.Remove(path)
+		if err != nil {
+			return err
+		}
+
+		// remove empty parent folders
+		for dir := filepath.Dir(path); dir != w.Dir && strings.HasPrefix(dir, w.Dir); dir = filepath.Dir(dir) {
+			files, err := ioutil.ReadDir(dir)
+			if err != nil || len(files) > 0 {
+				break
+			}
+
+			_ = os.Remove(dir)
+		}
+
+		return nil
+	})
+}
+
+func (w *ManifestWriter) isOwned(path string) bool {
+	for owned := range w.owned {
+		if path == owned || strings.HasPrefix(path, owned+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeKustomization writes a kustomization.yaml with all generated files and sub folders with a kustomization.yaml
+// into the given folder and returns true if the folder contains any resources
+func (w *ManifestWriter) writeKustomization(dir string) (bool, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	resources := []string{}
+	for _, file := range files {
+		path := filepath.Join(dir, file.Name())
+		if file.IsDir() {
+			hasResources, err := w.writeKustomization(path)
+			if err != nil {
+				return false, err
+			} else if hasResources {
+				resources = append(resources, file.Name())
+			}
+
+			continue
+		} else if file.Name() == kustomizationFile || filepath.Ext(file.Name()) != ".yaml" {
+			continue
+		}
+
+		_, generated, err := isGenerated(path)
+		if err != nil {
+			return false, err
+		} else if generated {
+			resources = append(resources, file.Name())
+		}
+	}
+
+	path := filepath.Join(dir, kustomizationFile)
+	if len(resources) == 0 {
+		_, generated, err := isGenerated(path)
+		if err == nil && generated {
+			err = os.Remove(path)
+		}
+
+		return false, err
+	}
+
+	sort.Strings(resources)
+	out, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	delete(w.written, path)
+	return true, w.writeFile(path, string(out))
+}
+
+// isGenerated returns if the file exists and if it starts with the generated header
+func isGenerated(path string) (bool, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, false, nil
+		}
+
+		return false, false, err
+	}
+	defer f.Close()
+
+	firstLine, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && firstLine == "" {
+		return true, false, nil
+	}
+
+	return true, strings.TrimSpace(firstLine) == GeneratedHeader, nil
+}
+
+// objectFileName returns a unique file name in the form <kind>-<name>.yaml for the object
+func objectFileName(obj *unstructured.Unstructured, used map[string]bool) string {
+	name := strings.ToLower(obj.GetKind()) + "-" + obj.GetName()
+	if used[name] && obj.GetNamespace() != "" {
+		name = strings.ToLower(obj.GetKind()) + "-" + obj.GetNamespace() + "-" + obj.GetName()
+	}
+	for i := 2; used[name]; i++ {
+		name = strings.ToLower(obj.GetKind()) + "-" + obj.GetName() + "-" + strconv.Itoa(i)
+	}
+
+	used[name] = true
+	return fileName(name) + ".yaml"
+}
+
+func fileName(name string) string {
+	return strings.NewReplacer("/", "-", "\\", "-", ":", "-").Replace(name)
+}
@@ -0,0 +1,123 @@
+package deploy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const testManifests = `apiVersion: v1
+kind: Service
+metadata:
+  name: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: app:built
+`
+
+func listFiles(t *testing.T, dir string) []string {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	})
+	assert.NilError(t, err)
+	sort.Strings(files)
+	return files
+}
+
+func TestManifestWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a file that was not generated must never be touched
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("docs"), 0644))
+
+	writer, err := NewManifestWriter(dir, false, false)
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Write("backend", testManifests))
+	assert.NilError(t, writer.Dependency("dep").Write("frontend", testManifests))
+	assert.NilError(t, writer.Finish(true))
+	assert.DeepEqual(t, listFiles(t, dir), []string{"README.md", "backend.yaml", "dependencies/dep/frontend.yaml"})
+
+	out, err := ioutil.ReadFile(filepath.Join(dir, "backend.yaml"))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(string(out), GeneratedHeader+"\n"), string(out))
+	assert.Assert(t, strings.Contains(string(out), "image: app:built"), string(out))
+	assert.Equal(t, strings.Count(string(out), "---\n"), 1)
+
+	// switching to split removes the old files of the rendered deployment only
+	writer, err = NewManifestWriter(dir, true, true)
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Write("backend", testManifests))
+	assert.NilError(t, writer.Finish(false))
+	assert.DeepEqual(t, listFiles(t, dir), []string{
+		"README.md",
+		"backend/deployment-app.yaml",
+		"backend/kustomization.yaml",
+		"backend/service-app.yaml",
+		"dependencies/dep/frontend.yaml",
+		"dependencies/dep/kustomization.yaml",
+		"dependencies/kustomization.yaml",
+		"kustomization.yaml",
+	})
+
+	out, err = ioutil.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), GeneratedHeader+`
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- backend
+- dependencies
+`)
+
+	// rendering everything removes the stale dependency
+	writer, err = NewManifestWriter(dir, true, false)
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Write("backend", testManifests))
+	assert.NilError(t, writer.Finish(true))
+	assert.DeepEqual(t, listFiles(t, dir), []string{"README.md", "backend/deployment-app.yaml", "backend/service-app.yaml"})
+}
+
+func TestManifestWriterConflicts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "backend.yaml"), []byte("kind: Service"), 0644))
+
+	writer, err := NewManifestWriter(dir, false, false)
+	assert.NilError(t, err)
+	err = writer.Write("backend", testManifests)
+	assert.ErrorContains(t, err, "was not generated by devspace render")
+
+	writer, err = NewManifestWriter(dir, true, false)
+	assert.NilError(t, err)
+	err = writer.Write("other", testManifests+"---\n"+testManifests)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, listFiles(t, filepath.Join(dir, "other")), []string{
+		"deployment-app-2.yaml",
+		"deployment-app.yaml",
+		"service-app-2.yaml",
+		"service-app.yaml",
+	})
+}
@@ -0,0 +1,162 @@
+package rollout
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultTimeout is the default amount of time to wait for the workloads of a deployment
+const DefaultTimeout = 300 * time.Second
+
+// pollInterval is the interval the workloads are checked in
+var pollInterval = 2 * time.Second
+
+// Options are the options for waiting on workloads
+type Options struct {
+	// Timeout is the maximum amount of time to wait, defaults to DefaultTimeout
+	Timeout time.Duration
+
+	// IgnorePodRestarts does not report container restarts as problems
+	IgnorePodRestarts bool
+}
+
+// Wait waits until the rollouts of all deployments, stateful sets, daemon sets and jobs within the given
+// objects are complete. Objects without a namespace are expected in the given namespace. Progress and
+// failing pods are printed to the log. If a rollout fails or the timeout is reached, an error with a report
+// about the pending workloads and their pods is returned.
+func Wait(client kubectl.Client, objects []*unstructured.Unstructured, namespace string, options Options, log log.Logger) error {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	pending := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		if !IsWorkload(obj) {
+			continue
+		}
+
+		obj = obj.DeepCopy()
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		pending = append(pending, obj)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	log.Infof("Waiting for %d workload(s) to become ready...", len(pending))
+
+	var (
+		start    = time.Now()
+		messages = map[string]string{}
+	)
+	for {
+		next := []*unstructured.Unstructured{}
+		statuses := []*Status{}
+		for _, obj := range pending {
+			status, err := GetStatus(client.KubeClient(), obj)
+			if err != nil {
+				return errors.Wrapf(err, "get status of %s", workloadName(obj.GetKind(), obj.GetName()))
+			} else if status.Ready {
+				log.Donef("%s is ready", status.Name)
+				continue
+			} else if status.Failed {
+				return errors.Errorf("%s failed: %s\n\n%s", status.Name, status.Message, report(client, []*Status{status}, options))
+			}
+
+			message := status.Message
+			failingPods, err := failingPods(client, status)
+			if err != nil {
+				return err
+			} else if len(failingPods) > 0 {
+				message += " (failing pods: " + strings.Join(failingPods, ", ") + ")"
+			}
+			if messages[status.Name] != message {
+				log.Infof("%s: %s", status.Name, message)
+				messages[status.Name] = message
+			}
+
+			next = append(next, obj)
+			statuses = append(statuses, status)
+		}
+
+		pending = next
+		if len(pending) == 0 {
+			return nil
+		} else if time.Since(start) >= timeout {
+			return errors.Errorf("timed out after %s waiting for workloads to become ready:\n\n%s", timeout.String(), report(client, statuses, options))
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+// failingPods returns the pods of the workload that are in a critical state together with the state
+func failingPods(client kubectl.Client, status *Status) ([]string, error) {
+	pods, err := listPods(client, status)
+	if err != nil {
+		return nil, err
+	}
+
+	failing := []string{}
+	for _, pod := range pods {
+		podStatus := kubectl.GetPodStatus(&pod)
+		if kubectl.CriticalStatus[strings.TrimPrefix(podStatus, "Init:")] {
+			failing = append(failing, pod.Name+" "+podStatus)
+		}
+	}
+
+	sort.Strings(failing)
+	return failing, nil
+}
+
+// report prints the status of the given workloads together with the problems of their pods
+func report(client kubectl.Client, statuses []*Status, options Options) string {
+	lines := []string{}
+	for _, status := range statuses {
+		lines = append(lines, status.Name+": "+status.Message)
+
+		pods, err := listPods(client, status)
+		if err != nil {
+			lines = append(lines, "  error listing pods: "+err.Error())
+			continue
+		}
+
+		for _, problem := range analyze.PodProblems(client, pods, options.IgnorePodRestarts) {
+			lines = append(lines, strings.TrimRight(problem, "\n"))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func listPods(client kubectl.Client, status *Status) ([]corev1.Pod, error) {
+	if status.Selector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(status.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse selector of %s", status.Name)
+	}
+
+	pods, err := client.KubeClient().CoreV1().Pods(status.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods of %s", status.Name)
+	}
+
+	return pods.Items, nil
+}
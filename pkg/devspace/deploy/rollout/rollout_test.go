@@ -0,0 +1,204 @@
+package rollout
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type statusTestCase struct {
+	name string
+
+	status *Status
+
+	expectedReady   bool
+	expectedFailed  bool
+	expectedMessage string
+}
+
+func TestStatus(t *testing.T) {
+	testCases := []statusTestCase{
+		{
+			name: "deployment generation not observed",
+			status: deploymentStatus(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+			}),
+			expectedMessage: "waiting for the spec update to be observed",
+		},
+		{
+			name: "deployment updating",
+			status: deploymentStatus(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32(3)},
+				Status:     appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1},
+			}),
+			expectedMessage: "1 of 3 replicas updated",
+		},
+		{
+			name: "deployment old replicas",
+			status: deploymentStatus(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32(2)},
+				Status:     appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2},
+			}),
+			expectedMessage: "1 old replicas pending termination",
+		},
+		{
+			name: "deployment unavailable",
+			status: deploymentStatus(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32(2)},
+				Status:     appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			}),
+			expectedMessage: "1 of 2 replicas ready",
+		},
+		{
+			name: "deployment progress deadline exceeded",
+			status: deploymentStatus(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+				}},
+			}),
+			expectedFailed:  true,
+			expectedMessage: "progress deadline exceeded",
+		},
+		{
+			name: "deployment ready",
+			status: deploymentStatus(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			}),
+			expectedReady:   true,
+			expectedMessage: "1 of 1 replicas ready",
+		},
+		{
+			name: "stateful set revision not updated",
+			status: statefulSetStatus(&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 1},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+			}),
+			expectedMessage: "0 of 1 replicas updated",
+		},
+		{
+			name: "stateful set partition",
+			status: statefulSetStatus(&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 1},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.Int32(3),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.Int32(2)},
+					},
+				},
+				Status: appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+			}),
+			expectedReady:   true,
+			expectedMessage: "3 of 3 replicas ready",
+		},
+		{
+			name: "daemon set unavailable",
+			status: daemonSetStatus(&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent"},
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 1},
+			}),
+			expectedMessage: "1 of 2 pods ready",
+		},
+		{
+			name: "job running",
+			status: jobStatus(&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrate"},
+				Spec:       batchv1.JobSpec{Completions: ptr.Int32(1)},
+				Status:     batchv1.JobStatus{Active: 1},
+			}),
+			expectedMessage: "0 of 1 completions, 1 active",
+		},
+		{
+			name: "job failed",
+			status: jobStatus(&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrate"},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"},
+				}},
+			}),
+			expectedFailed:  true,
+			expectedMessage: "job failed (reason: BackoffLimitExceeded): Job has reached the specified backoff limit",
+		},
+		{
+			name: "job completed",
+			status: jobStatus(&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrate"},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+				}},
+			}),
+			expectedReady:   true,
+			expectedMessage: "job completed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.status.Ready, testCase.expectedReady, "Unexpected ready in testCase %s", testCase.name)
+		assert.Equal(t, testCase.status.Failed, testCase.expectedFailed, "Unexpected failed in testCase %s", testCase.name)
+		assert.Equal(t, testCase.status.Message, testCase.expectedMessage, "Unexpected message in testCase %s", testCase.name)
+	}
+}
+
+func TestWait(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = 2 * time.Second }()
+
+	labels := map[string]string{"app": "backend"}
+	ready := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test"},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	pending := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "test"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1},
+	}
+	crashing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend-1", Namespace: "test", Labels: labels},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "backend", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+
+	objects := []*unstructured.Unstructured{
+		workload("apps/v1", "Deployment", "ready"),
+		workload("v1", "ConfigMap", "ignored"),
+	}
+	client := &fakekube.Client{Client: fake.NewSimpleClientset(ready, pending, crashing)}
+	err := Wait(client, objects, "test", Options{}, log.Discard)
+	assert.NilError(t, err)
+
+	objects = append(objects, workload("apps/v1", "Deployment", "backend"))
+	err = Wait(client, objects, "test", Options{Timeout: time.Millisecond}, log.Discard)
+	assert.ErrorContains(t, err, "timed out after 1ms")
+	assert.ErrorContains(t, err, "deployment/backend: 0 of 1 replicas ready")
+	assert.Assert(t, strings.Contains(err.Error(), "backend-1"), "Pod problems missing in report: %s", err.Error())
+}
+
+func workload(apiVersion, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return obj
+}
@@ -0,0 +1,264 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Status is the rollout status of a single workload
+type Status struct {
+	// Name is the kind and name of the workload, e.g. deployment/my-app
+	Name string
+
+	// Ready is true if the rollout of the workload is complete
+	Ready bool
+
+	// Failed is true if the rollout cannot complete anymore, e.g. because a job has failed
+	Failed bool
+
+	// Message describes the progress of the rollout
+	Message string
+
+	// Namespace and Selector select the pods of the workload
+	Namespace string
+	Selector  *metav1.LabelSelector
+
+	// Images are the container images of the pod template
+	Images []string
+}
+
+// IsWorkload returns true if the rollout of the object can be tracked
+func IsWorkload(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	switch gvk.Group + "/" + gvk.Kind {
+	case "apps/Deployment", "apps/StatefulSet", "apps/DaemonSet", "batch/Job":
+		return true
+	}
+
+	return false
+}
+
+// GetStatus retrieves the workload from the cluster and returns its rollout status
+func GetStatus(client kubernetes.Interface, obj *unstructured.Unstructured) (*Status, error) {
+	var (
+		name      = obj.GetName()
+		namespace = obj.GetNamespace()
+		status    *Status
+		err       error
+	)
+
+	switch obj.GetKind() {
+	case "Deployment":
+		var deployment *appsv1.Deployment
+		deployment, err = client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err == nil {
+			status = deploymentStatus(deployment)
+		}
+	case "StatefulSet":
+		var statefulSet *appsv1.StatefulSet
+		statefulSet, err = client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err == nil {
+			status = statefulSetStatus(statefulSet)
+		}
+	case "DaemonSet":
+		var daemonSet *appsv1.DaemonSet
+		daemonSet, err = client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err == nil {
+			status = daemonSetStatus(daemonSet)
+		}
+	case "Job":
+		var job *batchv1.Job
+		job, err = client.BatchV1().Jobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err == nil {
+			status = jobStatus(job)
+		}
+	default:
+		return nil, fmt.Errorf("cannot track rollout of kind %s", obj.GetKind())
+	}
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return &Status{
+				Name:      workloadName(obj.GetKind(), name),
+				Message:   "waiting for the object to be created",
+				Namespace: namespace,
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	status.Namespace = namespace
+	return status, nil
+}
+
+func deploymentStatus(deployment *appsv1.Deployment) *Status {
+	status := &Status{
+		Name:     workloadName("Deployment", deployment.Name),
+		Selector: deployment.Spec.Selector,
+		Images:   podImages(&deployment.Spec.Template.Spec),
+	}
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		status.Message = "waiting for the spec update to be observed"
+		return status
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			status.Failed = true
+			status.Message = "progress deadline exceeded"
+			return status
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.UpdatedReplicas < replicas {
+		status.Message = fmt.Sprintf("%d of %d replicas updated", deployment.Status.UpdatedReplicas, replicas)
+	} else if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		status.Message = fmt.Sprintf("%d old replicas pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	} else if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		status.Message = fmt.Sprintf("%d of %d replicas ready", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	} else {
+		status.Ready = true
+		status.Message = fmt.Sprintf("%d of %d replicas ready", deployment.Status.AvailableReplicas, replicas)
+	}
+
+	return status
+}
+
+func statefulSetStatus(statefulSet *appsv1.StatefulSet) *Status {
+	status := &Status{
+		Name:     workloadName("StatefulSet", statefulSet.Name),
+		Selector: statefulSet.Spec.Selector,
+		Images:   podImages(&statefulSet.Spec.Template.Spec),
+	}
+
+	// the rollout of on delete stateful sets cannot be tracked
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		status.Ready = true
+		return status
+	}
+	if statefulSet.Status.ObservedGeneration == 0 || statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		status.Message = "waiting for the spec update to be observed"
+		return status
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ReadyReplicas < replicas {
+		status.Message = fmt.Sprintf("%d of %d replicas ready", statefulSet.Status.ReadyReplicas, replicas)
+		return status
+	}
+
+	// with a partition only the replicas above the partition are updated
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate != nil && statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partitioned := replicas - *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition
+		if statefulSet.Status.UpdatedReplicas < partitioned {
+			status.Message = fmt.Sprintf("%d of %d replicas updated", statefulSet.Status.UpdatedReplicas, partitioned)
+			return status
+		}
+	} else if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		status.Message = fmt.Sprintf("%d of %d replicas updated", statefulSet.Status.UpdatedReplicas, replicas)
+		return status
+	}
+
+	status.Ready = true
+	status.Message = fmt.Sprintf("%d of %d replicas ready", statefulSet.Status.ReadyReplicas, replicas)
+	return status
+}
+
+func daemonSetStatus(daemonSet *appsv1.DaemonSet) *Status {
+	status := &Status{
+		Name:     workloadName("DaemonSet", daemonSet.Name),
+		Selector: daemonSet.Spec.Selector,
+		Images:   podImages(&daemonSet.Spec.Template.Spec),
+	}
+
+	// the rollout of on delete daemon sets cannot be tracked
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		status.Ready = true
+		return status
+	}
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		status.Message = "waiting for the spec update to be observed"
+		return status
+	}
+
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		status.Message = fmt.Sprintf("%d of %d pods updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	} else if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		status.Message = fmt.Sprintf("%d of %d pods ready", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	} else {
+		status.Ready = true
+		status.Message = fmt.Sprintf("%d of %d pods ready", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	}
+
+	return status
+}
+
+func jobStatus(job *batchv1.Job) *Status {
+	status := &Status{
+		Name:     workloadName("Job", job.Name),
+		Selector: job.Spec.Selector,
+		Images:   podImages(&job.Spec.Template.Spec),
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if condition.Type == batchv1.JobFailed {
+			status.Failed = true
+			status.Message = fmt.Sprintf("job failed (reason: %s)", condition.Reason)
+			if condition.Message != "" {
+				status.Message += ": " + condition.Message
+			}
+
+			return status
+		} else if condition.Type == batchv1.JobComplete {
+			status.Ready = true
+			status.Message = "job completed"
+			return status
+		}
+	}
+
+	if job.Spec.Completions != nil {
+		status.Message = fmt.Sprintf("%d of %d completions, %d active", job.Status.Succeeded, *job.Spec.Completions, job.Status.Active)
+	} else {
+		status.Message = fmt.Sprintf("%d succeeded, %d active", job.Status.Succeeded, job.Status.Active)
+	}
+
+	return status
+}
+
+func podImages(spec *corev1.PodSpec) []string {
+	images := []string{}
+	for _, container := range spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	for _, container := range spec.Containers {
+		images = append(images, container.Image)
+	}
+
+	return images
+}
+
+func workloadName(kind, name string) string {
+	return strings.ToLower(kind) + "/" + name
+}
@@ -0,0 +1,226 @@
+package deploy
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/rollout"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/scanner"
+	"github.com/pkg/errors"
+)
+
+// deployScheduler deploys the deployments of a config one after another or in parallel
+type deployScheduler struct {
+	controller *controller
+	options    *Options
+
+	helmV2Clients map[string]helmtypes.Client
+
+	// dependedOn holds the names of the deployments that other deployments depend on
+	dependedOn map[string]bool
+
+	// clientMutex makes sure deploy clients are created one at a time, because they might download binaries
+	// and share the helm v2 client cache
+	clientMutex sync.Mutex
+}
+
+// deployParallel deploys the sorted deployments in parallel. A deployment is started as soon as all
+// deployments it depends on are deployed and less than options.MaxConcurrentDeploys deployments are running
+func (s *deployScheduler) deployParallel(deployConfigs []*latest.DeploymentConfig, log logpkg.Logger) error {
+	var (
+		// the channels are buffered, so that running deployments can finish if we return early
+		errChan  = make(chan error, len(deployConfigs))
+		doneChan = make(chan string, len(deployConfigs))
+
+		done    = map[string]bool{}
+		pending = append([]*latest.DeploymentConfig{}, deployConfigs...)
+		running = 0
+		started = 0
+	)
+
+	for len(pending) > 0 || running > 0 {
+		// start all deployments that can be deployed
+		next := []*latest.DeploymentConfig{}
+		for _, deployConfig := range pending {
+			if running >= s.options.MaxConcurrentDeploys || !dependenciesDone(deployConfig, done, deployConfigs) {
+				next = append(next, deployConfig)
+				continue
+			}
+
+			running++
+			started++
+			go func(deployConfig *latest.DeploymentConfig, color string) {
+				// Create a prefixed log
+				reader, writer := io.Pipe()
+				streamLog := logpkg.NewStreamLogger(writer, log.GetLevel())
+				prefixLog := logpkg.NewPrefixLogger("["+deployConfig.Name+"] ", color, log)
+
+				// read from the reader
+				scanDone := make(chan struct{})
+				go func() {
+					defer close(scanDone)
+
+					scanner := scanner.NewScanner(reader)
+					for scanner.Scan() {
+						prefixLog.Info(scanner.Text())
+					}
+				}()
+
+				err := s.deploy(deployConfig, streamLog)
+				_ = writer.Close()
+				<-scanDone
+				if err != nil {
+					errChan <- err
+					return
+				}
+
+				doneChan <- deployConfig.Name
+			}(deployConfig, logpkg.Colors[(len(logpkg.Colors)-1)-(started%len(logpkg.Colors))])
+		}
+		pending = next
+
+		if running == 0 {
+			return errors.New("cannot deploy the remaining deployments, because their dependencies cannot be deployed")
+		}
+
+		select {
+		case err := <-errChan:
+			return err
+		case name := <-doneChan:
+			running--
+			done[name] = true
+		}
+	}
+
+	return nil
+}
+
+// deploy deploys a single deployment including its hooks
+func (s *deployScheduler) deploy(deployConfig *latest.DeploymentConfig, log logpkg.Logger) error {
+	c := s.controller
+	deployClient, method, err := s.newDeployClient(deployConfig, log)
+	if err != nil {
+		return err
+	}
+
+	// Execute before deployment deploy hook
+	err = c.hookExecuter.Execute(hook.Before, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
+	if err != nil {
+		return err
+	}
+	pluginErr := plugin.ExecutePluginHookWithContext("deploy.beforeDeploy", map[string]interface{}{
+		"DEPLOY_CONFIG": deployConfig,
+	})
+	if pluginErr != nil {
+		return pluginErr
+	}
+
+	wasDeployed, err := deployClient.Deploy(s.options.ForceDeploy, s.options.BuiltImages)
+	if waitConfig := s.waitForReadyConfig(deployConfig); err == nil && waitConfig != nil {
+		err = s.waitForReady(deployClient, deployConfig, waitConfig, log)
+	}
+	if err != nil {
+		c.hookExecuter.OnError(hook.StageDeployments, []string{hook.All, deployConfig.Name}, hook.Context{Client: c.client, Error: err}, log)
+		pluginErr := plugin.ExecutePluginHookWithContext("deploy.errorDeploy", map[string]interface{}{
+			"DEPLOY_CONFIG": deployConfig,
+			"ERROR":         err,
+		})
+		if pluginErr != nil {
+			return pluginErr
+		}
+		return errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
+	}
+
+	if wasDeployed {
+		log.Donef("Successfully deployed %s with %s", deployConfig.Name, method)
+
+		// Execute after deployment deploy hook
+		err = c.hookExecuter.Execute(hook.After, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
+		if err != nil {
+			return err
+		}
+		pluginErr := plugin.ExecutePluginHookWithContext("deploy.afterDeploy", map[string]interface{}{
+			"DEPLOY_CONFIG": deployConfig,
+		})
+		if pluginErr != nil {
+			return pluginErr
+		}
+	} else {
+		log.Infof("Skipping deployment %s", deployConfig.Name)
+	}
+
+	return nil
+}
+
+// waitForReadyConfig returns how to wait for the deployment or nil if DevSpace shouldn't wait. Deployments that
+// other deployments depend on are waited for by default, so that the dependent deployments find them ready
+func (s *deployScheduler) waitForReadyConfig(deployConfig *latest.DeploymentConfig) *latest.WaitForReadyConfig {
+	if deployConfig.WaitForReady != nil {
+		if deployConfig.WaitForReady.Disabled {
+			return nil
+		}
+
+		return deployConfig.WaitForReady
+	} else if s.dependedOn[deployConfig.Name] {
+		return &latest.WaitForReadyConfig{}
+	}
+
+	return nil
+}
+
+// waitForReady waits until the rollouts of the workloads of the deployment are complete
+func (s *deployScheduler) waitForReady(deployClient deployer.Interface, deployConfig *latest.DeploymentConfig, waitConfig *latest.WaitForReadyConfig, log logpkg.Logger) error {
+	objects, err := deployClient.DeployedObjects()
+	if err != nil {
+		return errors.Wrap(err, "get deployed objects")
+	}
+
+	namespace := s.controller.client.Namespace()
+	if deployConfig.Namespace != "" {
+		namespace = deployConfig.Namespace
+	}
+
+	return rollout.Wait(s.controller.client, objects, namespace, rollout.Options{
+		Timeout:           time.Duration(waitConfig.Timeout) * time.Second,
+		IgnorePodRestarts: waitConfig.IgnorePodRestarts,
+	}, log)
+}
+
+func (s *deployScheduler) newDeployClient(deployConfig *latest.DeploymentConfig, log logpkg.Logger) (deployer.Interface, string, error) {
+	s.clientMutex.Lock()
+	defer s.clientMutex.Unlock()
+
+	c := s.controller
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "kubectl", nil
+	} else if deployConfig.Helm != nil {
+		// Get helm client
+		helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, s.helmV2Clients, false, log)
+		if err != nil {
+			return nil, "", err
+		}
+
+		deployClient, err := helm.New(c.config, c.dependencies, helmClient, c.client, deployConfig, log)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "helm", nil
+	}
+
+	return nil, "", errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
+}
@@ -0,0 +1,223 @@
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/rollout"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/loft-sh/devspace/pkg/util/imageselector"
+	"github.com/loft-sh/devspace/pkg/util/log"
+)
+
+const (
+	// StatusInSync means the live objects match the rendered objects and all workloads are ready
+	StatusInSync = "InSync"
+	// StatusDrifted means the live objects differ from the rendered objects
+	StatusDrifted = "Drifted"
+	// StatusUnhealthy means the live objects match the rendered objects, but not all workloads are ready
+	StatusUnhealthy = "Unhealthy"
+	// StatusNotDeployed means the deployment was never deployed or was purged
+	StatusNotDeployed = "NotDeployed"
+	// StatusError means the status could not be determined
+	StatusError = "Error"
+)
+
+const (
+	// DriftModified means the live object differs from the rendered object
+	DriftModified = "modified"
+	// DriftMissing means the rendered object does not exist in the cluster
+	DriftMissing = "missing"
+	// DriftOrphaned means the object was deployed, but is not rendered anymore
+	DriftOrphaned = "orphaned"
+	// DriftImage means a container image differs from the last built image
+	DriftImage = "image"
+)
+
+// DeploymentStatus is the drift and health status of a deployment
+type DeploymentStatus struct {
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Status    string            `json:"status"`
+	Drift     []*ObjectDrift    `json:"drift,omitempty"`
+	Workloads []*WorkloadStatus `json:"workloads,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// ObjectDrift describes how a live object differs from what DevSpace would deploy
+type ObjectDrift struct {
+	Object  string `json:"object"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+	Diff    string `json:"diff,omitempty"`
+}
+
+// WorkloadStatus is the health of a workload of a deployment
+type WorkloadStatus struct {
+	Name    string `json:"name"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message"`
+}
+
+// Status compares the live objects of all deployments with the objects rendered with the last built images
+// and checks the health of the deployed workloads
+func (c *controller) Status(options *Options, log log.Logger) ([]*DeploymentStatus, error) {
+	helmV2Clients := map[string]helmtypes.Client{}
+	statuses := []*DeploymentStatus{}
+	for _, deployConfig := range c.config.Config().Deployments {
+		if len(options.Deployments) > 0 {
+			shouldSkip := true
+
+			for _, deployment := range options.Deployments {
+				if deployment == deployConfig.Name {
+					shouldSkip = false
+					break
+				}
+			}
+
+			if shouldSkip {
+				continue
+			}
+		}
+
+		status := &DeploymentStatus{
+			Name: deployConfig.Name,
+			Type: deploymentType(deployConfig),
+		}
+		err := c.deploymentStatus(deployConfig, helmV2Clients, status, log)
+		if err != nil {
+			status.Status = StatusError
+			status.Error = err.Error()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (c *controller) deploymentStatus(deployConfig *latest.DeploymentConfig, helmV2Clients map[string]helmtypes.Client, status *DeploymentStatus, log log.Logger) error {
+	deployClient, err := c.getDeployClient(deployConfig, helmV2Clients, false, log)
+	if err != nil {
+		return err
+	}
+
+	objects, err := deployClient.DeployedObjects()
+	if err != nil {
+		return err
+	} else if len(objects) == 0 {
+		status.Status = StatusNotDeployed
+		return nil
+	}
+
+	diffs, err := deployClient.Drift()
+	if err != nil {
+		return err
+	}
+	for _, objDiff := range diffs {
+		switch objDiff.Action {
+		case diff.ActionChanged:
+			status.Drift = append(status.Drift, &ObjectDrift{Object: objDiff.Name, Reason: DriftModified, Diff: objDiff.Diff})
+		case diff.ActionCreated:
+			status.Drift = append(status.Drift, &ObjectDrift{Object: objDiff.Name, Reason: DriftMissing})
+		case diff.ActionDeleted:
+			status.Drift = append(status.Drift, &ObjectDrift{Object: objDiff.Name, Reason: DriftOrphaned})
+		}
+	}
+
+	namespace := c.client.Namespace()
+	if deployConfig.Namespace != "" {
+		namespace = deployConfig.Namespace
+	}
+
+	builtImages := c.lastBuiltImages()
+	ready := true
+	for _, obj := range objects {
+		if !rollout.IsWorkload(obj) {
+			continue
+		}
+
+		obj = obj.DeepCopy()
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		workload, err := rollout.GetStatus(c.client.KubeClient(), obj)
+		if err != nil {
+			return err
+		}
+
+		ready = ready && workload.Ready
+		status.Workloads = append(status.Workloads, &WorkloadStatus{
+			Name:    workload.Name,
+			Ready:   workload.Ready,
+			Message: workload.Message,
+		})
+		status.Drift = append(status.Drift, imageDrift(workload, builtImages)...)
+	}
+
+	if len(status.Drift) > 0 {
+		status.Status = StatusDrifted
+	} else if !ready {
+		status.Status = StatusUnhealthy
+	} else {
+		status.Status = StatusInSync
+	}
+
+	return nil
+}
+
+// lastBuiltImages returns the tags of the last built images by image name
+func (c *controller) lastBuiltImages() map[string]string {
+	builtImages := map[string]string{}
+	imageCache := c.config.Generated().GetActive().Images
+	for key, imageConfig := range c.config.Config().Images {
+		if imageCache[key] == nil || imageCache[key].Tag == "" {
+			continue
+		}
+
+		name, _, err := imageselector.GetStrippedDockerImageName(imageConfig.Image)
+		if err != nil {
+			name = imageConfig.Image
+		}
+
+		builtImages[name] = imageCache[key].Tag
+	}
+
+	return builtImages
+}
+
+// imageDrift returns a drift for every container image of the workload that differs from the last built image
+func imageDrift(workload *rollout.Status, builtImages map[string]string) []*ObjectDrift {
+	drift := []*ObjectDrift{}
+	for _, image := range workload.Images {
+		name, tag, err := imageselector.GetStrippedDockerImageName(image)
+		if err != nil {
+			continue
+		}
+
+		builtTag, ok := builtImages[name]
+		if !ok || tag == builtTag {
+			continue
+		}
+
+		drift = append(drift, &ObjectDrift{
+			Object:  workload.Name,
+			Reason:  DriftImage,
+			Message: fmt.Sprintf("runs %s, but the last built image is %s:%s", image, name, builtTag),
+		})
+	}
+
+	return drift
+}
+
+func deploymentType(deployConfig *latest.DeploymentConfig) string {
+	if deployConfig.Helm != nil {
+		return "helm"
+	} else if deployConfig.Kubectl != nil {
+		return "kubectl"
+	}
+
+	return ""
+}
@@ -0,0 +1,241 @@
+package deploy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/rollout"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type imageDriftTestCase struct {
+	name string
+
+	images      []string
+	builtImages map[string]string
+
+	expectedMessages []string
+}
+
+func TestImageDrift(t *testing.T) {
+	testCases := []imageDriftTestCase{
+		{
+			name:        "last built image",
+			images:      []string{"myuser/app:abcde"},
+			builtImages: map[string]string{"myuser/app": "abcde"},
+		},
+		{
+			name:        "image not built by devspace",
+			images:      []string{"nginx:1.21", "myuser/app:abcde"},
+			builtImages: map[string]string{"myuser/app": "abcde"},
+		},
+		{
+			name:             "different tag",
+			images:           []string{"myuser/app:old"},
+			builtImages:      map[string]string{"myuser/app": "abcde"},
+			expectedMessages: []string{"runs myuser/app:old, but the last built image is myuser/app:abcde"},
+		},
+		{
+			name:             "registry prefix",
+			images:           []string{"docker.io/myuser/app:latest"},
+			builtImages:      map[string]string{"myuser/app": "abcde"},
+			expectedMessages: []string{"runs docker.io/myuser/app:latest, but the last built image is myuser/app:abcde"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		drift := imageDrift(&rollout.Status{Name: "deployment/app", Images: testCase.images}, testCase.builtImages)
+
+		messages := []string{}
+		for _, d := range drift {
+			assert.Equal(t, d.Object, "deployment/app", "Unexpected object in testCase %s", testCase.name)
+			assert.Equal(t, d.Reason, DriftImage, "Unexpected reason in testCase %s", testCase.name)
+			messages = append(messages, d.Message)
+		}
+		if testCase.expectedMessages == nil {
+			testCase.expectedMessages = []string{}
+		}
+		assert.DeepEqual(t, messages, testCase.expectedMessages)
+	}
+}
+
+// driftTestClient is a fake kube client whose rest config points to a fake api server
+type driftTestClient struct {
+	*fakekube.Client
+	host string
+}
+
+func (c *driftTestClient) RestConfig() *rest.Config {
+	return &rest.Config{Host: c.host}
+}
+
+// newDriftTestServer returns a fake api server that serves the given objects. A server-side dry-run apply
+// returns the live object with the spec of the applied object
+func newDriftTestServer(t *testing.T, objects map[string]*unstructured.Unstructured) *httptest.Server {
+	discovery := map[string]interface{}{
+		"/api": &metav1.APIVersions{Versions: []string{"v1"}},
+		"/apis": &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+			Name:             "apps",
+			Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: "apps/v1", Version: "v1"}},
+			PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "apps/v1", Version: "v1"},
+		}}},
+		"/api/v1": &metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "patch", "delete"}},
+		}},
+		"/apis/apps/v1": &metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "patch", "delete"}},
+		}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if response, ok := discovery[r.URL.Path]; ok {
+			assert.NilError(t, json.NewEncoder(w).Encode(response))
+			return
+		}
+
+		live, ok := objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			assert.NilError(t, json.NewEncoder(w).Encode(&metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound}))
+			return
+		}
+
+		result := live.DeepCopy()
+		if r.Method == http.MethodPatch {
+			assert.Equal(t, r.URL.Query().Get("dryRun"), "All", "only dry-run applies are expected")
+
+			applied := &unstructured.Unstructured{}
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NilError(t, err)
+			assert.NilError(t, applied.UnmarshalJSON(body))
+
+			spec, _, _ := unstructured.NestedMap(applied.Object, "spec")
+			for key, value := range spec {
+				assert.NilError(t, unstructured.SetNestedField(result.Object, value, "spec", key))
+			}
+		}
+
+		assert.NilError(t, json.NewEncoder(w).Encode(result.Object))
+	}))
+}
+
+func TestKubectlDeploymentStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	deployment := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+      - name: app
+        image: nginx
+`
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte("resources:\n- deployment.yaml\n"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "deployment.yaml"), []byte(deployment), 0644))
+
+	// the deployment was scaled in the cluster, so the live object differs from the last applied configuration
+	rendered := &unstructured.Unstructured{}
+	assert.NilError(t, yaml.Unmarshal([]byte(deployment), &rendered.Object))
+	lastApplied, err := rendered.MarshalJSON()
+	assert.NilError(t, err)
+
+	live := rendered.DeepCopy()
+	live.SetNamespace("test")
+	live.SetAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": string(lastApplied)})
+	assert.NilError(t, unstructured.SetNestedField(live.Object, int64(3), "spec", "replicas"))
+
+	// the config map was deployed before, but isn't part of the manifests anymore
+	orphan := &unstructured.Unstructured{}
+	orphan.SetAPIVersion("v1")
+	orphan.SetKind("ConfigMap")
+	orphan.SetNamespace("test")
+	orphan.SetName("old-config")
+
+	server := newDriftTestServer(t, map[string]*unstructured.Unstructured{
+		"/apis/apps/v1/namespaces/test/deployments/app": live,
+		"/api/v1/namespaces/test/configmaps/old-config": orphan,
+	})
+	defer server.Close()
+
+	replicas := int32(3)
+	kubeClient := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+	})
+	kubeClient.PrependReactor("get", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if !strings.HasPrefix(action.(k8stesting.GetAction).GetName(), "devspace-inventory-app-") {
+			return false, nil, nil
+		}
+
+		return true, &corev1.ConfigMap{Data: map[string]string{
+			"objects": `[{"apiVersion":"apps/v1","kind":"Deployment","name":"app"},{"apiVersion":"v1","kind":"ConfigMap","name":"old-config"}]`,
+		}}, nil
+	})
+
+	prune := false
+	testConfig := &latest.Config{
+		Deployments: []*latest.DeploymentConfig{
+			{
+				Name:      "app",
+				Namespace: "test",
+				Kubectl: &latest.KubectlConfig{
+					Manifests: []string{dir},
+					Kustomize: latest.KustomizeModeEmbedded,
+					CmdPath:   "kubectl",
+					Prune:     &prune,
+				},
+			},
+		},
+	}
+
+	client := &driftTestClient{Client: &fakekube.Client{Client: kubeClient}, host: server.URL}
+	controller := NewController(config.NewConfig(nil, testConfig, generated.New(), nil, filepath.Join(dir, "devspace.yaml")), nil, client)
+	statuses, err := controller.Status(&Options{}, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, len(statuses), 1)
+	assert.Equal(t, statuses[0].Error, "")
+	assert.Equal(t, statuses[0].Status, StatusDrifted)
+
+	reasons := map[string]string{}
+	for _, drift := range statuses[0].Drift {
+		reasons[drift.Object] = drift.Reason
+	}
+	assert.DeepEqual(t, reasons, map[string]string{
+		"deployment.apps/test/app":  DriftModified,
+		"configmap/test/old-config": DriftOrphaned,
+	})
+}
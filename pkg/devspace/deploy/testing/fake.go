@@ -5,6 +5,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	"github.com/loft-sh/devspace/pkg/util/log"
 )
 
@@ -26,7 +27,27 @@ func (f *FakeController) Render(options *deploy.Options, out io.Writer, log log.
 	return nil
 }
 
+// Diff implements interface
+func (f *FakeController) Diff(options *deploy.Options, out io.Writer, log log.Logger) (bool, error) {
+	return false, nil
+}
+
+// Status implements interface
+func (f *FakeController) Status(options *deploy.Options, log log.Logger) ([]*deploy.DeploymentStatus, error) {
+	return nil, nil
+}
+
 // Purge purges the deployments
 func (f *FakeController) Purge(deployments []string, log log.Logger) error {
 	return nil
 }
+
+// History implements interface
+func (f *FakeController) History(deployment string, log log.Logger) ([]*deployer.Revision, error) {
+	return nil, nil
+}
+
+// Rollback implements interface
+func (f *FakeController) Rollback(deployment string, revision int, log log.Logger) error {
+	return nil
+}
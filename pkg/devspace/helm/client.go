@@ -2,6 +2,7 @@ package helm
 
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/native"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/v2"
 	v3 "github.com/loft-sh/devspace/pkg/devspace/helm/v3"
@@ -15,5 +16,32 @@ func NewClient(config *latest.Config, deployConfig *latest.DeploymentConfig, kub
 		return v2.NewClient(config, kubeClient, tillerNamespace, log)
 	}
 
+	if deployConfig.Helm.Native {
+		// the native client cannot pass extra arguments to the helm binary, so we fall back to the binary
+		if option := cliOnlyOption(deployConfig.Helm); option != "" {
+			log.Warnf("Deployment %s uses helm.%s, which is not supported by the native helm client. Falling back to the helm binary", deployConfig.Name, option)
+		} else {
+			return native.NewClient(kubeClient, log)
+		}
+	}
+
 	return v3.NewClient(kubeClient, log)
 }
+
+// cliOnlyOption returns the first option of the helm config that only the helm binary supports
+func cliOnlyOption(helmConfig *latest.HelmConfig) string {
+	switch {
+	case helmConfig.Path != "":
+		return "path"
+	case len(helmConfig.UpgradeArgs) > 0:
+		return "upgradeArgs"
+	case len(helmConfig.TemplateArgs) > 0:
+		return "templateArgs"
+	case len(helmConfig.DeleteArgs) > 0:
+		return "deleteArgs"
+	case len(helmConfig.FetchArgs) > 0:
+		return "fetchArgs"
+	}
+
+	return ""
+}
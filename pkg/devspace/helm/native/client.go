@@ -0,0 +1,402 @@
+package native
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/generic"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// defaultTimeout is the timeout helm uses if no timeout is specified
+const defaultTimeout = 5 * time.Minute
+
+// timeFormat is the format helm list uses for the last deployed time
+const timeFormat = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+type client struct {
+	kubeClient kubectl.Client
+	settings   *cli.EnvSettings
+
+	// newConfiguration creates the helm sdk configuration for the release namespace
+	newConfiguration func(namespace string, helmConfig *latest.HelmConfig) (*action.Configuration, error)
+
+	// configurations holds the helm sdk configurations by kube context, storage driver and namespace
+	configurationsMutex sync.Mutex
+	configurations      map[string]*action.Configuration
+
+	log log.Logger
+}
+
+// NewClient creates a new helm v3 client that uses the helm sdk instead of the helm binary
+func NewClient(kubeClient kubectl.Client, log log.Logger) (types.Client, error) {
+	c := &client{
+		kubeClient:     kubeClient,
+		settings:       cli.New(),
+		configurations: map[string]*action.Configuration{},
+		log:            log,
+	}
+
+	c.newConfiguration = c.initConfiguration
+	return c, nil
+}
+
+func (c *client) initConfiguration(namespace string, helmConfig *latest.HelmConfig) (*action.Configuration, error) {
+	switch helmConfig.Driver {
+	case "", "secret", "secrets", "configmap", "configmaps", "memory":
+	default:
+		return nil, errors.Errorf("unsupported helm storage driver %s, please use secret, configmap or memory", helmConfig.Driver)
+	}
+
+	configuration := &action.Configuration{}
+	err := configuration.Init(&restClientGetter{kubeClient: c.kubeClient}, namespace, helmConfig.Driver, c.log.Debugf)
+	if err != nil {
+		return nil, err
+	}
+
+	// objects without namespace are deployed into the release namespace
+	if kubeClient, ok := configuration.KubeClient.(*kube.Client); ok {
+		kubeClient.Namespace = namespace
+	}
+
+	return configuration, nil
+}
+
+func (c *client) configuration(namespace string, helmConfig *latest.HelmConfig) (*action.Configuration, error) {
+	c.configurationsMutex.Lock()
+	defer c.configurationsMutex.Unlock()
+
+	// the configuration is bound to the cluster, the storage driver and the namespace of the releases
+	key := strings.Join([]string{c.kubeClient.CurrentContext(), helmConfig.Driver, namespace}, "/")
+	if c.configurations[key] != nil {
+		return c.configurations[key], nil
+	}
+
+	configuration, err := c.newConfiguration(namespace, helmConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "init helm")
+	}
+
+	c.configurations[key] = configuration
+	return configuration, nil
+}
+
+// InstallChart installs or upgrades the given chart via the helm sdk
+func (c *client) InstallChart(releaseName string, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*types.Release, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	configuration, err := c.configuration(releaseNamespace, helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	chrt, err := c.loadChart(helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := convertValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultTimeout
+	if helmConfig.Timeout != nil {
+		timeout = time.Duration(*helmConfig.Timeout) * time.Second
+	}
+
+	var rel *release.Release
+	_, err = action.NewHistory(configuration).Run(releaseName)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		c.log.Infof("Install release %s with helm", releaseName)
+		install := action.NewInstall(configuration)
+		install.ReleaseName = releaseName
+		install.Namespace = releaseNamespace
+		install.Atomic = helmConfig.Atomic
+		install.Wait = helmConfig.Wait || helmConfig.Atomic
+		install.Timeout = timeout
+		install.DisableHooks = helmConfig.DisableHooks
+		rel, err = install.Run(chrt, vals)
+	} else if err == nil {
+		c.log.Infof("Upgrade release %s with helm", releaseName)
+		upgrade := action.NewUpgrade(configuration)
+		upgrade.Namespace = releaseNamespace
+		upgrade.Atomic = helmConfig.Atomic
+		upgrade.CleanupOnFail = helmConfig.CleanupOnFail
+		upgrade.Wait = helmConfig.Wait || helmConfig.Atomic
+		upgrade.Timeout = timeout
+		upgrade.Force = helmConfig.Force
+		upgrade.Recreate = helmConfig.Recreate
+		upgrade.DisableHooks = helmConfig.DisableHooks
+		rel, err = upgrade.Run(releaseName, chrt, vals)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "deploy release %s", releaseName)
+	}
+
+	if helmConfig.DisplayOutput && rel.Info != nil && rel.Info.Notes != "" {
+		_, _ = c.log.Write([]byte(rel.Info.Notes + "\n"))
+	}
+
+	return convertRelease(rel), nil
+}
+
+// Template renders the chart via the helm sdk without accessing the cluster
+func (c *client) Template(releaseName, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	chrt, err := c.loadChart(helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	vals, err := convertValues(values)
+	if err != nil {
+		return "", err
+	}
+
+	// client only installs replace the kube client and storage of the configuration
+	install := action.NewInstall(&action.Configuration{Log: c.log.Debugf})
+	install.ReleaseName = releaseName
+	install.Namespace = releaseNamespace
+	install.DryRun = true
+	install.Replace = true
+	install.ClientOnly = true
+	install.DisableHooks = helmConfig.DisableHooks
+	rel, err := install.Run(chrt, vals)
+	if err != nil {
+		return "", errors.Wrapf(err, "render release %s", releaseName)
+	}
+
+	manifests := &bytes.Buffer{}
+	fmt.Fprintln(manifests, strings.TrimSpace(rel.Manifest))
+	if !helmConfig.DisableHooks {
+		for _, hook := range rel.Hooks {
+			fmt.Fprintf(manifests, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+		}
+	}
+
+	return manifests.String(), nil
+}
+
+// DeleteRelease uninstalls the release via the helm sdk
+func (c *client) DeleteRelease(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) error {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	configuration, err := c.configuration(releaseNamespace, helmConfig)
+	if err != nil {
+		return err
+	}
+
+	uninstall := action.NewUninstall(configuration)
+	uninstall.DisableHooks = helmConfig.DisableHooks
+	_, err = uninstall.Run(releaseName)
+	if err != nil {
+		return errors.Wrapf(err, "delete release %s", releaseName)
+	}
+
+	return nil
+}
+
+// ListReleases lists the releases in the namespace of the kube client
+func (c *client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release, error) {
+	configuration, err := c.configuration(c.kubeClient.Namespace(), helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	list := action.NewList(configuration)
+	list.SetStateMask()
+	rels, err := list.Run()
+	if err != nil {
+		return nil, errors.Wrap(err, "list releases")
+	}
+
+	releases := []*types.Release{}
+	for _, rel := range rels {
+		releases = append(releases, convertRelease(rel))
+	}
+
+	return releases, nil
+}
+
+// GetManifest returns the manifest of the deployed release or an empty string if the release does not exist
+func (c *client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	configuration, err := c.configuration(releaseNamespace, helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := action.NewGet(configuration).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return "", nil
+		}
+
+		return "", errors.Wrapf(err, "get release %s", releaseName)
+	}
+
+	return rel.Manifest, nil
+}
+
+// History returns the revisions of the release or nil if the release does not exist
+func (c *client) History(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) ([]*types.Revision, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	configuration, err := c.configuration(releaseNamespace, helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	rels, err := action.NewHistory(configuration).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "get history of release %s", releaseName)
+	}
+
+	sort.Slice(rels, func(i, j int) bool {
+		return rels[i].Version < rels[j].Version
+	})
+
+	revisions := []*types.Revision{}
+	for _, rel := range rels {
+		revision := &types.Revision{
+			Revision: rel.Version,
+		}
+		if rel.Info != nil {
+			revision.Updated = rel.Info.LastDeployed.Format(time.RFC3339)
+			revision.Status = rel.Info.Status.String()
+			revision.Description = rel.Info.Description
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			revision.Chart = rel.Chart.Metadata.Name + "-" + rel.Chart.Metadata.Version
+			revision.AppVersion = rel.Chart.Metadata.AppVersion
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// Rollback rolls the release back to the given revision via the helm sdk
+func (c *client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	configuration, err := c.configuration(releaseNamespace, helmConfig)
+	if err != nil {
+		return err
+	}
+
+	rollback := action.NewRollback(configuration)
+	rollback.Version = revision
+	rollback.CleanupOnFail = helmConfig.CleanupOnFail
+	rollback.Wait = helmConfig.Wait
+	rollback.Force = helmConfig.Force
+	rollback.DisableHooks = helmConfig.DisableHooks
+	rollback.Timeout = defaultTimeout
+	if helmConfig.Timeout != nil {
+		rollback.Timeout = time.Duration(*helmConfig.Timeout) * time.Second
+	}
+
+	err = rollback.Run(releaseName)
+	if err != nil {
+		return errors.Wrapf(err, "roll back release %s", releaseName)
+	}
+
+	return nil
+}
+
+// loadChart locates the chart of the helm config, downloads it if necessary and loads it
+func (c *client) loadChart(helmConfig *latest.HelmConfig) (*chart.Chart, error) {
+	chartName, chartRepo := generic.ChartNameAndRepo(helmConfig)
+	chartPathOptions := &action.ChartPathOptions{
+		RepoURL:  chartRepo,
+		Version:  helmConfig.Chart.Version,
+		Username: helmConfig.Chart.Username,
+		Password: helmConfig.Chart.Password,
+	}
+
+	chartPath, err := chartPathOptions.LocateChart(chartName, c.settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "locate chart %s", chartName)
+	}
+
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load chart %s", chartName)
+	}
+
+	if chrt.Metadata.Dependencies != nil {
+		err = action.CheckDependencies(chrt, chrt.Metadata.Dependencies)
+		if err != nil {
+			return nil, errors.Wrapf(err, "chart %s", chartName)
+		}
+	}
+
+	return chrt, nil
+}
+
+// convertValues converts the yaml values of the config into helm values
+func convertValues(values map[interface{}]interface{}) (map[string]interface{}, error) {
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal values")
+	}
+
+	vals, err := chartutil.ReadValues(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse values")
+	}
+
+	return vals, nil
+}
+
+func convertRelease(rel *release.Release) *types.Release {
+	converted := &types.Release{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  strconv.Itoa(rel.Version),
+	}
+	if rel.Info != nil {
+		converted.Status = rel.Info.Status.String()
+		converted.LastDeployed = rel.Info.LastDeployed.Format(timeFormat)
+	}
+
+	return converted
+}
@@ -0,0 +1,184 @@
+package native
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testChartYaml = `apiVersion: v2
+name: test
+version: 0.1.0
+appVersion: "1.0"
+`
+
+const testConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  value: {{ .Values.value | quote }}
+`
+
+const testHook = `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-hook
+  annotations:
+    helm.sh/hook: post-install
+spec:
+  containers:
+  - name: hook
+    image: busybox
+`
+
+func newTestClient(t *testing.T) (*client, *latest.HelmConfig) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	files := map[string]string{
+		"Chart.yaml":          testChartYaml,
+		"templates/cm.yaml":   testConfigMap,
+		"templates/hook.yaml": testHook,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, "chart", name)
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NilError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	c := &client{
+		kubeClient:     &fakekube.Client{Client: fake.NewSimpleClientset()},
+		settings:       cli.New(),
+		configurations: map[string]*action.Configuration{},
+		log:            log.Discard,
+	}
+	c.newConfiguration = func(namespace string, helmConfig *latest.HelmConfig) (*action.Configuration, error) {
+		memory := driver.NewMemory()
+		memory.SetNamespace(namespace)
+		return &action.Configuration{
+			Releases:     storage.Init(memory),
+			KubeClient:   &kubefake.PrintingKubeClient{Out: ioutil.Discard},
+			Capabilities: chartutil.DefaultCapabilities,
+			Log:          func(string, ...interface{}) {},
+		}, nil
+	}
+
+	return c, &latest.HelmConfig{
+		Chart: &latest.ChartConfig{
+			Name: filepath.Join(dir, "chart"),
+		},
+	}
+}
+
+func TestReleaseLifecycle(t *testing.T) {
+	c, helmConfig := newTestClient(t)
+
+	manifest, err := c.GetManifest("my-release", "", helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, manifest, "")
+
+	release, err := c.InstallChart("my-release", "", map[interface{}]interface{}{"value": "first"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, release.Name, "my-release")
+	assert.Equal(t, release.Namespace, "testNamespace")
+	assert.Equal(t, release.Revision, "1")
+	assert.Equal(t, release.Status, "deployed")
+
+	release, err = c.InstallChart("my-release", "", map[interface{}]interface{}{"value": "second"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, release.Revision, "2")
+
+	manifest, err = c.GetManifest("my-release", "", helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(manifest, `value: "second"`), manifest)
+
+	releases, err := c.ListReleases(helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, len(releases), 1)
+	assert.Equal(t, releases[0].Revision, "2")
+
+	err = c.Rollback("my-release", "", 1, helmConfig)
+	assert.NilError(t, err)
+
+	manifest, err = c.GetManifest("my-release", "", helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(manifest, `value: "first"`), manifest)
+
+	revisions, err := c.History("my-release", "", helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), 3)
+	for i, revision := range revisions {
+		assert.Equal(t, revision.Revision, i+1)
+		assert.Equal(t, revision.Chart, "test-0.1.0")
+		assert.Equal(t, revision.AppVersion, "1.0")
+	}
+	assert.Equal(t, revisions[0].Status, "superseded")
+	assert.Equal(t, revisions[2].Status, "deployed")
+	assert.Equal(t, revisions[2].Description, "Rollback to 1")
+
+	err = c.DeleteRelease("my-release", "", helmConfig)
+	assert.NilError(t, err)
+
+	revisions, err = c.History("my-release", "", helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, revisions == nil)
+}
+
+func TestTemplate(t *testing.T) {
+	c, helmConfig := newTestClient(t)
+
+	manifests, err := c.Template("my-release", "other", map[interface{}]interface{}{"value": "rendered"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(manifests, `value: "rendered"`), manifests)
+	assert.Assert(t, strings.Contains(manifests, "name: my-release-hook"), manifests)
+
+	// rendering must not create a release
+	releases, err := c.ListReleases(helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, len(releases), 0)
+
+	helmConfig.DisableHooks = true
+	manifests, err = c.Template("my-release", "other", nil, helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(manifests, "name: my-release-hook"), manifests)
+}
+
+func TestConfigurationCache(t *testing.T) {
+	c, helmConfig := newTestClient(t)
+
+	secrets, err := c.configuration("test", helmConfig)
+	assert.NilError(t, err)
+	cached, err := c.configuration("test", helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, secrets == cached, "expected the cached configuration")
+
+	// releases of another namespace, storage driver or kube context are stored elsewhere
+	other, err := c.configuration("other", helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, secrets != other, "expected a configuration for another namespace")
+
+	configMaps, err := c.configuration("test", &latest.HelmConfig{Driver: "configmap", Chart: helmConfig.Chart})
+	assert.NilError(t, err)
+	assert.Assert(t, secrets != configMaps, "expected a configuration for another storage driver")
+
+	c.kubeClient = &fakekube.Client{Client: fake.NewSimpleClientset(), Context: "other-context"}
+	otherContext, err := c.configuration("test", helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, secrets != otherContext, "expected a configuration for another kube context")
+}
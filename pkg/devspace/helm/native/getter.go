@@ -0,0 +1,43 @@
+package native
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// restClientGetter provides the helm sdk access to the cluster of the kube client
+type restClientGetter struct {
+	kubeClient kubectl.Client
+}
+
+func (r *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(r.kubeClient.RestConfig()), nil
+}
+
+func (r *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(r.kubeClient.RestConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return memory.NewMemCacheClient(discoveryClient), nil
+}
+
+func (r *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	discoveryClient, err := r.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return restmapper.NewShortcutExpander(mapper, discoveryClient), nil
+}
+
+func (r *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return r.kubeClient.ClientConfig()
+}
@@ -10,6 +10,12 @@ import (
 // Client implements Interface
 type Client struct {
 	Releases []*types.Release
+
+	// Manifests holds the release manifests by release name
+	Manifests map[string]string
+
+	// Revisions holds the release revisions by release name
+	Revisions map[string][]*types.Revision
 }
 
 // UpdateRepos implements interface
@@ -58,3 +64,33 @@ func (f *Client) InstallChart(releaseName string, releaseNamespace string, value
 func (f *Client) Template(releaseName, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error) {
 	return "", nil
 }
+
+// GetManifest implements interface
+func (f *Client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	return f.Manifests[releaseName], nil
+}
+
+// History implements interface
+func (f *Client) History(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) ([]*types.Revision, error) {
+	return f.Revisions[releaseName], nil
+}
+
+// Rollback implements interface
+func (f *Client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	revisions := f.Revisions[releaseName]
+	for _, r := range revisions {
+		if r.Revision == revision {
+			f.Revisions[releaseName] = append(revisions, &types.Revision{
+				Revision:    revisions[len(revisions)-1].Revision + 1,
+				Updated:     time.Now().String(),
+				Status:      "deployed",
+				Chart:       r.Chart,
+				AppVersion:  r.AppVersion,
+				Description: fmt.Sprintf("Rollback to %d", revision),
+			})
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Revision %d of release %s not found", revision, releaseName)
+}
@@ -10,6 +10,13 @@ type Client interface {
 	Template(releaseName, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error)
 	DeleteRelease(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) error
 	ListReleases(helmConfig *latest.HelmConfig) ([]*Release, error)
+
+	// GetManifest returns the manifest of the deployed release or an empty string if the release does not exist
+	GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error)
+
+	// History returns the revisions of the release or nil if the release does not exist
+	History(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) ([]*Revision, error)
+	Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error
 }
 
 // Release is the helm release struct
@@ -20,3 +27,13 @@ type Release struct {
 	Revision     string `json:"revision"`
 	LastDeployed string `json:"updated"`
 }
+
+// Revision is a revision of a helm release
+type Revision struct {
+	Revision    int    `json:"revision"`
+	Updated     string `json:"updated"`
+	Status      string `json:"status"`
+	Chart       string `json:"chart"`
+	AppVersion  string `json:"app_version"`
+	Description string `json:"description"`
+}
@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"encoding/json"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/generic"
 	"os"
 	"path/filepath"
@@ -174,6 +175,31 @@ func (c *client) DeleteRelease(releaseName string, releaseNamespace string, helm
 	return nil
 }
 
+func (c *client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	err := c.ensureTiller(helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+		"--tiller-namespace",
+		c.tillerNamespace,
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (c *client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release, error) {
 	err := c.ensureTiller(helmConfig)
 	if err != nil {
@@ -225,3 +251,62 @@ func (c *client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release,
 
 	return result, nil
 }
+
+func (c *client) History(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) ([]*types.Revision, error) {
+	err := c.ensureTiller(helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"history",
+		releaseName,
+		"--tiller-namespace",
+		c.tillerNamespace,
+		"--output",
+		"json",
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	revisions := []*types.Revision{}
+	err = json.Unmarshal(out, &revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (c *client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	err := c.ensureTiller(helmConfig)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+		strconv.Itoa(revision),
+		"--tiller-namespace",
+		c.tillerNamespace,
+	}
+	if helmConfig.Wait {
+		args = append(args, "--wait")
+	}
+	if helmConfig.Force {
+		args = append(args, "--force")
+	}
+	if helmConfig.DisableHooks {
+		args = append(args, "--no-hooks")
+	}
+
+	_, err = c.genericHelm.Exec(args, helmConfig)
+	return err
+}
@@ -207,6 +207,90 @@ func (c *client) DeleteRelease(releaseName string, releaseNamespace string, helm
 	return nil
 }
 
+func (c *client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+		"--namespace",
+		releaseNamespace,
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		if strings.Contains(err.Error(), "release: not found") {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func (c *client) History(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) ([]*types.Revision, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	args := []string{
+		"history",
+		releaseName,
+		"--namespace",
+		releaseNamespace,
+		"--output",
+		"json",
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		if strings.Contains(err.Error(), "release: not found") {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	revisions := []*types.Revision{}
+	err = yaml.Unmarshal(out, &revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (c *client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+		strconv.Itoa(revision),
+		"--namespace",
+		releaseNamespace,
+	}
+	if helmConfig.CleanupOnFail {
+		args = append(args, "--cleanup-on-fail")
+	}
+	if helmConfig.Wait {
+		args = append(args, "--wait")
+	}
+	if helmConfig.Force {
+		args = append(args, "--force")
+	}
+	if helmConfig.DisableHooks {
+		args = append(args, "--no-hooks")
+	}
+
+	_, err := c.genericHelm.Exec(args, helmConfig)
+	return err
+}
+
 func (c *client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release, error) {
 	args := []string{
 		"list",
@@ -0,0 +1,235 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// lookup returns the schema of the value at the path or nil if the path is not part of the schema. The
+// returned schema is not resolved, because the description of a property is part of the reference.
+// Paths within the replace, merge and strategicMerge sections of profiles are looked up relative to the
+// root of the config, because they are applied to it
+func (s *Server) lookup(path []string) *schema.Schema {
+	if len(path) >= 3 && path[0] == "profiles" && path[1] == itemKey && (path[2] == "replace" || path[2] == "merge" || path[2] == "strategicMerge") {
+		path = path[3:]
+	}
+
+	current := s.schema
+	for _, key := range path {
+		resolved := s.schema.Resolve(current)
+		if key == itemKey {
+			if resolved.Items == nil {
+				return nil
+			}
+
+			current = resolved.Items
+		} else if property, ok := resolved.Properties[key]; ok {
+			current = property
+		} else if additionalProperties, ok := resolved.AdditionalProperties.(*schema.Schema); ok {
+			current = additionalProperties
+		} else {
+			return nil
+		}
+	}
+
+	return current
+}
+
+// typeName returns a short description of the type of the schema, e.g. string[] or map[string]ImageConfig
+func (s *Server) typeName(property *schema.Schema) string {
+	resolved := s.schema.Resolve(property)
+	switch {
+	case len(resolved.Enum) > 0:
+		values := []string{}
+		for _, value := range resolved.Enum {
+			if value != "" {
+				values = append(values, fmt.Sprint(value))
+			}
+		}
+
+		return strings.Join(values, " | ")
+	case property.Ref != "":
+		return strings.TrimPrefix(property.Ref, "#/definitions/")
+	case resolved.Type == "array" && resolved.Items != nil:
+		return s.typeName(resolved.Items) + "[]"
+	case resolved.Type == "object":
+		if additionalProperties, ok := resolved.AdditionalProperties.(*schema.Schema); ok {
+			return "map[string]" + s.typeName(additionalProperties)
+		}
+	case resolved.Type == "":
+		return "any"
+	}
+
+	return resolved.Type
+}
+
+// completion returns the keys that can be added at the position or the values for the key at the position
+func (s *Server) completion(doc *document, position Position) []CompletionItem {
+	if position.Line >= len(doc.lines) {
+		return []CompletionItem{}
+	}
+
+	text := doc.lines[position.Line]
+	prefix := text[:minInt(position.Character, len(text))]
+	if index := strings.LastIndex(prefix, "${"); index >= 0 && !strings.Contains(prefix[index:], "}") {
+		return s.variableCompletion(doc)
+	}
+
+	l := parseLine(prefix)
+	if l.keyColumn >= 0 {
+		return s.valueCompletion(doc, append(doc.linePath(position.Line, l), l.key))
+	} else if strings.ContainsAny(prefix[l.column:], " :#") {
+		return []CompletionItem{}
+	}
+
+	path := doc.linePath(position.Line, l)
+	property := s.lookup(path)
+	if property == nil {
+		return []CompletionItem{}
+	}
+
+	existing := map[string]bool{}
+	for _, key := range doc.siblingKeys(position.Line, l) {
+		existing[key] = true
+	}
+
+	items := []CompletionItem{}
+	resolved := s.schema.Resolve(property)
+	for key, child := range resolved.Properties {
+		if existing[key] {
+			continue
+		}
+
+		insertText := key + ": "
+		if childType := s.schema.Resolve(child).Type; childType == "object" || childType == "array" {
+			insertText = key + ":"
+		}
+
+		item := CompletionItem{
+			Label:      key,
+			Kind:       CompletionItemKindProperty,
+			Detail:     s.typeName(child),
+			InsertText: insertText,
+		}
+		if child.Description != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: child.Description}
+		}
+
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
+
+// valueCompletion returns the possible values of the property at the path
+func (s *Server) valueCompletion(doc *document, path []string) []CompletionItem {
+	items := []CompletionItem{}
+	if key := path[len(path)-1]; key == "imageName" {
+		for _, name := range doc.imageNames() {
+			items = append(items, CompletionItem{Label: name, Kind: CompletionItemKindRef, Detail: "image"})
+		}
+
+		return items
+	}
+
+	property := s.lookup(path)
+	if property == nil {
+		return items
+	}
+
+	resolved := s.schema.Resolve(property)
+	values := resolved.Enum
+	if resolved.Type == "boolean" {
+		values = []interface{}{true, false}
+	}
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		items = append(items, CompletionItem{Label: fmt.Sprint(value), Kind: CompletionItemKindEnum})
+	}
+
+	return items
+}
+
+// variableCompletion returns the variables that are defined in the vars section
+func (s *Server) variableCompletion(doc *document) []CompletionItem {
+	items := []CompletionItem{}
+	_, vars := mappingValue(doc.root, "vars")
+	if vars == nil {
+		return items
+	}
+
+	for _, variable := range vars.Content {
+		_, name := mappingValue(variable, "name")
+		if name == nil {
+			continue
+		}
+
+		item := CompletionItem{Label: name.Value, Kind: CompletionItemKindVariable, InsertText: name.Value + "}"}
+		if _, source := mappingValue(variable, "source"); source != nil {
+			item.Detail = "source: " + source.Value
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// siblingKeys returns the keys of the mapping the line belongs to
+func (d *document) siblingKeys(lineIndex int, l *line) []string {
+	keys := []string{}
+	for i := lineIndex - 1; i >= 0 && l.dash < 0; i-- {
+		if isEmpty(d.lines[i]) {
+			continue
+		}
+
+		// the line that starts the sequence item belongs to the same mapping
+		sibling := parseLine(d.lines[i])
+		if sibling.keyColumn == l.column {
+			keys = append(keys, sibling.key)
+		}
+		if sibling.keyColumn >= 0 && sibling.keyColumn < l.column || sibling.dash >= 0 && sibling.dash < l.column {
+			break
+		}
+	}
+	for i := lineIndex + 1; i < len(d.lines); i++ {
+		if isEmpty(d.lines[i]) {
+			continue
+		}
+
+		sibling := parseLine(d.lines[i])
+		if sibling.keyColumn >= 0 && sibling.keyColumn < l.column || sibling.dash >= 0 && sibling.dash < l.column {
+			break
+		} else if sibling.keyColumn == l.column {
+			keys = append(keys, sibling.key)
+		}
+	}
+
+	return keys
+}
+
+// imageNames returns the names of the images that are defined in the document
+func (d *document) imageNames() []string {
+	names := []string{}
+	_, images := mappingValue(d.root, "images")
+	if images == nil || images.Kind != yaml.MappingNode {
+		return names
+	}
+
+	for i := 0; i < len(images.Content); i += 2 {
+		names = append(names, images.Content[i].Value)
+	}
+
+	return names
+}
@@ -0,0 +1,88 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/util/imageselector"
+	"gopkg.in/yaml.v3"
+)
+
+// imageHelperRegEx matches the image() and tag() helpers that reference an image by its name
+var imageHelperRegEx = regexp.MustCompile(`(?:image|tag)\("?'?([^)"']+)"?'?\)`)
+
+// definition returns the definition of the variable or the image referenced at the position
+func (s *Server) definition(doc *document, position Position) []Location {
+	if name, _ := doc.variableAt(position); name != "" {
+		definition := doc.variableDefinition(name)
+		if definition == nil {
+			return nil
+		}
+
+		_, nameNode := mappingValue(definition, "name")
+		return []Location{{URI: doc.uri, Range: nodeRange(nameNode)}}
+	}
+
+	if position.Line >= len(doc.lines) {
+		return nil
+	}
+
+	text := doc.lines[position.Line]
+	l := parseLine(text)
+	if l.key != "imageName" && l.key != "imageSelector" {
+		return nil
+	}
+
+	word, wordRange := doc.wordAt(position)
+	if word == "" || wordRange.Start.Character <= l.keyColumn+len(l.key) {
+		return nil
+	}
+
+	name := ""
+	if l.key == "imageName" {
+		name = word
+	} else if match := imageHelperRegEx.FindStringSubmatch(word); match != nil {
+		name = match[1]
+	} else {
+		name = doc.imageNameOf(word)
+	}
+
+	_, images := mappingValue(doc.root, "images")
+	key, _ := mappingValue(images, name)
+	if key == nil {
+		return nil
+	}
+
+	return []Location{{URI: doc.uri, Range: nodeRange(key)}}
+}
+
+// imageNameOf returns the name of the image with the given image reference, e.g. the name of the image
+// john/devbackend for john/devbackend:latest
+func (d *document) imageNameOf(reference string) string {
+	image, _, err := imageselector.GetStrippedDockerImageName(reference)
+	if err != nil {
+		image = reference
+	}
+
+	_, images := mappingValue(d.root, "images")
+	if images == nil || images.Kind != yaml.MappingNode {
+		return ""
+	}
+
+	for i := 0; i+1 < len(images.Content); i += 2 {
+		_, imageNode := mappingValue(images.Content[i+1], "image")
+		if imageNode == nil {
+			continue
+		}
+
+		configImage, _, err := imageselector.GetStrippedDockerImageName(imageNode.Value)
+		if err != nil {
+			configImage = imageNode.Value
+		}
+		if configImage == image || strings.TrimSpace(imageNode.Value) == reference {
+			return images.Content[i].Value
+		}
+	}
+
+	return ""
+}
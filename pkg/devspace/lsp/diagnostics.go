@@ -0,0 +1,136 @@
+package lsp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+const diagnosticSource = "devspace"
+
+var yamlErrorLineRegEx = regexp.MustCompile(`line (\d+)`)
+
+// diagnostics returns the syntax errors, schema violations and errors of the loader validation. The
+// loader validation only runs if there are no syntax errors and schema violations
+func (s *Server) diagnostics(doc *document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	if doc.parseErr != nil {
+		line := 0
+		if match := yamlErrorLineRegEx.FindStringSubmatch(doc.parseErr.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			line--
+		}
+
+		return append(diagnostics, doc.lineDiagnostic(line, SeverityError, doc.parseErr.Error()))
+	} else if doc.root == nil {
+		return diagnostics
+	}
+
+	versionKey, version := mappingValue(doc.root, "version")
+	if version == nil {
+		return append(diagnostics, doc.lineDiagnostic(0, SeverityError, "version is missing"))
+	} else if version.Value != latest.Version {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    nodeRange(versionKey),
+			Severity: SeverityInformation,
+			Source:   diagnosticSource,
+			Message:  "config version " + version.Value + " is outdated, run 'devspace upgrade config' to upgrade it to " + latest.Version,
+		})
+	} else {
+		schemaErrors, err := s.schema.Validate([]byte(doc.text))
+		if err != nil {
+			return append(diagnostics, doc.lineDiagnostic(0, SeverityError, err.Error()))
+		}
+
+		for _, schemaError := range schemaErrors {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    doc.wordRange(schemaError.Line-1, schemaError.Column-1),
+				Severity: SeverityError,
+				Source:   diagnosticSource,
+				Message:  schemaError.Path + ": " + schemaError.Message,
+			})
+		}
+		if len(schemaErrors) > 0 {
+			return diagnostics
+		}
+	}
+
+	err := validate([]byte(doc.text))
+	if err != nil {
+		// the loader errors start with the path of the invalid value, e.g. deployments[0].name is required
+		node := findNode(doc.root, strings.Fields(err.Error())[0])
+		r := nodeRange(node)
+		if node == doc.root {
+			r = nodeRange(versionKey)
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    r,
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  err.Error(),
+		})
+	}
+
+	return diagnostics
+}
+
+// validate parses the config like the loader and validates it. Variables and profiles are not resolved,
+// so that the validation doesn't need a cluster or user input
+func validate(content []byte) error {
+	data := map[interface{}]interface{}{}
+	err := yaml2.Unmarshal(content, &data)
+	if err != nil {
+		return err
+	}
+	delete(data, "profiles")
+
+	config, err := versions.Parse(data, log.Discard)
+	if err != nil {
+		// values with variables can only be parsed after the variables are resolved
+		if strings.Contains(err.Error(), "`$") {
+			return nil
+		}
+
+		return err
+	}
+
+	return loader.ValidateConfig(config, log.Discard)
+}
+
+// lineDiagnostic returns a diagnostic for the complete line
+func (d *document) lineDiagnostic(line, severity int, message string) Diagnostic {
+	if line < 0 || line >= len(d.lines) {
+		line = 0
+	}
+
+	return Diagnostic{
+		Range: Range{
+			Start: Position{Line: line},
+			End:   Position{Line: line, Character: len(d.lines[line])},
+		},
+		Severity: severity,
+		Source:   diagnosticSource,
+		Message:  message,
+	}
+}
+
+// wordRange returns the range of the word that starts at the position
+func (d *document) wordRange(line, character int) Range {
+	if line < 0 || line >= len(d.lines) {
+		return Range{}
+	}
+
+	word, r := d.wordAt(Position{Line: line, Character: character})
+	if word == "" {
+		r.End.Character = len(d.lines[line])
+	}
+
+	return r
+}
@@ -0,0 +1,252 @@
+package lsp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// itemKey is the path element of an item of a sequence
+const itemKey = "[]"
+
+var keyRegEx = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s#"'{}\[\],][^#]*?)\s*:(\s|$)`)
+
+// document is an opened devspace.yaml
+type document struct {
+	uri   string
+	text  string
+	lines []string
+
+	// root is the parsed yaml document or nil if the document is no valid yaml
+	root     *yaml.Node
+	parseErr error
+}
+
+func newDocument(uri, text string) *document {
+	doc := &document{
+		uri:   uri,
+		text:  text,
+		lines: strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"),
+	}
+
+	root := &yaml.Node{}
+	doc.parseErr = yaml.Unmarshal([]byte(text), root)
+	if doc.parseErr == nil && len(root.Content) > 0 && root.Content[0].Kind == yaml.MappingNode {
+		doc.root = root.Content[0]
+	}
+
+	return doc
+}
+
+// line is the structure of a single line of a yaml document. The parsing is line based, so that it also
+// works for incomplete documents while the user is typing
+type line struct {
+	// dash is the column of the innermost sequence item dash or -1
+	dash int
+
+	// key is the mapping key of the line and keyColumn its column or -1 if the line has no key
+	key       string
+	keyColumn int
+
+	// column is the column of the content after the indentation and the sequence item dashes
+	column int
+}
+
+func parseLine(text string) *line {
+	l := &line{dash: -1, keyColumn: -1}
+	for l.column < len(text) && text[l.column] == ' ' {
+		l.column++
+	}
+	for l.column < len(text) && text[l.column] == '-' && (l.column+1 == len(text) || text[l.column+1] == ' ') {
+		l.dash = l.column
+		l.column++
+		for l.column < len(text) && text[l.column] == ' ' {
+			l.column++
+		}
+	}
+
+	match := keyRegEx.FindStringSubmatch(text[l.column:])
+	if match != nil {
+		l.key = unquote(match[1])
+		l.keyColumn = l.column
+	}
+
+	return l
+}
+
+// isEmpty returns true if the line has no content or is a comment
+func isEmpty(text string) bool {
+	trimmed := strings.TrimSpace(text)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// path returns the keys of the mappings and sequences that contain the content at the given column of
+// the line, e.g. [deployments [] helm]. If inSequence is true the column is the column of a sequence
+// item dash instead of a mapping key
+func (d *document) path(lineIndex, column int, inSequence bool) []string {
+	path := []string{}
+	for i := lineIndex - 1; i >= 0 && (column > 0 || inSequence); i-- {
+		if isEmpty(d.lines[i]) {
+			continue
+		}
+
+		l := parseLine(d.lines[i])
+		if inSequence {
+			if l.dash == column {
+				// another item of the same sequence
+				continue
+			} else if l.keyColumn >= 0 && l.keyColumn <= column && l.dash < column {
+				path = append([]string{l.key}, path...)
+				column, inSequence = l.keyColumn, false
+				if l.dash >= 0 {
+					path = append([]string{itemKey}, path...)
+					column, inSequence = l.dash, true
+				}
+			} else if l.dash >= 0 && l.dash < column {
+				path = append([]string{itemKey}, path...)
+				column = l.dash
+			}
+
+			continue
+		}
+
+		if l.keyColumn >= 0 && l.keyColumn < column {
+			path = append([]string{l.key}, path...)
+			column = l.keyColumn
+			if l.dash >= 0 {
+				path = append([]string{itemKey}, path...)
+				column, inSequence = l.dash, true
+			}
+		} else if l.dash >= 0 && l.dash < column {
+			path = append([]string{itemKey}, path...)
+			column, inSequence = l.dash, true
+		}
+	}
+
+	return path
+}
+
+// linePath returns the path of the mapping the content of the line belongs to
+func (d *document) linePath(lineIndex int, l *line) []string {
+	if l.dash >= 0 {
+		return append(d.path(lineIndex, l.dash, true), itemKey)
+	}
+
+	return d.path(lineIndex, l.column, false)
+}
+
+// wordAt returns the word at the position and its range. Words consist of all characters except
+// whitespace and yaml syntax characters
+func (d *document) wordAt(position Position) (string, Range) {
+	if position.Line >= len(d.lines) {
+		return "", Range{}
+	}
+
+	text := d.lines[position.Line]
+	isWordChar := func(c byte) bool {
+		return !strings.ContainsRune(" \t:,[]{}\"'#", rune(c))
+	}
+
+	start := minInt(position.Character, len(text))
+	for start > 0 && isWordChar(text[start-1]) {
+		start--
+	}
+	end := minInt(position.Character, len(text))
+	for end < len(text) && isWordChar(text[end]) {
+		end++
+	}
+
+	return text[start:end], Range{
+		Start: Position{Line: position.Line, Character: start},
+		End:   Position{Line: position.Line, Character: end},
+	}
+}
+
+// mappingValue returns the key and value node of the key in the mapping
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// findNode returns the deepest node of the path, e.g. deployments[0].name, that exists in the document
+func findNode(root *yaml.Node, path string) *yaml.Node {
+	found := root
+	node := root
+	for _, element := range strings.Split(strings.ReplaceAll(path, "[", ".["), ".") {
+		if element == "" {
+			continue
+		} else if strings.HasPrefix(element, "[") {
+			index, err := strconv.Atoi(strings.Trim(element, "[]"))
+			if err != nil || node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return found
+			}
+
+			node = node.Content[index]
+			found = node
+			continue
+		}
+
+		key, value := mappingValue(node, element)
+		if key == nil {
+			return found
+		}
+
+		node, found = value, key
+	}
+
+	return found
+}
+
+// nodeRange returns the range of the scalar node or the first line of other nodes
+func nodeRange(node *yaml.Node) Range {
+	start := Position{Line: node.Line - 1, Character: node.Column - 1}
+	length := len(node.Value)
+	if node.Style == yaml.DoubleQuotedStyle || node.Style == yaml.SingleQuotedStyle {
+		length += 2
+	}
+	if node.Kind != yaml.ScalarNode || strings.Contains(node.Value, "\n") {
+		length = 0
+	}
+
+	return Range{Start: start, End: Position{Line: start.Line, Character: start.Character + length}}
+}
+
+// contains returns true if the position is within the range
+func (r Range) contains(position Position) bool {
+	if position.Line < r.Start.Line || position.Line > r.End.Line {
+		return false
+	} else if position.Line == r.Start.Line && position.Character < r.Start.Character {
+		return false
+	} else if position.Line == r.End.Line && position.Character > r.End.Character {
+		return false
+	}
+
+	return true
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,101 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"gopkg.in/yaml.v3"
+)
+
+// hover returns the documentation of the key or the definition of the variable at the position
+func (s *Server) hover(doc *document, position Position) *Hover {
+	if name, r := doc.variableAt(position); name != "" {
+		return variableHover(doc, name, r)
+	}
+
+	if position.Line >= len(doc.lines) {
+		return nil
+	}
+
+	l := parseLine(doc.lines[position.Line])
+	keyRange := Range{
+		Start: Position{Line: position.Line, Character: l.keyColumn},
+		End:   Position{Line: position.Line, Character: l.keyColumn + len(l.key)},
+	}
+	if l.keyColumn < 0 || !keyRange.contains(position) {
+		return nil
+	}
+
+	property := s.lookup(append(doc.linePath(position.Line, l), l.key))
+	if property == nil {
+		return nil
+	}
+
+	value := fmt.Sprintf("**%s** `%s`", l.key, s.typeName(property))
+	if property.Description != "" {
+		value += "\n\n" + property.Description
+	}
+
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: value},
+		Range:    &keyRange,
+	}
+}
+
+func variableHover(doc *document, name string, r Range) *Hover {
+	value := ""
+	if definition := doc.variableDefinition(name); definition != nil {
+		out, err := yaml.Marshal(definition)
+		if err == nil {
+			value = fmt.Sprintf("**%s** variable\n\n```yaml\n%s```", name, out)
+		}
+	} else if variable.IsPredefinedVariable(name) {
+		value = fmt.Sprintf("**%s** is a predefined variable", name)
+	} else {
+		value = fmt.Sprintf("**%s** is not defined in vars and will be asked for or loaded from the environment", name)
+	}
+
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: value},
+		Range:    &r,
+	}
+}
+
+// variableAt returns the name and range of the variable at the position, e.g. ${IMAGE}
+func (d *document) variableAt(position Position) (string, Range) {
+	if position.Line >= len(d.lines) {
+		return "", Range{}
+	}
+
+	text := d.lines[position.Line]
+	for _, match := range varspkg.VarMatchRegex.FindAllStringIndex(text, -1) {
+		r := Range{
+			Start: Position{Line: position.Line, Character: match[0]},
+			End:   Position{Line: position.Line, Character: match[1]},
+		}
+		if r.contains(position) {
+			return strings.Trim(text[match[0]:match[1]], "$!{}"), r
+		}
+	}
+
+	return "", Range{}
+}
+
+// variableDefinition returns the definition of the variable in the vars section or nil if there is none
+func (d *document) variableDefinition(name string) *yaml.Node {
+	_, vars := mappingValue(d.root, "vars")
+	if vars == nil {
+		return nil
+	}
+
+	for _, definition := range vars.Content {
+		_, nameNode := mappingValue(definition, "name")
+		if nameNode != nil && nameNode.Value == name {
+			return definition
+		}
+	}
+
+	return nil
+}
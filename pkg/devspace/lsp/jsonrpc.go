@@ -0,0 +1,121 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidParams  = -32602
+	codeMethodNotFound = -32601
+	codeInternalError  = -32603
+)
+
+// message is a JSON-RPC request, response or notification. Notifications have no id
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+// responseError is the error of a failed request
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+// readMessage reads a message with its Content-Length header from the reader
+func readMessage(reader *bufio.Reader) (*message, error) {
+	contentLength := -1
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		header := strings.SplitN(line, ":", 2)
+		if len(header) == 2 && strings.EqualFold(strings.TrimSpace(header[0]), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(header[1]))
+			if err != nil {
+				return nil, errors.Wrap(err, "parse Content-Length")
+			}
+		}
+	}
+	if contentLength < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	_, err := io.ReadFull(reader, body)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &message{}
+	err = json.Unmarshal(body, msg)
+	if err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+
+	return msg, nil
+}
+
+// writeMessage writes the message with its Content-Length header to the writer
+func writeMessage(writer io.Writer, msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+// newResponse creates the response to the request with the given id. Either the result or the error is set
+func newResponse(id *json.RawMessage, result interface{}, err error) map[string]interface{} {
+	response := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+	}
+	if err != nil {
+		respErr, ok := err.(*responseError)
+		if !ok {
+			respErr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+
+		response["error"] = respErr
+	} else {
+		response["result"] = result
+	}
+
+	return response
+}
+
+// newNotification creates a notification that is sent from the server to the editor
+func newNotification(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	}
+}
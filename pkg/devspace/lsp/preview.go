@@ -0,0 +1,80 @@
+package lsp
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/pkg/errors"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+// codeLenses returns a preview command for every profile of the document
+func (s *Server) codeLenses(doc *document) []CodeLens {
+	lenses := []CodeLens{}
+	_, profiles := mappingValue(doc.root, "profiles")
+	if profiles == nil {
+		return lenses
+	}
+
+	for _, profile := range profiles.Content {
+		_, name := mappingValue(profile, "name")
+		if name == nil {
+			continue
+		}
+
+		lenses = append(lenses, CodeLens{
+			Range: nodeRange(name),
+			Command: &Command{
+				Title:     "Preview config with profile " + name.Value,
+				Command:   CommandPreviewProfile,
+				Arguments: []interface{}{doc.uri, name.Value},
+			},
+		})
+	}
+
+	return lenses
+}
+
+// executeCommand executes the preview command and returns the config with the profile applied as yaml
+func (s *Server) executeCommand(params *ExecuteCommandParams) (interface{}, error) {
+	if params.Command != CommandPreviewProfile {
+		return nil, &responseError{Code: codeInvalidParams, Message: "command " + params.Command + " is not supported"}
+	} else if len(params.Arguments) != 2 {
+		return nil, &responseError{Code: codeInvalidParams, Message: "expected the document uri and the profile name as arguments"}
+	}
+
+	uri, profile := "", ""
+	err := json.Unmarshal(params.Arguments[0], &uri)
+	if err == nil {
+		err = json.Unmarshal(params.Arguments[1], &profile)
+	}
+	if err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	doc, ok := s.documents[uri]
+	if !ok {
+		return nil, &responseError{Code: codeInvalidParams, Message: "document " + uri + " is not opened"}
+	} else if doc.parseErr != nil {
+		return nil, errors.Wrap(doc.parseErr, "parse config")
+	}
+
+	data := map[interface{}]interface{}{}
+	err = yaml2.Unmarshal([]byte(doc.text), &data)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := loader.PreviewProfile(filepath.Dir(uriToPath(uri)), data, profile, s.log)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := yaml2.Marshal(preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(out), nil
+}
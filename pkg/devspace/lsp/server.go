@@ -0,0 +1,235 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/schema"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// CommandPreviewProfile is the command that returns the config with a profile applied
+const CommandPreviewProfile = "devspace.previewProfile"
+
+// Server is a language server for devspace.yaml files that communicates over a reader and writer,
+// usually stdin and stdout
+type Server struct {
+	reader *bufio.Reader
+	writer io.Writer
+	log    log.Logger
+
+	schema    *schema.Schema
+	documents map[string]*document
+	shutdown  bool
+}
+
+// NewServer creates a new language server
+func NewServer(reader io.Reader, writer io.Writer, log log.Logger) *Server {
+	return &Server{
+		reader:    bufio.NewReader(reader),
+		writer:    writer,
+		log:       log,
+		schema:    schema.NewConfigSchema(),
+		documents: map[string]*document{},
+	}
+}
+
+// Run handles the messages of the editor until it sends the exit notification or closes the reader
+func (s *Server) Run() error {
+	for {
+		msg, err := readMessage(s.reader)
+		if err == io.EOF {
+			return nil
+		} else if respErr, ok := err.(*responseError); ok {
+			err = writeMessage(s.writer, newResponse(nil, nil, respErr))
+			if err != nil {
+				return err
+			}
+
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "read message")
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+
+			return nil
+		}
+
+		result, err := s.handle(msg)
+		if msg.ID == nil {
+			if err != nil {
+				s.log.Warnf("Error handling %s: %v", msg.Method, err)
+			}
+
+			continue
+		}
+
+		err = writeMessage(s.writer, newResponse(msg.ID, result, err))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (interface{}, error) {
+	switch msg.Method {
+	case "initialize":
+		return &InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync:       TextDocumentSyncKindFull,
+				CompletionProvider:     &CompletionOptions{TriggerCharacters: []string{":", "{", " "}},
+				HoverProvider:          true,
+				DefinitionProvider:     true,
+				CodeLensProvider:       &CodeLensOptions{},
+				ExecuteCommandProvider: &ExecuteCommandOptions{Commands: []string{CommandPreviewProfile}},
+			},
+			ServerInfo: ServerInfo{Name: "devspace"},
+		}, nil
+	case "initialized", "$/cancelRequest", "textDocument/didSave":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		params := &DidOpenTextDocumentParams{}
+		err := unmarshalParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, s.open(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		params := &DidChangeTextDocumentParams{}
+		err := unmarshalParams(msg, params)
+		if err != nil {
+			return nil, err
+		} else if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+
+		return nil, s.open(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		params := &DidCloseTextDocumentParams{}
+		err := unmarshalParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(s.documents, params.TextDocument.URI)
+		return nil, writeMessage(s.writer, newNotification("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}}))
+	case "textDocument/completion":
+		params := &TextDocumentPositionParams{}
+		doc, err := s.documentParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CompletionList{Items: s.completion(doc, params.Position)}, nil
+	case "textDocument/hover":
+		params := &TextDocumentPositionParams{}
+		doc, err := s.documentParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.hover(doc, params.Position), nil
+	case "textDocument/definition":
+		params := &TextDocumentPositionParams{}
+		doc, err := s.documentParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.definition(doc, params.Position), nil
+	case "textDocument/codeLens":
+		params := &CodeLensParams{}
+		doc, err := s.documentParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.codeLenses(doc), nil
+	case "workspace/executeCommand":
+		params := &ExecuteCommandParams{}
+		err := unmarshalParams(msg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.executeCommand(params)
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method " + msg.Method + " is not supported"}
+}
+
+// open stores the content of the document and publishes its diagnostics
+func (s *Server) open(uri, text string) error {
+	doc := newDocument(uri, text)
+	if old, ok := s.documents[uri]; ok && doc.root == nil {
+		// keep the last valid yaml for completions while the user is typing
+		doc.root = old.root
+	}
+
+	s.documents[uri] = doc
+	return writeMessage(s.writer, newNotification("textDocument/publishDiagnostics", &PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: s.diagnostics(doc),
+	}))
+}
+
+// documentParams unmarshals the params of a request for a document and returns the opened document
+func (s *Server) documentParams(msg *message, params interface{}) (*document, error) {
+	err := unmarshalParams(msg, params)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := ""
+	switch p := params.(type) {
+	case *TextDocumentPositionParams:
+		uri = p.TextDocument.URI
+	case *CodeLensParams:
+		uri = p.TextDocument.URI
+	}
+
+	doc, ok := s.documents[uri]
+	if !ok {
+		return nil, &responseError{Code: codeInvalidParams, Message: "document " + uri + " is not opened"}
+	}
+
+	return doc, nil
+}
+
+func unmarshalParams(msg *message, params interface{}) error {
+	err := json.Unmarshal(msg.Params, params)
+	if err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+// uriToPath converts a file uri to a local path
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return uri
+	}
+
+	path := parsed.Path
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		// windows paths look like file:///c:/project/devspace.yaml
+		path = path[1:]
+	}
+
+	return filepath.FromSlash(strings.TrimSpace(path))
+}
@@ -0,0 +1,182 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+const testURI = "file:///project/devspace.yaml"
+
+const testConfig = `version: v1beta10
+vars:
+- name: IMAGE
+  value: john/devbackend
+images:
+  backend:
+    image: ${IMAGE}
+deployments:
+- name: backend
+  helm:
+    componentChart: true
+    values:
+      containers:
+      - image: john/devbackend
+dev:
+  ports:
+  - imageName: backend
+    forward:
+    - port: 8080
+  sync:
+  - imageSelector: image(backend):tag(backend)
+    
+profiles:
+- name: production
+  patches:
+  - op: replace
+    path: images.backend.image
+    value: john/prod
+`
+
+type testRequest struct {
+	id     int
+	method string
+	params interface{}
+}
+
+func position(line, character int) *TextDocumentPositionParams {
+	return &TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: testURI},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func TestServer(t *testing.T) {
+	requests := []testRequest{
+		{id: 1, method: "initialize", params: map[string]interface{}{}},
+		{method: "initialized", params: map[string]interface{}{}},
+		{method: "textDocument/didOpen", params: &DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: testURI, LanguageID: "yaml", Text: testConfig}}},
+		{id: 2, method: "textDocument/completion", params: position(21, 4)},
+		{id: 3, method: "textDocument/completion", params: position(16, 15)},
+		{id: 4, method: "textDocument/hover", params: position(20, 6)},
+		{id: 5, method: "textDocument/definition", params: position(6, 14)},
+		{id: 6, method: "textDocument/definition", params: position(16, 18)},
+		{id: 7, method: "textDocument/definition", params: position(20, 22)},
+		{id: 8, method: "textDocument/codeLens", params: &CodeLensParams{TextDocument: TextDocumentIdentifier{URI: testURI}}},
+		{id: 9, method: "workspace/executeCommand", params: &ExecuteCommandParams{Command: CommandPreviewProfile, Arguments: []json.RawMessage{json.RawMessage(`"` + testURI + `"`), json.RawMessage(`"production"`)}}},
+		{method: "textDocument/didChange", params: &DidChangeTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: testURI}, ContentChanges: []TextDocumentContentChangeEvent{{Text: strings.Replace(testConfig, "images:", "imagess:", 1)}}}},
+		{id: 10, method: "unknown/method", params: map[string]interface{}{}},
+		{id: 11, method: "shutdown"},
+		{method: "exit"},
+	}
+
+	in := &bytes.Buffer{}
+	for _, request := range requests {
+		msg := map[string]interface{}{"jsonrpc": "2.0", "method": request.method, "params": request.params}
+		if request.id != 0 {
+			msg["id"] = request.id
+		}
+
+		assert.NilError(t, writeMessage(in, msg))
+	}
+
+	out := &bytes.Buffer{}
+	err := NewServer(in, out, log.Discard).Run()
+	assert.NilError(t, err)
+
+	responses := map[string]*message{}
+	diagnostics := []*PublishDiagnosticsParams{}
+	reader := bufio.NewReader(out)
+	for {
+		msg, err := readMessage(reader)
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+
+		if msg.Method == "textDocument/publishDiagnostics" {
+			params := &PublishDiagnosticsParams{}
+			assert.NilError(t, json.Unmarshal(msg.Params, params))
+			diagnostics = append(diagnostics, params)
+		} else {
+			responses[string(*msg.ID)] = msg
+		}
+	}
+
+	initializeResult := &InitializeResult{}
+	assert.NilError(t, json.Unmarshal(responses["1"].Result, initializeResult))
+	assert.Equal(t, initializeResult.Capabilities.HoverProvider, true)
+
+	// the opened document is valid, the changed one has an unknown field
+	assert.Equal(t, len(diagnostics), 2)
+	assert.DeepEqual(t, diagnostics[0].Diagnostics, []Diagnostic{})
+	assert.Equal(t, len(diagnostics[1].Diagnostics), 1)
+	assert.Equal(t, diagnostics[1].Diagnostics[0].Message, "imagess: unknown field imagess, did you mean images?")
+	assert.Equal(t, diagnostics[1].Diagnostics[0].Range, Range{Start: Position{Line: 4}, End: Position{Line: 4, Character: 7}})
+
+	// keys of the sync config that are not used yet
+	completion := &CompletionList{}
+	assert.NilError(t, json.Unmarshal(responses["2"].Result, completion))
+	labels := map[string]bool{}
+	for _, item := range completion.Items {
+		labels[item.Label] = true
+	}
+	assert.Assert(t, labels["localSubPath"], "missing localSubPath in %v", labels)
+	assert.Assert(t, !labels["imageSelector"], "imageSelector is already used")
+
+	// image names for imageName
+	completion = &CompletionList{}
+	assert.NilError(t, json.Unmarshal(responses["3"].Result, completion))
+	assert.Equal(t, len(completion.Items), 1)
+	assert.Equal(t, completion.Items[0].Label, "backend")
+
+	hover := &Hover{}
+	assert.NilError(t, json.Unmarshal(responses["4"].Result, hover))
+	assert.Assert(t, strings.HasPrefix(hover.Contents.Value, "**imageSelector** `string`"), hover.Contents.Value)
+
+	// variable and image definitions
+	expectedLocations := map[string][]Location{
+		"5": {{URI: testURI, Range: Range{Start: Position{Line: 2, Character: 8}, End: Position{Line: 2, Character: 13}}}},
+		"6": {{URI: testURI, Range: Range{Start: Position{Line: 5, Character: 2}, End: Position{Line: 5, Character: 9}}}},
+		"7": {{URI: testURI, Range: Range{Start: Position{Line: 5, Character: 2}, End: Position{Line: 5, Character: 9}}}},
+	}
+	for id, expected := range expectedLocations {
+		locations := []Location{}
+		assert.NilError(t, json.Unmarshal(responses[id].Result, &locations))
+		assert.DeepEqual(t, locations, expected)
+	}
+
+	lenses := []CodeLens{}
+	assert.NilError(t, json.Unmarshal(responses["8"].Result, &lenses))
+	assert.Equal(t, len(lenses), 1)
+	assert.DeepEqual(t, lenses[0].Command.Arguments, []interface{}{testURI, "production"})
+
+	preview := ""
+	assert.NilError(t, json.Unmarshal(responses["9"].Result, &preview))
+	assert.Assert(t, strings.Contains(preview, "image: john/prod"), preview)
+	assert.Assert(t, !strings.Contains(preview, "profiles"), preview)
+
+	assert.Equal(t, responses["10"].Error.Code, codeMethodNotFound)
+}
+
+func TestPath(t *testing.T) {
+	doc := newDocument(testURI, testConfig)
+	testCases := map[int][]string{
+		3:  {"vars", itemKey},
+		6:  {"images", "backend"},
+		13: {"deployments", itemKey, "helm", "values", "containers", itemKey},
+		18: {"dev", "ports", itemKey, "forward", itemKey},
+		21: {"dev", "sync", itemKey},
+		26: {"profiles", itemKey, "patches", itemKey},
+	}
+
+	for lineIndex, expected := range testCases {
+		assert.DeepEqual(t, doc.linePath(lineIndex, parseLine(doc.lines[lineIndex])), expected)
+	}
+}
@@ -0,0 +1,439 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// The manifest media types we accept when requesting a manifest from the registry
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+var linkRegEx = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Tag is a single tag of a repository
+type Tag struct {
+	Name    string
+	Digest  string
+	Created time.Time
+}
+
+// Client talks to a docker registry via the Docker Registry HTTP API v2
+type Client interface {
+	// ListTags returns the names of all tags of the repository
+	ListTags(repository string) ([]string, error)
+
+	// GetTag returns the manifest digest and the creation date of the tagged image
+	GetTag(repository, tag string) (*Tag, error)
+
+	// DeleteManifest deletes the manifest with the given digest and all tags that reference it
+	DeleteManifest(repository, digest string) error
+}
+
+type client struct {
+	endpoint   string
+	authConfig *types.AuthConfig
+	httpClient *http.Client
+
+	tokensMutex sync.Mutex
+	tokens      map[string]string
+	scopes      map[string]string
+}
+
+// NewClient creates a new registry client for the given registry host (e.g. my-registry.com:5000). The auth config
+// is optional and can be retrieved with docker.Client.GetAuthConfig
+func NewClient(host string, authConfig *types.AuthConfig) Client {
+	if host == "" || host == "docker.io" || host == "index.docker.io" {
+		host = "registry-1.docker.io"
+	}
+	if authConfig == nil {
+		authConfig = &types.AuthConfig{}
+	}
+
+	scheme := "https"
+	if isLocalhost(host) {
+		scheme = "http"
+	}
+
+	return &client{
+		endpoint:   scheme + "://" + host,
+		authConfig: authConfig,
+		httpClient: &http.Client{Timeout: time.Minute},
+		tokens:     map[string]string{},
+		scopes:     map[string]string{},
+	}
+}
+
+func isLocalhost(host string) bool {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+
+	if hostname == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(hostname)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ListTags implements interface
+func (c *client) ListTags(repository string) ([]string, error) {
+	tags := []string{}
+	next := c.endpoint + "/v2/" + repository + "/tags/list?n=1000"
+	for next != "" {
+		resp, err := c.request(http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		list := struct {
+			Tags []string `json:"tags"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode tags of %s", repository)
+		}
+
+		tags = append(tags, list.Tags...)
+		next, err = c.nextLink(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+func (c *client) nextLink(resp *http.Response) (string, error) {
+	matches := linkRegEx.FindStringSubmatch(resp.Header.Get("Link"))
+	if len(matches) != 2 {
+		return "", nil
+	}
+
+	base, err := url.Parse(c.endpoint)
+	if err != nil {
+		return "", err
+	}
+	link, err := base.Parse(matches[1])
+	if err != nil {
+		return "", errors.Wrap(err, "parse next link")
+	}
+
+	return link.String(), nil
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Config    *struct {
+		Digest string `json:"digest"`
+	} `json:"config,omitempty"`
+	Manifests []struct {
+		Digest string `json:"digest"`
+	} `json:"manifests,omitempty"`
+}
+
+// GetTag implements interface
+func (c *client) GetTag(repository, tag string) (*Tag, error) {
+	digest, m, err := c.getManifest(repository, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// for manifest lists and image indexes we use the config of the first image
+	if m.Config == nil && len(m.Manifests) > 0 {
+		_, m, err = c.getManifest(repository, m.Manifests[0].Digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created := time.Time{}
+	if m.Config != nil && m.Config.Digest != "" {
+		resp, err := c.request(http.MethodGet, c.endpoint+"/v2/"+repository+"/blobs/"+m.Config.Digest, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		config := struct {
+			Created time.Time `json:"created"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&config)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode image config of %s:%s", repository, tag)
+		}
+
+		created = config.Created
+	}
+
+	return &Tag{
+		Name:    tag,
+		Digest:  digest,
+		Created: created,
+	}, nil
+}
+
+func (c *client) getManifest(repository, reference string) (string, *manifest, error) {
+	resp, err := c.request(http.MethodGet, c.endpoint+"/v2/"+repository+"/manifests/"+reference, map[string]string{
+		"Accept": strings.Join(manifestMediaTypes, ", "),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	out, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	m := &manifest{}
+	err = json.Unmarshal(out, m)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "decode manifest of %s:%s", repository, reference)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(out))
+	}
+
+	return digest, m, nil
+}
+
+// DeleteManifest implements interface
+func (c *client) DeleteManifest(repository, digest string) error {
+	resp, err := c.request(http.MethodDelete, c.endpoint+"/v2/"+repository+"/manifests/"+digest, nil)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+	return nil
+}
+
+// request executes the request and authenticates against the registry if necessary. Only
+// successful responses are returned, the caller has to close the response body
+func (c *client) request(method, rawURL string, headers map[string]string) (*http.Response, error) {
+	// try the scope of the last request with the same method first to avoid an authentication roundtrip
+	c.tokensMutex.Lock()
+	scope := c.scopes[method]
+	c.tokensMutex.Unlock()
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(method, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		c.authorize(req, scope)
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && i == 0 {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+
+			scope, err = c.authenticate(challenge)
+			if err != nil {
+				return nil, err
+			}
+
+			c.tokensMutex.Lock()
+			c.scopes[method] = scope
+			c.tokensMutex.Unlock()
+
+			continue
+		} else if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+			resp.Body.Close()
+			return nil, errors.Errorf("%s %s: unexpected status code %d: %s", method, rawURL, resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+
+		return resp, nil
+	}
+
+	return nil, errors.Errorf("%s %s: unauthorized", method, rawURL)
+}
+
+func (c *client) authorize(req *http.Request, scope string) {
+	c.tokensMutex.Lock()
+	token, ok := c.tokens[scope]
+	c.tokensMutex.Unlock()
+
+	if ok {
+		if token == "" {
+			req.SetBasicAuth(c.authConfig.Username, c.authConfig.Password)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
+
+// authenticate handles the authentication challenge of the registry and returns the scope
+// the obtained credentials are valid for
+func (c *client) authenticate(challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.authConfig.Username == "" {
+			return "", errors.Errorf("registry %s requires authentication, please run 'docker login'", c.endpoint)
+		}
+
+		c.tokensMutex.Lock()
+		c.tokens["basic"] = ""
+		c.tokensMutex.Unlock()
+		return "basic", nil
+	case "bearer":
+		scope := params["scope"]
+		token, err := c.fetchToken(params["realm"], params["service"], scope)
+		if err != nil {
+			return "", err
+		}
+
+		c.tokensMutex.Lock()
+		c.tokens[scope] = token
+		c.tokensMutex.Unlock()
+		return scope, nil
+	}
+
+	return "", errors.Errorf("unsupported authentication challenge from registry %s: %s", c.endpoint, challenge)
+}
+
+func (c *client) fetchToken(realm, service, scope string) (string, error) {
+	if realm == "" {
+		return "", errors.Errorf("registry %s returned an authentication challenge without realm", c.endpoint)
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	if c.authConfig.IdentityToken != "" {
+		// exchange the identity token (OAuth2 refresh token) for an access token
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", c.authConfig.IdentityToken)
+		form.Set("service", service)
+		form.Set("scope", scope)
+		form.Set("client_id", "devspace")
+		resp, err = c.httpClient.PostForm(realm, form)
+	} else {
+		var req *http.Request
+		req, err = http.NewRequest(http.MethodGet, realm, nil)
+		if err != nil {
+			return "", err
+		}
+
+		query := req.URL.Query()
+		if service != "" {
+			query.Set("service", service)
+		}
+		if scope != "" {
+			query.Set("scope", scope)
+		}
+		req.URL.RawQuery = query.Encode()
+		if c.authConfig.Username != "" {
+			req.SetBasicAuth(c.authConfig.Username, c.authConfig.Password)
+		}
+
+		resp, err = c.httpClient.Do(req)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "request registry token")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("request registry token: unexpected status code %d", resp.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return "", errors.Wrap(err, "decode registry token")
+	}
+
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+
+	return token.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	challenge = strings.TrimSpace(challenge)
+	idx := strings.Index(challenge, " ")
+	if idx == -1 {
+		return challenge, params
+	}
+
+	scheme := challenge[:idx]
+	rest := challenge[idx+1:]
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq == -1 {
+			break
+		}
+
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = strings.TrimSpace(rest[eq+1:])
+
+		value := ""
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end == -1 {
+				value = rest[1:]
+				rest = ""
+			} else {
+				value = rest[1 : end+1]
+				rest = rest[end+2:]
+			}
+		} else {
+			end := strings.Index(rest, ",")
+			if end == -1 {
+				value = rest
+				rest = ""
+			} else {
+				value = rest[:end]
+				rest = rest[end:]
+			}
+		}
+
+		params[key] = value
+		rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+		rest = strings.TrimSpace(rest)
+	}
+
+	return scheme, params
+}
@@ -0,0 +1,102 @@
+package registry
+
+import (
+	"sort"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// PruneOptions describe which tags of a repository should be kept
+type PruneOptions struct {
+	// Keep is the number of most recent tags to keep
+	Keep int
+
+	// ProtectedTags and ProtectedDigests are never deleted, e.g. because
+	// they are referenced by running containers
+	ProtectedTags    map[string]bool
+	ProtectedDigests map[string]bool
+
+	// DryRun only returns the tags that would be deleted
+	DryRun bool
+}
+
+// Prune deletes all tags of the repository that are not protected and not among the most recent
+// tags. It returns the tags that were deleted or would be deleted in a dry run
+func Prune(client Client, repository string, options *PruneOptions, log log.Logger) ([]*Tag, error) {
+	names, err := client.ListTags(repository)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list tags of %s", repository)
+	}
+
+	tags := make([]*Tag, 0, len(names))
+	for _, name := range names {
+		tag, err := client.GetTag(repository, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get tag %s:%s", repository, name)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	toDelete := SelectTagsToDelete(tags, options)
+	if options.DryRun {
+		return toDelete, nil
+	}
+
+	// deleting a manifest removes all tags that point to it, so we only delete each digest once
+	deleted := []*Tag{}
+	deletedDigests := map[string]bool{}
+	for _, tag := range toDelete {
+		if deletedDigests[tag.Digest] == false {
+			err = client.DeleteManifest(repository, tag.Digest)
+			if err != nil {
+				return deleted, errors.Wrapf(err, "delete %s:%s", repository, tag.Name)
+			}
+
+			deletedDigests[tag.Digest] = true
+		}
+
+		log.Debugf("Deleted %s:%s (%s)", repository, tag.Name, tag.Digest)
+		deleted = append(deleted, tag)
+	}
+
+	return deleted, nil
+}
+
+// SelectTagsToDelete returns all tags that are neither protected nor among the options.Keep most
+// recent tags. Tags that share their digest with a tag that is kept are never returned, because
+// deleting the manifest would also remove the kept tag.
+func SelectTagsToDelete(tags []*Tag, options *PruneOptions) []*Tag {
+	sorted := make([]*Tag, len(tags))
+	copy(sorted, tags)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Created.Equal(sorted[j].Created) {
+			return sorted[i].Name > sorted[j].Name
+		}
+
+		return sorted[i].Created.After(sorted[j].Created)
+	})
+
+	keepDigests := map[string]bool{}
+	candidates := []*Tag{}
+	for i, tag := range sorted {
+		if i < options.Keep || options.ProtectedTags[tag.Name] || options.ProtectedDigests[tag.Digest] {
+			keepDigests[tag.Digest] = true
+			continue
+		}
+
+		candidates = append(candidates, tag)
+	}
+
+	toDelete := []*Tag{}
+	for _, tag := range candidates {
+		if keepDigests[tag.Digest] {
+			continue
+		}
+
+		toDelete = append(toDelete, tag)
+	}
+
+	return toDelete
+}
@@ -0,0 +1,175 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+// fakeRegistry implements the parts of the registry api v2 we use with token authentication
+type fakeRegistry struct {
+	m sync.Mutex
+
+	// tag -> digest
+	tags map[string]string
+
+	// digest -> creation time
+	created map[string]time.Time
+
+	deleted []string
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if r.URL.Path == "/token" {
+		user, pass, _ := r.BasicAuth()
+		if user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "token-" + r.URL.Query().Get("scope")})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer token-repository:test/app:pull,delete" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="registry",scope="repository:test/app:pull,delete"`, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/v2/test/app/tags/list":
+		tags := []string{}
+		for tag := range f.tags {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+
+		// return the tags in two pages
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/test/app/tags/list?n=1000&last=page>; rel="next"`)
+			tags = tags[:len(tags)/2]
+		} else {
+			tags = tags[len(tags)/2:]
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "test/app", "tags": tags})
+	case strings.HasPrefix(r.URL.Path, "/v2/test/app/manifests/"):
+		reference := strings.TrimPrefix(r.URL.Path, "/v2/test/app/manifests/")
+		digest, ok := f.tags[reference]
+		if !ok {
+			digest = reference
+		}
+
+		if r.Method == http.MethodDelete {
+			f.deleted = append(f.deleted, digest)
+			for tag, tagDigest := range f.tags {
+				if tagDigest == digest {
+					delete(f.tags, tag)
+				}
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		w.Header().Set("Docker-Content-Digest", digest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+			"config":    map[string]string{"digest": "config-" + digest},
+		})
+	case strings.HasPrefix(r.URL.Path, "/v2/test/app/blobs/config-"):
+		digest := strings.TrimPrefix(r.URL.Path, "/v2/test/app/blobs/config-")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"created": f.created[digest]})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Now()
+	registry := &fakeRegistry{
+		tags: map[string]string{
+			"a":      "sha256:a",
+			"b":      "sha256:b",
+			"c":      "sha256:c",
+			"c-copy": "sha256:c",
+			"d":      "sha256:d",
+			"e":      "sha256:e",
+			"f":      "sha256:f",
+		},
+		created: map[string]time.Time{
+			"sha256:a": now.Add(-6 * time.Hour),
+			"sha256:b": now.Add(-5 * time.Hour),
+			"sha256:c": now.Add(-4 * time.Hour),
+			"sha256:d": now.Add(-3 * time.Hour),
+			"sha256:e": now.Add(-2 * time.Hour),
+			"sha256:f": now.Add(-1 * time.Hour),
+		},
+	}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	client := NewClient(strings.TrimPrefix(server.URL, "http://"), &types.AuthConfig{Username: "user", Password: "pass"})
+	options := &PruneOptions{
+		Keep:             2,
+		ProtectedTags:    map[string]bool{"a": true},
+		ProtectedDigests: map[string]bool{"sha256:c": true},
+		DryRun:           true,
+	}
+
+	// a dry run should not delete anything
+	tags, err := Prune(client, "test/app", options, log.Discard)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tagNames(tags), []string{"d", "b"})
+	assert.Equal(t, len(registry.deleted), 0)
+
+	options.DryRun = false
+	tags, err = Prune(client, "test/app", options, log.Discard)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tagNames(tags), []string{"d", "b"})
+	assert.DeepEqual(t, registry.deleted, []string{"sha256:d", "sha256:b"})
+
+	remaining, err := client.ListTags("test/app")
+	assert.NilError(t, err)
+	assert.Equal(t, len(remaining), 5)
+}
+
+func TestSelectTagsToDelete(t *testing.T) {
+	now := time.Now()
+	tags := []*Tag{
+		{Name: "old", Digest: "sha256:1", Created: now.Add(-3 * time.Hour)},
+		{Name: "old-alias", Digest: "sha256:3", Created: now.Add(-3 * time.Hour)},
+		{Name: "older", Digest: "sha256:2", Created: now.Add(-4 * time.Hour)},
+		{Name: "newest", Digest: "sha256:3", Created: now},
+	}
+
+	// old-alias shares its digest with the newest tag and must not be deleted
+	toDelete := SelectTagsToDelete(tags, &PruneOptions{Keep: 1})
+	assert.DeepEqual(t, tagNames(toDelete), []string{"old", "older"})
+
+	toDelete = SelectTagsToDelete(tags, &PruneOptions{Keep: 0, ProtectedTags: map[string]bool{"older": true}})
+	assert.DeepEqual(t, tagNames(toDelete), []string{"newest", "old-alias", "old"})
+}
+
+func tagNames(tags []*Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,62 @@
+package synccontroller
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// UploadOptions describe a one time upload of a local directory into a container
+type UploadOptions struct {
+	LocalPath     string
+	ContainerPath string
+	ExcludePaths  []string
+	Arch          latest.ContainerArchitecture
+	Verbose       bool
+}
+
+// UploadOnce mirrors the local path into the container path with the sync helper and returns as soon as
+// the initial upload is done. Only changed files are transferred and files that do not exist locally anymore
+// are removed from the container.
+func UploadOnce(client kubectl.Client, pod *v1.Pod, container string, options *UploadOptions, log logpkg.Logger) error {
+	c := &controller{
+		client: client,
+		log:    log,
+	}
+
+	syncClient, err := c.initClient(pod, container, &latest.SyncConfig{
+		LocalSubPath:    options.LocalPath,
+		ContainerPath:   options.ContainerPath,
+		ExcludePaths:    options.ExcludePaths,
+		Arch:            options.Arch,
+		DisableDownload: ptr.Bool(true),
+		InitialSync:     latest.InitialSyncStrategyMirrorLocal,
+	}, options.Verbose, log)
+	if err != nil {
+		return errors.Wrap(err, "init sync")
+	}
+
+	var (
+		onInitUploadDone = make(chan struct{})
+		onDone           = make(chan struct{})
+		onError          = make(chan error, 1)
+	)
+
+	err = syncClient.Start(onInitUploadDone, nil, onDone, onError)
+	if err != nil {
+		return err
+	}
+	defer syncClient.Stop(nil)
+
+	select {
+	case <-onInitUploadDone:
+		return nil
+	case err := <-onError:
+		return errors.Wrap(err, "upload")
+	case <-onDone:
+		return errors.New("sync stopped unexpectedly")
+	}
+}
@@ -0,0 +1,143 @@
+package dockerfile
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Instruction is a single instruction of a Dockerfile
+type Instruction struct {
+	// Line is the line number the instruction starts at, starting with 1
+	Line int
+
+	// Command is the upper case instruction, e.g. FROM or COPY
+	Command string
+
+	// Flags are the leading --key=value flags of the instruction, e.g. --from for COPY
+	Flags map[string]string
+
+	// Args are the arguments of the instruction without the flags
+	Args []string
+}
+
+// Stage is a single build stage of a Dockerfile
+type Stage struct {
+	// Name is the name of the stage in 'FROM image AS name' or empty
+	Name string
+
+	// Image is the base image of the stage
+	Image string
+
+	// From is the FROM instruction of the stage
+	From *Instruction
+
+	// Instructions are all instructions of the stage including the FROM instruction
+	Instructions []*Instruction
+}
+
+// Parse parses the content of a Dockerfile into instructions. Comments and empty lines are skipped,
+// lines ending with a backslash are joined with the next line.
+func Parse(content string) []*Instruction {
+	lines := strings.Split(string(NormalizeNewlines([]byte(content))), "\n")
+	instructions := []*Instruction{}
+
+	current := ""
+	start := 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if current == "" {
+			start = i + 1
+		}
+		if strings.HasSuffix(trimmed, "\\") {
+			current += strings.TrimSuffix(trimmed, "\\") + " "
+			continue
+		}
+
+		current += trimmed
+		instructions = append(instructions, parseInstruction(start, current))
+		current = ""
+	}
+	if strings.TrimSpace(current) != "" {
+		instructions = append(instructions, parseInstruction(start, current))
+	}
+
+	return instructions
+}
+
+func parseInstruction(line int, content string) *Instruction {
+	instruction := &Instruction{
+		Line:  line,
+		Flags: map[string]string{},
+	}
+
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return instruction
+	}
+
+	instruction.Command = strings.ToUpper(fields[0])
+	rest := strings.TrimSpace(content[len(fields[0]):])
+
+	// parse flags
+	for strings.HasPrefix(rest, "--") {
+		idx := strings.IndexAny(rest, " \t")
+		flag := rest
+		if idx == -1 {
+			rest = ""
+		} else {
+			flag = rest[:idx]
+			rest = strings.TrimSpace(rest[idx:])
+		}
+
+		flag = strings.TrimPrefix(flag, "--")
+		if eq := strings.Index(flag, "="); eq != -1 {
+			instruction.Flags[strings.ToLower(flag[:eq])] = flag[eq+1:]
+		} else {
+			instruction.Flags[strings.ToLower(flag)] = ""
+		}
+	}
+
+	// exec (json) or shell form?
+	if strings.HasPrefix(rest, "[") {
+		args := []string{}
+		if err := json.Unmarshal([]byte(rest), &args); err == nil {
+			instruction.Args = args
+			return instruction
+		}
+	}
+
+	instruction.Args = strings.Fields(rest)
+	return instruction
+}
+
+// Stages groups the instructions into build stages. Instructions before the first FROM are ignored
+func Stages(instructions []*Instruction) []*Stage {
+	stages := []*Stage{}
+	for _, instruction := range instructions {
+		if instruction.Command == "FROM" {
+			stage := &Stage{
+				From:         instruction,
+				Instructions: []*Instruction{instruction},
+			}
+			if len(instruction.Args) > 0 {
+				stage.Image = instruction.Args[0]
+			}
+			if len(instruction.Args) > 2 && strings.ToUpper(instruction.Args[1]) == "AS" {
+				stage.Name = instruction.Args[2]
+			}
+
+			stages = append(stages, stage)
+			continue
+		}
+
+		if len(stages) > 0 {
+			stages[len(stages)-1].Instructions = append(stages[len(stages)-1].Instructions, instruction)
+		}
+	}
+
+	return stages
+}
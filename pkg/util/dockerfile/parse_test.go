@@ -0,0 +1,35 @@
+package dockerfile
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParse(t *testing.T) {
+	instructions := Parse(`# syntax=docker/dockerfile:1
+FROM golang:1.15 as build
+
+COPY --chown=1000 --from=base \
+  a.txt b.txt \
+  /app/
+RUN ["go", "build"]
+FROM build
+`)
+
+	assert.Equal(t, len(instructions), 4)
+	assert.Equal(t, instructions[0].Line, 2)
+	assert.Equal(t, instructions[1].Line, 4)
+	assert.Equal(t, instructions[1].Command, "COPY")
+	assert.DeepEqual(t, instructions[1].Flags, map[string]string{"chown": "1000", "from": "base"})
+	assert.DeepEqual(t, instructions[1].Args, []string{"a.txt", "b.txt", "/app/"})
+	assert.DeepEqual(t, instructions[2].Args, []string{"go", "build"})
+
+	stages := Stages(instructions)
+	assert.Equal(t, len(stages), 2)
+	assert.Equal(t, stages[0].Name, "build")
+	assert.Equal(t, stages[0].Image, "golang:1.15")
+	assert.Equal(t, len(stages[0].Instructions), 3)
+	assert.Equal(t, stages[1].Image, "build")
+	assert.Equal(t, stages[1].Name, "")
+}
@@ -3,6 +3,7 @@ package encoding
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"regexp"
 	"strings"
 )
 
@@ -22,3 +23,18 @@ func SafeConcatNameMax(name []string, max int) string {
 	}
 	return fullPath
 }
+
+var invalidDNS1123Chars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// SafeDNS1123Name converts the name into a valid DNS-1123 label. If characters had to be replaced,
+// a short hash of the original name is appended, so that different names don't end up the same,
+// e.g. my_app and my-app
+func SafeDNS1123Name(name string) string {
+	converted := strings.Trim(invalidDNS1123Chars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if converted != name {
+		digest := sha256.Sum256([]byte(name))
+		converted = strings.Trim(converted+"-"+hex.EncodeToString(digest[0:])[0:7], "-")
+	}
+
+	return SafeConcatName(converted)
+}
@@ -0,0 +1,20 @@
+package encoding
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestSafeDNS1123Name(t *testing.T) {
+	assert.Equal(t, SafeDNS1123Name("backend"), "backend")
+
+	names := map[string]bool{}
+	for _, name := range []string{"my_app", "my-app", "App.V2!", "app.v2", "__", "a-very-long-deployment-name-that-exceeds-the-maximum-length-of-a-label"} {
+		converted := SafeDNS1123Name(name)
+		assert.Equal(t, len(validation.IsDNS1123Label(converted)), 0, "%s is not a valid label: %v", converted, validation.IsDNS1123Label(converted))
+		assert.Assert(t, !names[converted], "%s is not unique", converted)
+		names[converted] = true
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/base64"
 	"io"
-	
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/pkg/errors"
 )
 
@@ -92,3 +97,38 @@ func DecryptAES(key, data []byte) ([]byte, error) {
 
 	return plaintext, nil
 }
+
+// LoadOrCreateKey loads the base64 encoded 32 byte key from the given path or generates a new random key
+// and saves it to the path if it does not exist yet
+func LoadOrCreateKey(path string) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode key %s", path)
+		} else if len(key) != 32 {
+			return nil, errors.Errorf("key %s has %d bytes, expected 32 bytes", path, len(key))
+		}
+
+		return key, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ioutil.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
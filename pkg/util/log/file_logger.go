@@ -33,7 +33,7 @@ func GetFileLogger(filename string) Logger {
 		newLogger := &fileLogger{
 			logger: logrus.New(),
 		}
-		newLogger.logger.Formatter = &logrus.JSONFormatter{}
+		newLogger.logger.Formatter = &redactFormatter{&logrus.JSONFormatter{}}
 		newLogger.logger.SetOutput(&lumberjack.Logger{
 			Filename:   Logdir + filename + ".log",
 			MaxAge:     12,
@@ -0,0 +1,65 @@
+package log
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RedactedValue replaces redacted values in the log output
+const RedactedValue = "********"
+
+var redactedValues = map[string]string{}
+var redactedValuesMutex sync.RWMutex
+
+// Redact makes sure the value of the secret with the given name is never printed by any logger, no matter
+// how short it is. A secret that is redacted again replaces its previous value
+func Redact(name, value string) {
+	redactedValuesMutex.Lock()
+	defer redactedValuesMutex.Unlock()
+
+	// an empty value would match everywhere and cannot leak anything
+	if value == "" {
+		delete(redactedValues, name)
+		return
+	}
+
+	redactedValues[name] = value
+}
+
+// RedactString replaces all redacted values within the message
+func RedactString(message string) string {
+	redactedValuesMutex.RLock()
+	defer redactedValuesMutex.RUnlock()
+
+	// longer values are replaced first, because they might contain other redacted values
+	values := make([]string, 0, len(redactedValues))
+	for _, redacted := range redactedValues {
+		values = append(values, redacted)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	for _, redacted := range values {
+		message = strings.Replace(message, redacted, RedactedValue, -1)
+	}
+
+	return message
+}
+
+// redactFormatter redacts the output of the wrapped formatter
+type redactFormatter struct {
+	logrus.Formatter
+}
+
+func (r *redactFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	out, err := r.Formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(RedactString(string(out))), nil
+}
@@ -108,7 +108,7 @@ func (s *stdoutLogger) writeMessage(fnType logFunctionType, message string) {
 			fnInformation.stream.Write([]byte(ansi.Color(formatInt(now.Hour())+":"+formatInt(now.Minute())+":"+formatInt(now.Second())+" ", "white+b")))
 		}
 		fnInformation.stream.Write([]byte(ansi.Color(fnInformation.tag, fnInformation.color)))
-		fnInformation.stream.Write([]byte(message))
+		fnInformation.stream.Write([]byte(RedactString(message)))
 
 		if s.loadingText != nil && fnType != fatalFn {
 			s.loadingText.Start()
@@ -77,7 +77,7 @@ func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
 			panic(err)
 		}
 
-		_, err = s.stream.Write([]byte(message))
+		_, err = s.stream.Write([]byte(RedactString(message)))
 		if err != nil {
 			panic(err)
 		}